@@ -51,7 +51,7 @@ const docTemplate = `{
                                 "data": {
                                     "type": "array",
                                     "items": {
-                                        "$ref": "#/definitions/models.Achievement"
+                                        "$ref": "#/definitions/models.AchievementView"
                                     }
                                 },
                                 "message": {
@@ -92,7 +92,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Student submits a new achievement with supporting documents. Uses hybrid database storage (MongoDB + PostgreSQL).",
+                "description": "Student submits a new achievement with supporting documents. Uses hybrid database storage (MongoDB + PostgreSQL). Category-specific structured fields are sent in details as a JSON object and validated against the JSON Schema of the category (or its nearest parent).",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -113,14 +113,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Achievement category (e.g., Academic Competition, Research, etc.)",
+                        "description": "Achievement category name, code or alias from GET /taxonomy/categories",
                         "name": "category",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Achievement level (Local/Regional/National/International)",
+                        "description": "Achievement level name, code or alias from GET /taxonomy/levels",
                         "name": "level",
                         "in": "formData",
                         "required": true
@@ -138,6 +138,12 @@ const docTemplate = `{
                         "name": "description",
                         "in": "formData"
                     },
+                    {
+                        "type": "string",
+                        "description": "Category-specific structured fields as a JSON object string, e.g. {\\",
+                        "name": "details",
+                        "in": "formData"
+                    },
                     {
                         "type": "file",
                         "description": "Supporting documents (certificates, photos, etc. - multiple files allowed)",
@@ -164,7 +170,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request, missing required fields, or file upload error",
+                        "description": "Invalid request, missing required fields, category or level not in taxonomy, details not matching the category schema (errors lists each violation), or file upload error (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED, FILE_CONTENT_MISMATCH)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -184,6 +190,20 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "413": {
+                        "description": "Storage quota exceeded (code: ACHIEVEMENT_QUOTA_EXCEEDED, STUDENT_QUOTA_EXCEEDED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Malware detected by antivirus scan (code: FILE_INFECTED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal server error - database or file system error",
                         "schema": {
@@ -201,7 +221,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lecturer gets paginated list of achievements from their advisees (students under supervision).",
+                "description": "Lecturer gets paginated list of achievements from their advisees (students under supervision). Supports the same filters and sorting as the all achievements list, in page mode (page/limit) or cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta; a cursor is only valid for the sort_by and sort_order it was issued with. Structured details can be filtered with details.\u003cfield\u003e=value (e.g. details.rank=1), matched as case-insensitive text, number or boolean.",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,6 +246,80 @@ const docTemplate = `{
                         "description": "Items per page (default: 10, max: 100)",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category (case-insensitive)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by level (case-insensitive)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student (user) ID",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student program study",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sort_order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -240,9 +334,12 @@ const docTemplate = `{
                                         "achievements": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Achievement"
+                                                "$ref": "#/definitions/models.AchievementView"
                                             }
                                         },
+                                        "filters": {
+                                            "$ref": "#/definitions/models.AchievementFilter"
+                                        },
                                         "pagination": {
                                             "$ref": "#/definitions/models.PaginationMeta"
                                         }
@@ -257,6 +354,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid filter parameters or cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -288,7 +392,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin gets paginated list of all achievements with filtering and sorting options.",
+                "description": "Paginated list of achievements with combined filtering and sorting. Filters on achievement fields (category, level, date, status) and student fields (program study, academic year, advisor) are applied together so totals and ordering stay correct. Admin sees all achievements, lecturers see their advisees and students see their own. Supports page mode (page/limit) and cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta; a cursor is only valid for the sort_by and sort_order it was issued with. Structured details can be filtered with details.\u003cfield\u003e=value (e.g. details.rank=1), matched as case-insensitive text, number or boolean.",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,7 +402,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get all achievements (Admin)",
+                "summary": "Get all achievements",
                 "parameters": [
                     {
                         "type": "integer",
@@ -314,6 +418,18 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by status (draft, submitted, verified, rejected)",
@@ -322,14 +438,56 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by student ID",
+                        "description": "Filter by category (case-insensitive)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by level (case-insensitive)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student (user) ID",
                         "name": "student_id",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter by student program study",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by advisor (lecturer user) ID",
+                        "name": "advisor_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "default": "created_at",
-                        "description": "Sort by field (default: created_at)",
+                        "description": "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at",
                         "name": "sort_by",
                         "in": "query"
                     },
@@ -353,11 +511,11 @@ const docTemplate = `{
                                         "achievements": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Achievement"
+                                                "$ref": "#/definitions/models.AchievementView"
                                             }
                                         },
                                         "filters": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/models.AchievementFilter"
                                         },
                                         "pagination": {
                                             "type": "object"
@@ -374,7 +532,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid filter parameters",
+                        "description": "Invalid filter parameters or cursor",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -388,7 +546,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires admin access)",
+                        "description": "Insufficient permissions (requires achievements.read)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -404,59 +562,119 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/pending": {
+        "/achievements/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of achievements that are pending verification (submitted status).",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export the achievement list as CSV or XLSX with the same filters (including details.\u003cfield\u003e=value), sorting and access scoping as the list endpoints (admin: all students, lecturer: advisees, student: own achievements). Small exports are streamed directly. Exports with more rows than the sync limit, or requested with async=true, run as a background job; poll GET /exports/{id} and download the file from GET /exports/{id}/download.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Get pending verification achievements",
+                "summary": "Export achievements",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (default: 1)",
-                        "name": "page",
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format (csv, xlsx)",
+                        "name": "format",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page (default: 10, max: 100)",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Always run as a background job",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category (case-insensitive)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by level (case-insensitive)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student (user) ID",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student program study",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by advisor (lecturer user) ID",
+                        "name": "advisor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sort_order",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Pending achievements retrieved successfully",
+                        "description": "Export file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Export job created",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "achievements": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Achievement"
-                                            }
-                                        },
-                                        "pagination": {
-                                            "type": "object"
-                                        }
-                                    }
+                                    "$ref": "#/definitions/models.ExportJob"
                                 },
                                 "message": {
                                     "type": "string"
@@ -467,6 +685,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid format or filter parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -475,14 +700,14 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires achievements.verify)",
+                        "description": "Insufficient permissions (requires achievements.read)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve pending achievements",
+                        "description": "Failed to export achievements",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -491,16 +716,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}": {
-            "get": {
+        "/achievements/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific achievement with access control (owner, admin, or lecturer only).",
+                "description": "Admin imports historical achievements of existing students from a CSV or XLSX file (first sheet). Required columns: external_key (unique key from the source records), student_id (NIM), title, category, level (name, code or alias from the taxonomy), date (YYYY-MM-DD). Optional columns: description, details (JSON object validated against the category schema), status (draft, submitted or verified; default submitted), verified_by (username or NIP of a lecturer/admin, required for verified), verified_at (YYYY-MM-DD, default import time) and documents (paths inside the documents ZIP, separated by \";\"). Rows whose external_key was already imported are skipped, so the same file can be imported again safely. Verified rows receive rubric points. With dry_run=true rows are only validated. For very large document bundles use the CLI: go run . import-achievements --file data.xlsx --documents dokumen.zip.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -508,24 +733,36 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get achievement by ID",
+                "summary": "Import historical achievements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV or XLSX file (max 10MB, max 5000 rows)",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "ZIP bundle with supporting documents referenced in the documents column",
+                        "name": "documents",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file without creating achievements",
+                        "name": "dry_run",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement retrieved successfully",
+                        "description": "Import or dry run report (per-row results and errors)",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "$ref": "#/definitions/models.Achievement"
+                                    "$ref": "#/definitions/models.AchievementImportReport"
                                 },
                                 "message": {
                                     "type": "string"
@@ -536,43 +773,45 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing JWT token",
+                    "400": {
+                        "description": "Missing or unreadable file, unsupported format, invalid header or invalid ZIP",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Access denied - not owner, admin, or lecturer",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Achievement not found",
+                    "403": {
+                        "description": "Admin only",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve achievement data",
+                        "description": "Failed to validate or import achievements",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/achievements/pending": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update achievement information (only if status is draft). Validates ownership and status before updating.",
+                "description": "Get paginated list of achievements that are pending verification (submitted status), longest waiting first. Supports page mode (page/limit) and cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,33 +821,54 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Update achievement",
+                "summary": "Get pending verification achievements",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "description": "Achievement update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SubmitAchievementRequest"
-                        }
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement updated successfully",
+                        "description": "Pending achievements retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "$ref": "#/definitions/models.Achievement"
+                                    "type": "object",
+                                    "properties": {
+                                        "achievements": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AchievementView"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "type": "object"
+                                        }
+                                    }
                                 },
                                 "message": {
                                     "type": "string"
@@ -620,7 +880,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request or achievement cannot be updated (not draft status)",
+                        "description": "Invalid or tampered cursor",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -634,35 +894,30 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied - not owner or insufficient permissions",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Achievement not found",
+                        "description": "Insufficient permissions (requires achievements.verify)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Update operation failed",
+                        "description": "Failed to retrieve pending achievements",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/achievements/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete an achievement (only if status is draft). Validates ownership and status before deletion.",
+                "description": "Full-text search on achievement title and description, ranked by relevance (title matches weigh more), served from the achievement read model so results include student and advisor fields. Matching words are wrapped in \u003cmark\u003e tags in the highlights. Admin searches all achievements, lecturers search their advisees' achievements and students search their own. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by relevance) which returns pagination as models.CursorPaginationMeta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -672,22 +927,71 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Delete achievement",
+                "summary": "Search achievements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Search keywords (prefix a word with - to exclude it, wrap a phrase in quotes)",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement deleted successfully",
+                        "description": "Search results retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pagination": {
+                                            "$ref": "#/definitions/models.PaginationMeta"
+                                        },
+                                        "query": {
+                                            "type": "string"
+                                        },
+                                        "results": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AchievementSearchResult"
+                                            }
+                                        }
+                                    }
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -698,7 +1002,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Achievement cannot be deleted (not draft status)",
+                        "description": "Missing search query, invalid status filter or cursor",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -712,21 +1016,14 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied - not owner or insufficient permissions",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Achievement not found",
+                        "description": "Insufficient permissions (requires achievements.read)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Delete operation failed",
+                        "description": "Search failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -735,16 +1032,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/attachments": {
-            "post": {
+        "/achievements/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload additional files to a draft achievement. Validates file types and handles rollback on errors.",
+                "description": "Get detailed information about a specific achievement with access control (owner, admin, or lecturer only).",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -752,7 +1049,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Upload additional attachments",
+                "summary": "Get achievement by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -760,37 +1057,16 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Additional attachment files (multiple files allowed)",
-                        "name": "attachments",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Attachments uploaded successfully",
+                        "description": "Achievement retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "achievement_id": {
-                                            "type": "string"
-                                        },
-                                        "new_documents": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Document"
-                                            }
-                                        },
-                                        "total_documents": {
-                                            "type": "integer"
-                                        }
-                                    }
+                                    "$ref": "#/definitions/models.Achievement"
                                 },
                                 "message": {
                                     "type": "string"
@@ -801,13 +1077,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request, no files, or upload error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -816,7 +1085,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied or achievement not in draft status",
+                        "description": "Access denied - not owner, admin, or lecturer",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -830,23 +1099,21 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Upload operation failed",
+                        "description": "Failed to retrieve achievement data",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/achievements/{id}/history": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get status change history of an achievement including timestamps and verification/rejection details.",
+                "description": "Update achievement information (only if status is draft). Validates ownership and status before updating. details replaces the structured fields and is validated against the category JSON Schema; when only the category changes, the existing details are validated against the new category.",
                 "consumes": [
                     "application/json"
                 ],
@@ -856,7 +1123,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get achievement history",
+                "summary": "Update achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -864,30 +1131,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Achievement update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SubmitAchievementRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "History retrieved successfully",
+                        "description": "Achievement updated successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "achievement_id": {
-                                            "type": "string"
-                                        },
-                                        "current_status": {
-                                            "type": "string"
-                                        },
-                                        "history": {
-                                            "type": "array",
-                                            "items": {
-                                                "type": "object"
-                                            }
-                                        }
-                                    }
+                                    "$ref": "#/definitions/models.Achievement"
                                 },
                                 "message": {
                                     "type": "string"
@@ -898,6 +1160,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid request, category or level not in taxonomy, details not matching the category schema (errors lists each violation), or achievement cannot be updated (not draft status)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -906,7 +1175,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied - not owner, admin, or lecturer",
+                        "description": "Access denied - not owner or insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -920,23 +1189,21 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve history data",
+                        "description": "Update operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/achievements/{id}/reject": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lecturer rejects a submitted achievement with reason. Changes status from 'submitted' to 'rejected'.",
+                "description": "Soft delete an achievement (only if status is draft). Validates ownership and status before deletion. Attached files are purged by the file garbage collector after the retention period.",
                 "consumes": [
                     "application/json"
                 ],
@@ -946,7 +1213,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Reject achievement",
+                "summary": "Delete achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -954,39 +1221,14 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection request with reason",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "rejection_note": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement rejected successfully",
+                        "description": "Achievement deleted successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "achievement": {
-                                            "$ref": "#/definitions/models.Achievement"
-                                        },
-                                        "reference": {
-                                            "type": "object"
-                                        }
-                                    }
-                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -997,7 +1239,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request, missing rejection note, or achievement cannot be rejected",
+                        "description": "Achievement cannot be deleted (not draft status)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1011,7 +1253,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires achievements.verify)",
+                        "description": "Access denied - not owner or insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1025,7 +1267,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Rejection process failed - database error",
+                        "description": "Delete operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1034,16 +1276,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/review": {
-            "get": {
+        "/achievements/{id}/attachments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lecturer reviews detailed information of an achievement for verification process.",
+                "description": "Upload additional files to a draft achievement. Validates file content against its extension, enforces per-achievement and per-student storage quotas, and handles rollback on errors.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -1051,7 +1293,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Review achievement detail",
+                "summary": "Upload additional attachments",
                 "parameters": [
                     {
                         "type": "string",
@@ -1059,22 +1301,35 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Additional attachment files (multiple files allowed)",
+                        "name": "attachments",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement details retrieved successfully",
+                        "description": "Attachments uploaded successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "achievement": {
-                                            "$ref": "#/definitions/models.Achievement"
+                                        "achievement_id": {
+                                            "type": "string"
                                         },
-                                        "reference": {
-                                            "type": "object"
+                                        "new_documents": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Document"
+                                            }
+                                        },
+                                        "total_documents": {
+                                            "type": "integer"
                                         }
                                     }
                                 },
@@ -1087,6 +1342,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid request, no files, or upload error (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED, FILE_CONTENT_MISMATCH)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -1095,7 +1357,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires achievements.verify)",
+                        "description": "Access denied or achievement not in draft status",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1108,8 +1370,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "413": {
+                        "description": "Storage quota exceeded (code: ACHIEVEMENT_QUOTA_EXCEEDED, STUDENT_QUOTA_EXCEEDED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Malware detected by antivirus scan (code: FILE_INFECTED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
-                        "description": "Failed to retrieve achievement details",
+                        "description": "Upload operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1118,14 +1394,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/submit": {
-            "post": {
+        "/achievements/{id}/documents/{docId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Submit a draft achievement for verification by lecturer. Changes status from 'draft' to 'submitted'.",
+                "description": "Remove a document from a draft achievement. The file is deleted from storage after the achievement is updated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1135,7 +1411,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Submit achievement for verification",
+                "summary": "Delete attachment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1143,11 +1419,18 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement submitted successfully",
+                        "description": "Attachment deleted successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1157,11 +1440,11 @@ const docTemplate = `{
                                         "achievement_id": {
                                             "type": "string"
                                         },
-                                        "status": {
+                                        "document_id": {
                                             "type": "string"
                                         },
-                                        "updated_at": {
-                                            "type": "string"
+                                        "total_documents": {
+                                            "type": "integer"
                                         }
                                     }
                                 },
@@ -1175,7 +1458,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Achievement cannot be submitted (not draft status)",
+                        "description": "Achievement not in draft status",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1196,14 +1479,14 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Achievement not found",
+                        "description": "Achievement or document not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Submission process failed - database error",
+                        "description": "Delete operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1212,14 +1495,77 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/verify": {
-            "post": {
+        "/achievements/{id}/documents/{docId}/thumbnail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the server-generated thumbnail of an image document (JPEG/PNG). Access control: owner, admin, or the student's advisor.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Get document thumbnail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Thumbnail image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied - not owner, admin, or the student's advisor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement, document, or thumbnail not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lecturer approves a submitted achievement. Changes status from 'submitted' to 'verified'.",
+                "description": "Get status change history of an achievement including timestamps and verification/rejection details.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1229,7 +1575,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Approve achievement",
+                "summary": "Get achievement history",
                 "parameters": [
                     {
                         "type": "string",
@@ -1241,18 +1587,24 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement approved successfully",
+                        "description": "History retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "achievement": {
-                                            "$ref": "#/definitions/models.Achievement"
+                                        "achievement_id": {
+                                            "type": "string"
                                         },
-                                        "reference": {
-                                            "type": "object"
+                                        "current_status": {
+                                            "type": "string"
+                                        },
+                                        "history": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "object"
+                                            }
                                         }
                                     }
                                 },
@@ -1265,13 +1617,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Achievement cannot be approved (not submitted status)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -1280,7 +1625,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires achievements.verify)",
+                        "description": "Access denied - not owner, admin, or lecturer",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1294,7 +1639,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Verification process failed - database error",
+                        "description": "Failed to retrieve history data",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1303,9 +1648,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/achievements/{id}/reject": {
             "post": {
-                "description": "Authenticate user with username/email and password. Returns JWT token and user profile information.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lecturer rejects a submitted achievement with reason. Changes status from 'submitted' to 'rejected'.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1313,50 +1663,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "User login",
+                "summary": "Reject achievement",
                 "parameters": [
                     {
-                        "description": "Login credentials (username/email and password)",
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection request with reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "type": "object",
+                            "properties": {
+                                "rejection_note": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful - returns JWT token and user profile",
+                        "description": "Achievement rejected successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "achievement": {
+                                            "$ref": "#/definitions/models.Achievement"
+                                        },
+                                        "reference": {
+                                            "type": "object"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or missing required fields",
+                        "description": "Invalid request, missing rejection note, or achievement cannot be rejected",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials (wrong username/email or password)",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Account inactive - contact administrator",
+                        "description": "Insufficient permissions (requires achievements.verify)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal server error - token generation failed",
+                        "description": "Rejection process failed - database error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1365,14 +1753,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/achievements/{id}/review": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user (client-side token removal). JWT tokens are stateless, so logout is handled client-side.",
+                "description": "Lecturer reviews detailed information of an achievement for verification process, including the antivirus scan status of each document and a warning when a document was already uploaded on another achievement. Lecturers can open their advisees' achievements and achievements sharing a document with one of them, so duplicate links stay reachable.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1380,15 +1768,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
+                ],
+                "summary": "Review achievement detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "User logout",
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "Achievement details retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "achievement": {
+                                            "$ref": "#/definitions/models.Achievement"
+                                        },
+                                        "duplicate_warning": {
+                                            "type": "object",
+                                            "properties": {
+                                                "matches": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.DuplicateFlag"
+                                                    }
+                                                },
+                                                "message": {
+                                                    "type": "string"
+                                                }
+                                            }
+                                        },
+                                        "reference": {
+                                            "type": "object"
+                                        },
+                                        "scan_summary": {
+                                            "type": "object"
+                                        }
+                                    }
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -1399,60 +1824,28 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/auth/profile": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get profile information of the currently authenticated user including role and status.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Get current user profile",
-                "responses": {
-                    "200": {
-                        "description": "Profile retrieved successfully",
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires achievements.verify), or lecturer is not the student's advisor and no advisee has an identical document",
                         "schema": {
                             "type": "object",
-                            "properties": {
-                                "data": {
-                                    "$ref": "#/definitions/models.UserProfile"
-                                },
-                                "message": {
-                                    "type": "string"
-                                },
-                                "status": {
-                                    "type": "string"
-                                }
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                    "404": {
+                        "description": "Achievement not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve profile data",
+                        "description": "Failed to retrieve achievement details",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1461,9 +1854,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/achievements/{id}/submit": {
             "post": {
-                "description": "Generate new access token using refresh token. Validates refresh token and user status.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit a draft achievement for verification by lecturer. Changes status from 'draft' to 'submitted'. Documents whose SHA-256 hash matches a document on another achievement are flagged as possible duplicates (the submission is not blocked).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1471,35 +1869,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievements"
                 ],
-                "summary": "Refresh JWT token",
+                "summary": "Submit achievement for verification",
                 "parameters": [
                     {
-                        "description": "Refresh token request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "refresh_token": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refreshed successfully",
+                        "description": "Achievement submitted successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "token": {
+                                        "achievement_id": {
+                                            "type": "string"
+                                        },
+                                        "duplicate_flags": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.DuplicateFlag"
+                                            }
+                                        },
+                                        "status": {
+                                            "type": "string"
+                                        },
+                                        "updated_at": {
                                             "type": "string"
                                         }
                                     }
@@ -1514,28 +1917,35 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or missing refresh token",
+                        "description": "Achievement cannot be submitted (not draft status or documents not cleared by antivirus scan)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Invalid or expired refresh token",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "User account inactive",
+                        "description": "Access denied - not owner or insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to generate new token",
+                        "description": "Submission process failed - database error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1544,14 +1954,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/lecturers": {
-            "get": {
+        "/achievements/{id}/uploads": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of lecturers with their profile information and department details.",
+                "description": "Create an upload session for a large evidence file (e.g. video) on a draft achievement. The maximum file size depends on the taxonomy code of the achievement category (UPLOAD_CATEGORY_LIMITS_MB). Send the file in chunks with PATCH and resume from the offset returned by GET after a connection drop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1559,42 +1969,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer Management"
+                    "Achievements"
                 ],
-                "summary": "Get list of lecturers",
+                "summary": "Start resumable upload",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page (default: 10, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "File name, total size in bytes and optional SHA-256 (hex) of the whole file",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUploadSessionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Lecturers retrieved successfully",
+                    "201": {
+                        "description": "Upload session created",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "lecturers": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Lecturer"
-                                            }
+                                        "expires_at": {
+                                            "type": "string"
                                         },
-                                        "pagination": {
-                                            "$ref": "#/definitions/models.PaginationMeta"
+                                        "filesize": {
+                                            "type": "integer"
+                                        },
+                                        "max_chunk_size": {
+                                            "type": "integer"
+                                        },
+                                        "offset": {
+                                            "type": "integer"
+                                        },
+                                        "upload_id": {
+                                            "type": "string"
+                                        },
+                                        "upload_url": {
+                                            "type": "string"
                                         }
                                     }
                                 },
@@ -1607,22 +2028,43 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid request, achievement not in draft status, or file rejected (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires lecturers.read)",
+                        "description": "Access denied - not owner",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Storage quota exceeded, counting uploads still in progress (code: ACHIEVEMENT_QUOTA_EXCEEDED or STUDENT_QUOTA_EXCEEDED)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve lecturers",
+                        "description": "Failed to create upload session",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1631,52 +2073,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/lecturers/{id}/advisees": {
+        "/achievements/{id}/uploads/{uploadId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of students advised by a specific lecturer including their profile information.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the number of bytes already received for an upload session, so the client can resume after a connection drop. The offset is also returned in the Upload-Offset header (HEAD is supported).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer Management"
+                    "Achievements"
                 ],
-                "summary": "Get lecturer advisees",
+                "summary": "Get resumable upload offset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Advisees retrieved successfully",
+                        "description": "Upload session retrieved",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "advisees": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Student"
-                                            }
-                                        },
-                                        "total": {
-                                            "type": "integer"
-                                        }
-                                    }
+                                    "$ref": "#/definitions/models.UploadSession"
                                 },
                                 "message": {
                                     "type": "string"
@@ -1688,83 +2123,54 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Insufficient permissions (requires lecturers.read)",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Lecturer not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to retrieve advisees",
+                        "description": "Upload session not found or expired",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/lecturers/{id}/profile": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update lecturer profile information including lecturer ID and department.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Abort an upload session and delete the partially uploaded data.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer Management"
+                    "Achievements"
                 ],
-                "summary": "Update lecturer profile",
+                "summary": "Cancel resumable upload",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Lecturer profile update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "department": {
-                                    "type": "string"
-                                },
-                                "lecturer_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lecturer profile updated successfully",
+                        "description": "Upload session cancelled",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -1777,70 +2183,100 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Upload session not found or expired",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Insufficient permissions",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Lecturer not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Update operation failed",
+                    "500": {
+                        "description": "Failed to cancel upload session",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/reports/statistics": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin view of comprehensive achievement statistics across all students including top performers ranking.",
+                "description": "Append a chunk to an upload session. The Upload-Offset header must equal the current offset. An optional Upload-Checksum header (\"sha256 \u003cbase64\u003e\") verifies the chunk. When the last chunk arrives the file is validated, scanned and attached to the achievement.",
                 "consumes": [
-                    "application/json"
+                    "application/offset+octet-stream"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics \u0026 Reports"
+                    "Achievements"
+                ],
+                "summary": "Upload file chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Byte offset of this chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chunk checksum, e.g. sha256 \u003cbase64 digest\u003e",
+                        "name": "Upload-Checksum",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get all achievement statistics",
                 "responses": {
                     "200": {
-                        "description": "All statistics retrieved successfully",
+                        "description": "Chunk stored (document is set once the upload is complete)",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object"
+                                    "type": "object",
+                                    "properties": {
+                                        "completed": {
+                                            "type": "boolean"
+                                        },
+                                        "document": {
+                                            "$ref": "#/definitions/models.Document"
+                                        },
+                                        "filesize": {
+                                            "type": "integer"
+                                        },
+                                        "offset": {
+                                            "type": "integer"
+                                        },
+                                        "upload_id": {
+                                            "type": "string"
+                                        }
+                                    }
                                 },
                                 "message": {
                                     "type": "string"
@@ -1851,6 +2287,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid offset header or file rejected (code: FILE_CONTENT_MISMATCH)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -1858,15 +2301,43 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Insufficient permissions (requires admin access)",
+                    "404": {
+                        "description": "Upload session not found or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Offset does not match the session (code: UPLOAD_OFFSET_MISMATCH)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk too large or exceeds file size (code: CHUNK_TOO_LARGE)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Malware detected by antivirus scan (code: FILE_INFECTED)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "460": {
+                        "description": "Checksum mismatch (code: CHECKSUM_MISMATCH)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve statistics from database",
+                        "description": "Failed to store chunk",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1875,14 +2346,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/student/{id}": {
-            "get": {
+        "/achievements/{id}/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed achievement report for a specific student including statistics and achievement list.",
+                "description": "Lecturer approves a submitted achievement. Changes status from 'submitted' to 'verified' and awards points from the scoring rubric for its category and level.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1890,13 +2361,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics \u0026 Reports"
+                    "Achievements"
                 ],
-                "summary": "Get student report",
+                "summary": "Approve achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1904,24 +2375,18 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student report retrieved successfully",
+                        "description": "Achievement approved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "achievements": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Achievement"
-                                            }
+                                        "achievement": {
+                                            "$ref": "#/definitions/models.Achievement"
                                         },
-                                        "statistics": {
+                                        "reference": {
                                             "type": "object"
-                                        },
-                                        "student": {
-                                            "$ref": "#/definitions/models.Student"
                                         }
                                     }
                                 },
@@ -1934,6 +2399,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Achievement cannot be approved (not submitted status)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
@@ -1942,21 +2414,21 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Access denied - not owner, admin, or lecturer",
+                        "description": "Insufficient permissions (requires achievements.verify)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Student not found",
+                        "description": "Achievement not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve report data",
+                        "description": "Verification process failed - database error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1965,14 +2437,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/students": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get paginated list of students with their profile information.",
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user with username/email and password. Returns JWT token and user profile information.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1980,70 +2447,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
+                    "Authentication"
                 ],
-                "summary": "Get list of students",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page (default: 10, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Login credentials (username/email and password)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Students retrieved successfully",
+                        "description": "Login successful - returns JWT token and user profile",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing required fields",
                         "schema": {
                             "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "pagination": {
-                                            "$ref": "#/definitions/models.PaginationMeta"
-                                        },
-                                        "students": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Student"
-                                            }
-                                        }
-                                    }
-                                },
-                                "message": {
-                                    "type": "string"
-                                },
-                                "status": {
-                                    "type": "string"
-                                }
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid credentials (wrong username/email or password)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires students.read)",
+                        "description": "Account inactive - contact administrator",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve students",
+                        "description": "Internal server error - token generation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2052,14 +2499,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific student including user data and profile.",
+                "description": "Logout user (client-side token removal). JWT tokens are stateless, so logout is handled client-side.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2067,35 +2514,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
-                ],
-                "summary": "Get student by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Student ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Authentication"
                 ],
+                "summary": "User logout",
                 "responses": {
                     "200": {
-                        "description": "Student retrieved successfully",
+                        "description": "Logout successful",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "student": {
-                                            "$ref": "#/definitions/models.Student"
-                                        },
-                                        "user": {
-                                            "$ref": "#/definitions/models.User"
-                                        }
-                                    }
-                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -2106,28 +2533,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Insufficient permissions (requires students.read)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Student not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to retrieve student data",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2136,14 +2542,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}/achievements": {
+        "/auth/profile": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all achievements for a specific student. Access control: admin, lecturer, or the student themselves.",
+                "description": "Get profile information of the currently authenticated user including role and status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2151,40 +2557,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
-                ],
-                "summary": "Get student achievements",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Student ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Authentication"
                 ],
+                "summary": "Get current user profile",
                 "responses": {
                     "200": {
-                        "description": "Student achievements retrieved successfully",
+                        "description": "Profile retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "achievements": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Achievement"
-                                            }
-                                        },
-                                        "student_id": {
-                                            "type": "string"
-                                        },
-                                        "total": {
-                                            "type": "integer"
-                                        }
-                                    }
+                                    "$ref": "#/definitions/models.UserProfile"
                                 },
                                 "message": {
                                     "type": "string"
@@ -2196,21 +2579,14 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing JWT token",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Access denied - insufficient permissions",
+                        "description": "Unauthorized - invalid or missing token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve achievements",
+                        "description": "Failed to retrieve profile data",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2219,14 +2595,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}/advisor": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Assign a lecturer as advisor to a student. Validates that the advisor is a valid lecturer.",
+        "/auth/refresh": {
+            "post": {
+                "description": "Generate new access token using refresh token. Validates refresh token and user status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2234,26 +2605,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
+                    "Authentication"
                 ],
-                "summary": "Assign advisor to student",
+                "summary": "Refresh JWT token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Student ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Advisor assignment request with lecturer ID",
+                        "description": "Refresh token request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "advisor_id": {
+                                "refresh_token": {
                                     "type": "string"
                                 }
                             }
@@ -2262,10 +2626,18 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Advisor assigned successfully",
+                        "description": "Token refreshed successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "token": {
+                                            "type": "string"
+                                        }
+                                    }
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -2276,28 +2648,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request or advisor must be a lecturer",
+                        "description": "Invalid request body or missing refresh token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid or expired refresh token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires students.assign_advisor)",
+                        "description": "User account inactive",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Advisor assignment failed",
+                        "description": "Failed to generate new token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2306,59 +2678,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}/profile": {
-            "put": {
+        "/exports/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update student profile information including student ID, program study, and academic year.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the status of a background export job created by the current user. When status is completed the file can be downloaded from /exports/{id}/download until expires_at.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Update student profile",
+                "summary": "Get export job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Export job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Student profile update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "academic_year": {
-                                    "type": "string"
-                                },
-                                "program_study": {
-                                    "type": "string"
-                                },
-                                "student_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student profile updated successfully",
+                        "description": "Export job retrieved",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.ExportJob"
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -2368,36 +2720,71 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Export job not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/exports/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the file produced by a completed background export job created by the current user.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Statistics \u0026 Reports"
+                ],
+                "summary": "Download export file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export file",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
-                    "403": {
-                        "description": "Insufficient permissions",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Student not found",
+                        "description": "Export job not found or expired",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Update operation failed",
+                    "409": {
+                        "description": "Export job not completed yet",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2406,14 +2793,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/lecturers": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get paginated list of users with optional role filtering. Admin access required.",
+                "description": "Get paginated list of lecturers with their profile information and department details. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by name) which returns pagination as models.CursorPaginationMeta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2421,9 +2808,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Lecturer Management"
                 ],
-                "summary": "Get list of users",
+                "summary": "Get list of lecturers",
                 "parameters": [
                     {
                         "type": "integer",
@@ -2441,28 +2828,34 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by role ID (1=Admin, 2=Lecturer, 3=Student)",
-                        "name": "role_id",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Users retrieved successfully",
+                        "description": "Lecturers retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "pagination": {
-                                            "$ref": "#/definitions/models.PaginationMeta"
-                                        },
-                                        "users": {
+                                        "lecturers": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.User"
+                                                "$ref": "#/definitions/models.Lecturer"
                                             }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/models.PaginationMeta"
                                         }
                                     }
                                 },
@@ -2475,6 +2868,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid or tampered cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2483,28 +2883,30 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires users.read)",
+                        "description": "Insufficient permissions (requires lecturers.read)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve users",
+                        "description": "Failed to retrieve lecturers",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/lecturers/{id}/advisees": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new user with role assignment and optional student/lecturer profile. Generates random password.",
+                "description": "Get list of students advised by a specific lecturer including their profile information, ordered by name. Without a cursor the full list is returned; cursor mode (cursor/limit) returns one page with pagination as models.CursorPaginationMeta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2512,89 +2914,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Lecturer Management"
                 ],
-                "summary": "Create new user",
+                "summary": "Get lecturer advisees",
                 "parameters": [
                     {
-                        "description": "User creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page in cursor mode (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisees retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "academic_year": {
-                                    "type": "string"
-                                },
-                                "department": {
-                                    "type": "string"
-                                },
-                                "email": {
-                                    "type": "string"
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "advisees": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Student"
+                                            }
+                                        },
+                                        "total": {
+                                            "type": "integer"
+                                        }
+                                    }
                                 },
-                                "full_name": {
+                                "message": {
                                     "type": "string"
                                 },
-                                "is_active": {
-                                    "type": "boolean"
-                                },
-                                "lecturer_id": {
-                                    "type": "string"
-                                },
-                                "program_study": {
-                                    "type": "string"
-                                },
-                                "role_id": {
-                                    "type": "string"
-                                },
-                                "student_id": {
-                                    "type": "string"
-                                },
-                                "username": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "User created successfully with generated password",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "email": {
-                                            "type": "string"
-                                        },
-                                        "password": {
-                                            "type": "string"
-                                        },
-                                        "role_id": {
-                                            "type": "string"
-                                        },
-                                        "user_id": {
-                                            "type": "string"
-                                        },
-                                        "username": {
-                                            "type": "string"
-                                        }
-                                    }
-                                },
-                                "message": {
-                                    "type": "string"
-                                },
-                                "status": {
+                                "status": {
                                     "type": "string"
                                 }
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request, validation error, or duplicate username/email",
+                        "description": "Invalid or tampered cursor",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2608,14 +2989,21 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires users.create)",
+                        "description": "Insufficient permissions (requires lecturers.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal server error - user or profile creation failed",
+                        "description": "Failed to retrieve advisees",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2624,14 +3012,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
+        "/lecturers/{id}/profile": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific user including role-based profile (Student/Lecturer).",
+                "description": "Update lecturer profile information including lecturer ID and department.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2639,35 +3027,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Lecturer Management"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Update lecturer profile",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Lecturer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Lecturer profile update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "department": {
+                                    "type": "string"
+                                },
+                                "lecturer_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully with profile",
+                        "description": "Lecturer profile updated successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "profile": {
-                                            "type": "object"
-                                        },
-                                        "user": {
-                                            "$ref": "#/definitions/models.User"
-                                        }
-                                    }
-                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -2677,6 +3071,13 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -2685,35 +3086,37 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires users.read)",
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Lecturer not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve user data",
+                        "description": "Update operation failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/reports/leaderboard": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user information including role change and account activation/deactivation.",
+                "description": "Ranks students by verified achievements or total rubric points, optionally scoped by program study, academic year cohort, advisor and achievement date range. Only verified achievements count. Students with equal scores share a rank and the next rank is skipped (1, 2, 2, 4); ties are listed by name. Students tied with the entry at the limit are all included, so the list can be longer than limit. The leaderboard is the same for every caller, so it is cached for a few minutes and served with Cache-Control and ETag headers for display screens.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2721,52 +3124,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Update user",
+                "summary": "Get achievement leaderboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Program study (case-insensitive)",
+                        "name": "program_study",
+                        "in": "query"
                     },
                     {
-                        "description": "User update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "email": {
-                                    "type": "string"
-                                },
-                                "full_name": {
-                                    "type": "string"
-                                },
-                                "is_active": {
-                                    "type": "boolean"
-                                },
-                                "role_id": {
-                                    "type": "string"
-                                },
-                                "username": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Academic year cohort",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Advisor (lecturer) ID",
+                        "name": "advisor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "verified",
+                        "description": "Ranking basis (verified, points)",
+                        "name": "rank_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of entries (1-100), extended by students tied with the last entry",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Leaderboard retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "$ref": "#/definitions/models.User"
+                                    "type": "object",
+                                    "properties": {
+                                        "filter": {
+                                            "$ref": "#/definitions/models.LeaderboardFilter"
+                                        },
+                                        "leaderboard": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LeaderboardEntry"
+                                            }
+                                        }
+                                    }
                                 },
                                 "message": {
                                     "type": "string"
@@ -2777,50 +3208,48 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "304": {
+                        "description": "Leaderboard not modified"
+                    },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid rank_by, limit or date filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires users.update)",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Update operation failed",
+                        "description": "Failed to compute leaderboard",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/reports/statistics": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a user. User data is marked as deleted but not physically removed.",
+                "description": "Admin view of comprehensive achievement statistics across all students including top performers ranking. Served from incrementally maintained counters; a date range that is not month-aligned is computed from the read model. Optionally filtered by status and date range.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2828,24 +3257,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Delete user",
+                "summary": "Get all achievement statistics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "All statistics retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "type": "object"
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -2855,29 +3298,29 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid status or date filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Insufficient permissions (requires users.delete)",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Insufficient permissions (requires admin access)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Delete operation failed",
+                        "description": "Failed to retrieve statistics from database",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2886,91 +3329,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/lecturer-profile": {
-            "post": {
+        "/reports/statistics/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create lecturer profile for a user. Validates that profile doesn't already exist.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export achievement statistics (summary, category, level and period breakdown) as CSV or XLSX, scoped like the list endpoints (admin: all students, lecturer: advisees, student: own achievements).",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Lecturer Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Set lecturer profile",
+                "summary": "Export achievement statistics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "csv",
+                        "description": "Export format (csv, xlsx)",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "description": "Lecturer profile creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "department": {
-                                    "type": "string"
-                                },
-                                "lecturer_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Lecturer profile created successfully",
+                    "200": {
+                        "description": "Export file",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "$ref": "#/definitions/models.Lecturer"
-                                },
-                                "message": {
-                                    "type": "string"
-                                },
-                                "status": {
-                                    "type": "string"
-                                }
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or profile already exists",
+                        "description": "Invalid format, status or date filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions",
+                        "description": "Insufficient permissions (requires achievements.read)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Profile creation failed",
+                        "description": "Failed to export statistics",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -2979,14 +3410,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/role": {
-            "put": {
+        "/reports/student/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assign a specific role to a user. Validates role existence before assignment.",
+                "description": "Get detailed achievement report for a specific student including statistics, total rubric points of verified achievements and achievement list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2994,38 +3425,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Assign role to user",
+                "summary": "Get student report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Student ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Role assignment request (1=Admin, 2=Lecturer, 3=Student)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "role_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role assigned successfully",
+                        "description": "Student report retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "achievements": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AchievementView"
+                                            }
+                                        },
+                                        "statistics": {
+                                            "type": "object"
+                                        },
+                                        "student": {
+                                            "$ref": "#/definitions/models.Student"
+                                        },
+                                        "total_points": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
                                 "message": {
                                     "type": "string"
                                 },
@@ -3035,29 +3472,91 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid role ID or role not found",
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied - not owner, admin, or lecturer",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/student/{id}/transcript.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate the official achievement transcript attached to the diploma supplement (SKPI). Only verified achievements are listed, together with student identity, program study, advisor, verification dates and a verification code. Each issued transcript is stored under its code so it can be verified later through GET /transcripts/verify, even after the student's verified achievements change.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Statistics \u0026 Reports"
+                ],
+                "summary": "Get student achievement transcript (PDF)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transcript PDF",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "403": {
-                        "description": "Insufficient permissions (requires users.assign_role)",
+                        "description": "Access denied - not owner, admin, or lecturer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Role assignment failed",
+                        "description": "Failed to generate transcript",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -3066,14 +3565,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/student-profile": {
-            "post": {
+        "/reports/trends": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create student profile for a user. Validates that profile doesn't already exist.",
+                "description": "Trend and cohort analytics per time bucket (month, academic semester labelled e.g. 2023/2024-Ganjil for August-January and 2023/2024-Genap for February-July, or year), optionally grouped by program study, academic year cohort, category, level or advisor (grouped by advisor_id, with the advisor name in group.advisor_name). Each point includes status counts, verification rate (verified / (verified + rejected)), average hours from submission to verification and year-over-year growth against the same period one year earlier. Admin sees all students, lecturers their advisees and students themselves.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3081,46 +3580,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student Management"
+                    "Statistics \u0026 Reports"
                 ],
-                "summary": "Set student profile",
+                "summary": "Get achievement trends",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "month",
+                        "description": "Time bucket (month, semester, year)",
+                        "name": "bucket",
+                        "in": "query"
                     },
                     {
-                        "description": "Student profile creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "academic_year": {
-                                    "type": "string"
-                                },
-                                "program_study": {
-                                    "type": "string"
-                                },
-                                "student_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "string",
+                        "description": "Comma-separated grouping dimensions (program_study, academic_year, category, level, advisor)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (draft, submitted, verified, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date from (YYYY-MM-DD, inclusive)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Achievement date to (YYYY-MM-DD, inclusive)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Student profile created successfully",
+                    "200": {
+                        "description": "Trends retrieved successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
                                 "data": {
-                                    "$ref": "#/definitions/models.Student"
+                                    "type": "object",
+                                    "properties": {
+                                        "bucket": {
+                                            "type": "string"
+                                        },
+                                        "group_by": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        },
+                                        "trends": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TrendPoint"
+                                            }
+                                        }
+                                    }
                                 },
                                 "message": {
                                     "type": "string"
@@ -3132,14 +3652,14 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request or profile already exists",
+                        "description": "Invalid bucket, group_by, status or date filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -3153,7 +3673,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Profile creation failed",
+                        "description": "Failed to compute trends",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -3161,75 +3681,3246 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Achievement": {
-            "type": "object",
-            "properties": {
-                "achievement_id": {
-                    "type": "string"
+        },
+        "/rubrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the credit point rubric per achievement category and level. Category \"*\" applies to every category of that level unless a more specific rubric exists.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rubrics"
+                ],
+                "summary": "Get scoring rubrics",
+                "responses": {
+                    "200": {
+                        "description": "Rubrics retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.ScoringRubric"
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires achievements.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve rubrics",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin creates a credit point rubric for an achievement category and level. Points of all verified achievements are recomputed in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rubrics"
+                ],
+                "summary": "Create scoring rubric",
+                "parameters": [
+                    {
+                        "description": "Category (or * for any category), level and points",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScoringRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rubric created, recompute started",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.ScoringRubric"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric for this category and level already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rubrics/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin changes the category, level or points of a rubric. Points of all verified achievements are recomputed in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rubrics"
+                ],
+                "summary": "Update scoring rubric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category (or * for any category), level and points",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScoringRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric updated, recompute started",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.ScoringRubric"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric for this category and level already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin deletes a rubric. Points of all verified achievements are recomputed in the background.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rubrics"
+                ],
+                "summary": "Delete scoring rubric",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric deleted, recompute started",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of students with their profile information. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by newest first) which returns pagination as models.CursorPaginationMeta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Get list of students",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Students retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pagination": {
+                                            "$ref": "#/definitions/models.PaginationMeta"
+                                        },
+                                        "students": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Student"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or tampered cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires students.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve students",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific student including user data and profile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Get student by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "student": {
+                                            "$ref": "#/definitions/models.Student"
+                                        },
+                                        "user": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires students.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve student data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all achievements for a specific student. Access control: admin, lecturer, or the student themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Get student achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student achievements retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "achievements": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AchievementView"
+                                            }
+                                        },
+                                        "student_id": {
+                                            "type": "string"
+                                        },
+                                        "total": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied - insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve achievements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a lecturer as advisor to a student. Validates that the advisor is a valid lecturer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Assign advisor to student",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Advisor assignment request with lecturer ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "advisor_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisor assigned successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or advisor must be a lecturer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires students.assign_advisor)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Advisor assignment failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update student profile information including student ID, program study, and academic year.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Update student profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student profile update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "academic_year": {
+                                    "type": "string"
+                                },
+                                "program_study": {
+                                    "type": "string"
+                                },
+                                "student_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student profile updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Update operation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomy/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the achievement category master data. Categories may have a parent category (parent_id) to form a hierarchy. Submissions must use a category name, code or alias from this list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Get achievement categories",
+                "responses": {
+                    "200": {
+                        "description": "Categories retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.AchievementCategory"
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires achievements.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve categories",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin creates an achievement category. Code defaults to a slug of the name. Aliases are alternative spellings that are mapped to this category on submission and migration. details_schema is an optional JSON Schema (draft 2020-12, \"type\": \"object\") for the structured details of achievements in this category; sub-categories without a schema use the schema of their nearest parent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Create achievement category",
+                "parameters": [
+                    {
+                        "description": "Category code, name, description, parent, aliases and details schema",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AchievementCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.AchievementCategory"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, invalid details_schema, unknown parent or parent cycle",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Category code or name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomy/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin changes an achievement category, including its details_schema. A new schema applies to submissions and updates from now on; existing achievements are not revalidated. When the name changes the old name is kept as an alias, scoring rubrics are renamed and existing achievements are migrated to the new name in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Update achievement category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category code, name, description, parent, aliases and details schema",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AchievementCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.AchievementCategory"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, invalid details_schema, unknown parent or parent cycle",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Category code or name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin deletes an achievement category. Categories that still have sub-categories or are used by achievements cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Delete achievement category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Category has sub-categories or is used by achievements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomy/levels": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the achievement level master data ordered by sort_order. Submissions must use a level name, code or alias from this list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Get achievement levels",
+                "responses": {
+                    "200": {
+                        "description": "Levels retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.AchievementLevel"
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires achievements.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve levels",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin creates an achievement level. Code defaults to a slug of the name; sort_order controls the display order (lowest first).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Create achievement level",
+                "parameters": [
+                    {
+                        "description": "Level code, name, description, sort order and aliases",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AchievementLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Level created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.AchievementLevel"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Level code or name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create level",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomy/levels/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin changes an achievement level. When the name changes the old name is kept as an alias, scoring rubrics are renamed and existing achievements are migrated to the new name in the background.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Update achievement level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Level ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Level code, name, description, sort order and aliases",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AchievementLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Level updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.AchievementLevel"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Level not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Level code or name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update level",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin deletes an achievement level. Levels used by achievements cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Delete achievement level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Level ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Level deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Level not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Level is used by achievements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete level",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxonomy/migrate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin maps the free-text category and level values of existing achievements onto the taxonomy by name, code or alias (case and whitespace insensitive). With create_missing, values that cannot be mapped are added to the taxonomy using their most common spelling. With dry_run, nothing is changed and only the report is returned. Points are recomputed afterwards.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Taxonomy"
+                ],
+                "summary": "Migrate free-text categories and levels",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only report the mapping",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Create taxonomy entries for unmapped values",
+                        "name": "create_missing",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Migration report",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.TaxonomyMigrationReport"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Migration failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transcripts/verify": {
+            "get": {
+                "description": "Public endpoint (no login, rate limited per IP) to check a verification code printed on an issued transcript. A valid code returns the transcript as it was issued, regardless of later changes to the student's achievements.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics \u0026 Reports"
+                ],
+                "summary": "Verify transcript code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification code printed on the transcript",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification result",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "transcript": {
+                                            "$ref": "#/definitions/models.IssuedTranscript"
+                                        },
+                                        "valid": {
+                                            "type": "boolean"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to load transcript data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of users with optional role filtering. Admin access required. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by newest first) which returns pagination as models.CursorPaginationMeta.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get list of users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'cursor' to start cursor pagination from the first page",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role ID (1=Admin, 2=Lecturer, 3=Student)",
+                        "name": "role_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pagination": {
+                                            "$ref": "#/definitions/models.PaginationMeta"
+                                        },
+                                        "users": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or tampered cursor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve users",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user with role assignment and optional student/lecturer profile. Generates random password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "User creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "academic_year": {
+                                    "type": "string"
+                                },
+                                "department": {
+                                    "type": "string"
+                                },
+                                "email": {
+                                    "type": "string"
+                                },
+                                "full_name": {
+                                    "type": "string"
+                                },
+                                "is_active": {
+                                    "type": "boolean"
+                                },
+                                "lecturer_id": {
+                                    "type": "string"
+                                },
+                                "program_study": {
+                                    "type": "string"
+                                },
+                                "role_id": {
+                                    "type": "string"
+                                },
+                                "student_id": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully with generated password",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "email": {
+                                            "type": "string"
+                                        },
+                                        "password": {
+                                            "type": "string"
+                                        },
+                                        "role_id": {
+                                            "type": "string"
+                                        },
+                                        "user_id": {
+                                            "type": "string"
+                                        },
+                                        "username": {
+                                            "type": "string"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, validation error, or duplicate username/email",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.create)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - user or profile creation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import users from a CSV or XLSX file (first sheet). Required columns: username, email, full_name, role (role ID or name). Optional columns: is_active (default true), student_id, program_study, academic_year, advisor_nip (NIP of an existing lecturer or a lecturer in the same file) for students, and lecturer_id, department for lecturers. With dry_run=true the file is only validated and per-row errors (duplicate username/email, unknown role, etc.) are reported. Otherwise all valid rows are created in a single transaction and invalid rows are reported and skipped. Generated passwords are never returned in the response; they are stored encrypted and can be downloaded once as CSV by the importing user from credentials_url until credentials_expires_at.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Bulk import users",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file (max 10MB, max 2000 rows)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file without creating users",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run validation report",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.UserImportReport"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Users imported",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.UserImportReport"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unreadable file, unsupported format or invalid header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.create)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Import failed, no users were created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import/credentials/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the generated usernames and initial passwords of a bulk import as CSV. Only the user who ran the import can download it, only once and only until credentials_expires_at; the encrypted credentials are deleted on download. Distribute the passwords securely and ask users to change them.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Download imported user credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import ID from credentials_url",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Credentials CSV (username, email, full_name, password)",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.create)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Credentials not found, already downloaded or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to decrypt credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific user including role-based profile (Student/Lecturer).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully with profile",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "profile": {
+                                            "type": "object"
+                                        },
+                                        "user": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.read)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve user data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user information including role change and account activation/deactivation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "email": {
+                                    "type": "string"
+                                },
+                                "full_name": {
+                                    "type": "string"
+                                },
+                                "is_active": {
+                                    "type": "boolean"
+                                },
+                                "role_id": {
+                                    "type": "string"
+                                },
+                                "username": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.User"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.update)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Update operation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a user. User data is marked as deleted but not physically removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.delete)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Delete operation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/lecturer-profile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create lecturer profile for a user. Validates that profile doesn't already exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer Management"
+                ],
+                "summary": "Set lecturer profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lecturer profile creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "department": {
+                                    "type": "string"
+                                },
+                                "lecturer_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Lecturer profile created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.Lecturer"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or profile already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Profile creation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a specific role to a user. Validates role existence before assignment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Assign role to user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role assignment request (1=Admin, 2=Lecturer, 3=Student)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "role_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role assigned successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role ID or role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions (requires users.assign_role)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Role assignment failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/student-profile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create student profile for a user. Validates that profile doesn't already exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student Management"
+                ],
+                "summary": "Set student profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student profile creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "academic_year": {
+                                    "type": "string"
+                                },
+                                "program_study": {
+                                    "type": "string"
+                                },
+                                "student_id": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Student profile created successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "$ref": "#/definitions/models.Student"
+                                },
+                                "message": {
+                                    "type": "string"
+                                },
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or profile already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Profile creation failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Achievement": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Field terstruktur sesuai JSON Schema kategori (contoh: rank, organizer, doi)",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Document"
+                    }
+                },
+                "duplicate_flags": {
+                    "description": "Dokumen yang sama ditemukan pada achievement lain saat submit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateFlag"
+                    }
+                },
+                "external_key": {
+                    "description": "Kunci unik dari sistem/spreadsheet asal untuk achievement hasil import historis",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementCategory": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details_schema": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details_schema": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementFilter": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Filter details.\u003cfield\u003e=value, nilai dicocokkan sebagai teks, angka atau boolean",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "level": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementImportRow"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "invalid_rows": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AchievementImportResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementImportResult": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "external_key": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "created atau skipped (external key sudah pernah diimport)",
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementImportRow": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "external_key": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementLevel": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementLevelRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AchievementSearchResult": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "achievement_id": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "advisor_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Field terstruktur sesuai JSON Schema kategori (contoh: rank, organizer, doi)",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Document"
+                    }
+                },
+                "duplicate_flags": {
+                    "description": "Dokumen yang sama ditemukan pada achievement lain saat submit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateFlag"
+                    }
+                },
+                "external_key": {
+                    "description": "Kunci unik dari sistem/spreadsheet asal untuk achievement hasil import historis",
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "student_name": {
+                    "type": "string"
+                },
+                "student_number": {
+                    "description": "Dari students dan users",
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "description": "Dari achievement_references",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AchievementView": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "achievement_id": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "advisor_name": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Field terstruktur sesuai JSON Schema kategori (contoh: rank, organizer, doi)",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Document"
+                    }
+                },
+                "duplicate_flags": {
+                    "description": "Dokumen yang sama ditemukan pada achievement lain saat submit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateFlag"
+                    }
+                },
+                "external_key": {
+                    "description": "Kunci unik dari sistem/spreadsheet asal untuk achievement hasil import historis",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "student_name": {
+                    "type": "string"
+                },
+                "student_number": {
+                    "description": "Dari students dan users",
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "description": "Dari achievement_references",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateUploadSessionRequest": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "filesize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Document": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string"
+                },
+                "filepath": {
+                    "type": "string"
+                },
+                "filesize": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mimetype": {
+                    "type": "string"
+                },
+                "scan_signature": {
+                    "type": "string"
+                },
+                "scan_status": {
+                    "description": "Hasil scan antivirus (pending, clean, infected, skipped, too_large)",
+                    "type": "string"
+                },
+                "scanned_at": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "Hash SHA-256 isi file asli untuk deteksi duplikat",
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DuplicateFlag": {
+            "type": "object",
+            "properties": {
+                "document_id": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "other_achievement_id": {
+                    "type": "string"
+                },
+                "other_achievement_url": {
+                    "type": "string"
+                },
+                "other_status": {
+                    "type": "string"
+                },
+                "other_student_id": {
+                    "type": "string"
+                },
+                "other_title": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExportJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/models.AchievementFilter"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IssuedTranscript": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TranscriptEntry"
+                    }
                 },
-                "category": {
+                "advisor_name": {
                     "type": "string"
                 },
-                "created_at": {
+                "issued_at": {
                     "type": "string"
                 },
-                "date": {
+                "program_study": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "student_name": {
                     "type": "string"
                 },
-                "description": {
+                "student_number": {
                     "type": "string"
                 },
-                "documents": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Document"
-                    }
-                },
-                "id": {
+                "verification_code": {
                     "type": "string"
-                },
-                "is_deleted": {
-                    "type": "boolean"
-                },
-                "level": {
+                }
+            }
+        },
+        "models.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
                     "type": "string"
                 },
-                "status": {
+                "program_study": {
                     "type": "string"
                 },
+                "rank": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
                 "student_id": {
                     "type": "string"
                 },
-                "title": {
+                "student_name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "student_number": {
                     "type": "string"
+                },
+                "total_points": {
+                    "type": "integer"
+                },
+                "verified_achievements": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Document": {
+        "models.LeaderboardFilter": {
             "type": "object",
             "properties": {
-                "filename": {
+                "academic_year": {
                     "type": "string"
                 },
-                "filepath": {
+                "advisor_id": {
                     "type": "string"
                 },
-                "filesize": {
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "limit": {
                     "type": "integer"
                 },
-                "mimetype": {
+                "program_study": {
                     "type": "string"
                 },
-                "uploaded_at": {
+                "rank_by": {
                     "type": "string"
                 }
             }
@@ -3296,6 +6987,43 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ScoringRubric": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScoringRubricRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Student": {
             "type": "object",
             "properties": {
@@ -3335,11 +7063,156 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "details": {
+                    "description": "Field terstruktur sesuai JSON Schema kategori. Pada form multipart dikirim\nsebagai string JSON object di field details.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "level": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaxonomyMapping": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "boolean"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaxonomyMigrationReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "mappings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxonomyMapping"
+                    }
+                },
+                "unmapped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxonomyMapping"
+                    }
+                }
+            }
+        },
+        "models.TranscriptEntry": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
                 "level": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrendPoint": {
+            "type": "object",
+            "properties": {
+                "avg_hours_to_verify": {
+                    "type": "number"
+                },
+                "draft": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "previous_year_total": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "verification_rate": {
+                    "type": "number"
+                },
+                "verified": {
+                    "type": "integer"
+                },
+                "year_over_year": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.UploadSession": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "checksum": {
+                    "description": "Hash SHA-256 (hex) seluruh file dari client, dicek saat upload selesai",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "filesize": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "upload_id": {
+                    "type": "string"
                 }
             }
         },
@@ -3375,6 +7248,92 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UserImportReport": {
+            "type": "object",
+            "properties": {
+                "credentials_expires_at": {
+                    "type": "string"
+                },
+                "credentials_url": {
+                    "description": "Kredensial user baru hanya bisa diunduh sekali dari CredentialsURL",
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserImportRow"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "invalid_rows": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserImportRow": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "advisor_nip": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UserProfile": {
             "type": "object",
             "properties": {
@@ -3422,4 +7381,4 @@ var SwaggerInfo = &swag.Spec{
 
 func init() {
 	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
-}
\ No newline at end of file
+}
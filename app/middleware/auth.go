@@ -7,7 +7,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthRequired() fiber.Handler {
+// AuthRequired memvalidasi Bearer token dengan konfigurasi JWT
+func AuthRequired(jwtConfig utils.JWTConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -19,7 +20,7 @@ func AuthRequired() fiber.Handler {
 			return c.Status(401).JSON(fiber.Map{"error": "Format token tidak valid"})
 		}
 
-		claims, err := utils.ValidateToken(tokenParts[1])
+		claims, err := utils.ValidateToken(tokenParts[1], jwtConfig)
 		if err != nil {
 			return c.Status(401).JSON(fiber.Map{"error": "Token tidak valid atau expired"})
 		}
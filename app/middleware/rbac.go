@@ -5,6 +5,8 @@ import (
 	"crud-app/app/utils"
 	"database/sql"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -12,16 +14,49 @@ import (
 
 type RBACMiddleware struct {
 	permRepo *repository.PermissionRepository
+	cache    *utils.PermissionCache
+	cacheTTL time.Duration
 }
 
-func NewRBACMiddleware(db *sql.DB) *RBACMiddleware {
+// NewRBACMiddleware membuat middleware RBAC. Permission user disimpan di cache selama cacheTTL.
+func NewRBACMiddleware(db *sql.DB, cache *utils.PermissionCache, cacheTTL time.Duration) *RBACMiddleware {
 	return &RBACMiddleware{
 		permRepo: repository.NewPermissionRepository(db),
+		cache:    cache,
+		cacheTTL: cacheTTL,
 	}
 }
 
+// requiredPermissions mencatat semua permission yang dipakai route, sumber katalog
+// permission untuk command seed
+var (
+	requiredPermissionsMu sync.Mutex
+	requiredPermissions   = make(map[string]bool)
+)
+
+func registerPermissions(permissionNames ...string) {
+	requiredPermissionsMu.Lock()
+	defer requiredPermissionsMu.Unlock()
+	for _, name := range permissionNames {
+		requiredPermissions[name] = true
+	}
+}
+
+// RequiredPermissions mengembalikan semua permission yang dipakai route yang sudah didaftarkan, urut nama
+func RequiredPermissions() []string {
+	requiredPermissionsMu.Lock()
+	defer requiredPermissionsMu.Unlock()
+	names := make([]string, 0, len(requiredPermissions))
+	for name := range requiredPermissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // RequirePermission middleware untuk mengecek permission
 func (m *RBACMiddleware) RequirePermission(permissionName string) fiber.Handler {
+	registerPermissions(permissionName)
 	return func(c *fiber.Ctx) error {
 		// Step 1: Ekstrak user_id dari context (sudah di-set oleh AuthRequired middleware)
 		userID, ok := c.Locals("user_id").(string)
@@ -36,7 +71,7 @@ func (m *RBACMiddleware) RequirePermission(permissionName string) fiber.Handler
 		cacheKey := fmt.Sprintf("user_permissions:%s", userID)
 		var permissions []string
 
-		if cachedPerms, found := utils.Cache.Get(cacheKey); found {
+		if cachedPerms, found := m.cache.Get(cacheKey); found {
 			permissions = cachedPerms.([]string)
 		} else {
 			// Step 3: Load permissions dari database jika tidak ada di cache
@@ -49,8 +84,8 @@ func (m *RBACMiddleware) RequirePermission(permissionName string) fiber.Handler
 			}
 			permissions = perms
 
-			// Step 4: Simpan ke cache dengan TTL 15 menit
-			utils.Cache.Set(cacheKey, permissions, 15*time.Minute)
+			// Step 4: Simpan ke cache sesuai TTL konfigurasi
+			m.cache.Set(cacheKey, permissions, m.cacheTTL)
 		}
 
 		// Step 5: Check apakah user memiliki permission yang diperlukan
@@ -76,6 +111,7 @@ func (m *RBACMiddleware) RequirePermission(permissionName string) fiber.Handler
 
 // RequireAnyPermission middleware untuk mengecek salah satu dari beberapa permissions
 func (m *RBACMiddleware) RequireAnyPermission(permissionNames ...string) fiber.Handler {
+	registerPermissions(permissionNames...)
 	return func(c *fiber.Ctx) error {
 		userID, ok := c.Locals("user_id").(string)
 		if !ok || userID == "" {
@@ -88,7 +124,7 @@ func (m *RBACMiddleware) RequireAnyPermission(permissionNames ...string) fiber.H
 		cacheKey := fmt.Sprintf("user_permissions:%s", userID)
 		var permissions []string
 
-		if cachedPerms, found := utils.Cache.Get(cacheKey); found {
+		if cachedPerms, found := m.cache.Get(cacheKey); found {
 			permissions = cachedPerms.([]string)
 		} else {
 			perms, err := m.permRepo.GetUserPermissions(userID)
@@ -99,7 +135,7 @@ func (m *RBACMiddleware) RequireAnyPermission(permissionNames ...string) fiber.H
 				})
 			}
 			permissions = perms
-			utils.Cache.Set(cacheKey, permissions, 15*time.Minute)
+			m.cache.Set(cacheKey, permissions, m.cacheTTL)
 		}
 
 		// Check apakah user memiliki salah satu permission
@@ -129,6 +165,7 @@ func (m *RBACMiddleware) RequireAnyPermission(permissionNames ...string) fiber.H
 
 // RequireAllPermissions middleware untuk mengecek semua permissions harus dimiliki
 func (m *RBACMiddleware) RequireAllPermissions(permissionNames ...string) fiber.Handler {
+	registerPermissions(permissionNames...)
 	return func(c *fiber.Ctx) error {
 		userID, ok := c.Locals("user_id").(string)
 		if !ok || userID == "" {
@@ -141,7 +178,7 @@ func (m *RBACMiddleware) RequireAllPermissions(permissionNames ...string) fiber.
 		cacheKey := fmt.Sprintf("user_permissions:%s", userID)
 		var permissions []string
 
-		if cachedPerms, found := utils.Cache.Get(cacheKey); found {
+		if cachedPerms, found := m.cache.Get(cacheKey); found {
 			permissions = cachedPerms.([]string)
 		} else {
 			perms, err := m.permRepo.GetUserPermissions(userID)
@@ -152,7 +189,7 @@ func (m *RBACMiddleware) RequireAllPermissions(permissionNames ...string) fiber.
 				})
 			}
 			permissions = perms
-			utils.Cache.Set(cacheKey, permissions, 15*time.Minute)
+			m.cache.Set(cacheKey, permissions, m.cacheTTL)
 		}
 
 		// Check apakah user memiliki semua permissions yang diperlukan
@@ -1,11 +1,16 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// AchievementSchemaVersion versi bentuk dokumen achievement saat ini. Naikkan bersama
+// migration dokumen baru (service.AchievementDocumentMigrations) saat bentuk dokumen berubah.
+const AchievementSchemaVersion = 2
+
 // Achievement model untuk MongoDB
 type Achievement struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -18,19 +23,64 @@ type Achievement struct {
 	Description   string             `bson:"description" json:"description"`
 	Documents     []Document         `bson:"documents" json:"documents"`
 	Status        string             `bson:"status" json:"status"`
+	Points        int                `bson:"points" json:"points"`
 	IsDeleted     bool               `bson:"is_deleted" json:"is_deleted"`
 	DeletedAt     *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+
+	// Dokumen yang sama ditemukan pada achievement lain saat submit
+	DuplicateFlags []DuplicateFlag `bson:"duplicate_flags,omitempty" json:"duplicate_flags,omitempty"`
+
+	// Field terstruktur sesuai JSON Schema kategori (contoh: rank, organizer, doi)
+	Details map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+
+	// Kunci unik dari sistem/spreadsheet asal untuk achievement hasil import historis
+	ExternalKey string `bson:"external_key,omitempty" json:"external_key,omitempty"`
+
+	// Versi bentuk dokumen, lihat AchievementSchemaVersion
+	SchemaVersion int `bson:"schema_version" json:"-"`
 }
 
 // Document model untuk file upload
 type Document struct {
+	ID         string    `bson:"id,omitempty" json:"id"`
 	Filename   string    `bson:"filename" json:"filename"`
 	Filepath   string    `bson:"filepath" json:"filepath"`
 	Filesize   int64     `bson:"filesize" json:"filesize"`
 	Mimetype   string    `bson:"mimetype" json:"mimetype"`
 	UploadedAt time.Time `bson:"uploaded_at" json:"uploaded_at"`
+	// Hasil scan antivirus (pending, clean, infected, skipped, too_large)
+	ScanStatus    string     `bson:"scan_status" json:"scan_status"`
+	ScanSignature string     `bson:"scan_signature,omitempty" json:"scan_signature,omitempty"`
+	ScannedAt     *time.Time `bson:"scanned_at,omitempty" json:"scanned_at,omitempty"`
+	// Thumbnail untuk dokumen gambar (JPEG/PNG)
+	ThumbnailPath string `bson:"thumbnail_path,omitempty" json:"-"`
+	ThumbnailURL  string `bson:"thumbnail_url,omitempty" json:"thumbnail_url,omitempty"`
+	// Hash SHA-256 isi file asli untuk deteksi duplikat
+	SHA256 string `bson:"sha256,omitempty" json:"sha256,omitempty"`
+}
+
+// DuplicateFlag menandai dokumen yang juga dipakai oleh achievement lain
+type DuplicateFlag struct {
+	DocumentID          string `bson:"document_id" json:"document_id"`
+	Filename            string `bson:"filename" json:"filename"`
+	SHA256              string `bson:"sha256" json:"sha256"`
+	OtherAchievementID  string `bson:"other_achievement_id" json:"other_achievement_id"`
+	OtherStudentID      string `bson:"other_student_id" json:"other_student_id"`
+	OtherTitle          string `bson:"other_title" json:"other_title"`
+	OtherStatus         string `bson:"other_status" json:"other_status"`
+	OtherAchievementURL string `bson:"other_achievement_url" json:"other_achievement_url"`
+}
+
+// DocumentThumbnailURL menghasilkan URL endpoint thumbnail sebuah dokumen
+func DocumentThumbnailURL(achievementID, documentID string) string {
+	return fmt.Sprintf("/api/v1/achievements/%s/documents/%s/thumbnail", achievementID, documentID)
+}
+
+// AchievementReviewURL menghasilkan URL endpoint review achievement untuk dosen wali
+func AchievementReviewURL(achievementID string) string {
+	return fmt.Sprintf("/api/v1/achievements/%s/review", achievementID)
 }
 
 // SubmitAchievementRequest untuk request body
@@ -40,6 +90,10 @@ type SubmitAchievementRequest struct {
 	Level       string `json:"level" form:"level"`
 	Date        string `json:"date" form:"date"` // Format: YYYY-MM-DD
 	Description string `json:"description" form:"description"`
+
+	// Field terstruktur sesuai JSON Schema kategori. Pada form multipart dikirim
+	// sebagai string JSON object di field details.
+	Details map[string]interface{} `json:"details,omitempty" form:"-"`
 }
 
 // AchievementResponse untuk response
@@ -56,6 +110,8 @@ type AchievementResponse struct {
 	Status        string     `json:"status"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
+
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
 // PaginationMeta untuk metadata pagination
@@ -66,8 +122,41 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// AchievementFilter filter gabungan untuk list achievement. Program study, academic year
+// dan advisor berasal dari tabel students, tersedia di read model AchievementView.
+type AchievementFilter struct {
+	Status       string     `json:"status,omitempty"`
+	Category     string     `json:"category,omitempty"`
+	Level        string     `json:"level,omitempty"`
+	DateFrom     *time.Time `json:"date_from,omitempty"`
+	DateTo       *time.Time `json:"date_to,omitempty"`
+	StudentID    string     `json:"student_id,omitempty"`
+	ProgramStudy string     `json:"program_study,omitempty"`
+	AcademicYear string     `json:"academic_year,omitempty"`
+	AdvisorID    string     `json:"advisor_id,omitempty"`
+	SortBy       string     `json:"sort_by"`
+	SortOrder    string     `json:"sort_order"`
+
+	// Filter details.<field>=value, nilai dicocokkan sebagai teks, angka atau boolean
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// StatisticsFilter filter untuk statistik achievement
+type StatisticsFilter struct {
+	Status   string     `json:"status,omitempty"`
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
+}
+
+// AchievementSearchResult hasil pencarian full-text beserta skor relevansi
+type AchievementSearchResult struct {
+	AchievementView `bson:",inline"`
+	Score           float64           `bson:"score" json:"score"`
+	Highlights      map[string]string `bson:"-" json:"highlights"`
+}
+
 // AchievementListResponse untuk response list dengan pagination
 type AchievementListResponse struct {
 	Achievements []Achievement  `json:"achievements"`
 	Pagination   PaginationMeta `json:"pagination"`
-}
\ No newline at end of file
+}
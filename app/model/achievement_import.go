@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// AchievementImportRow satu baris file import achievement historis. ExternalKey
+// adalah kunci unik dari data asal; baris dengan kunci yang sudah pernah diimport
+// dilewati sehingga import aman dijalankan ulang.
+type AchievementImportRow struct {
+	Row           int                    `json:"row"`
+	ExternalKey   string                 `json:"external_key"`
+	StudentNumber string                 `json:"student_id"`
+	Title         string                 `json:"title"`
+	Category      string                 `json:"category"`
+	Level         string                 `json:"level"`
+	Date          string                 `json:"date"`
+	Description   string                 `json:"description,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	Status        string                 `json:"status"`
+	VerifiedBy    string                 `json:"verified_by,omitempty"`
+	VerifiedAt    string                 `json:"verified_at,omitempty"`
+	Documents     []string               `json:"documents,omitempty"`
+	Errors        []string               `json:"errors,omitempty"`
+
+	// Hasil validasi
+	StudentUserID         string    `json:"-"`
+	VerifierID            string    `json:"-"`
+	AchievementDate       time.Time `json:"-"`
+	VerificationDate      time.Time `json:"-"`
+	ExistingAchievementID string    `json:"-"`
+}
+
+// AchievementImportLookup data yang sudah ada untuk validasi import achievement
+type AchievementImportLookup struct {
+	// NIM (huruf kecil) ke user ID mahasiswa
+	Students map[string]string
+	// Username atau NIP (huruf kecil) ke user ID dosen/admin yang boleh menjadi verifikator
+	Verifiers map[string]string
+	// External key yang sudah diimport ke achievement_id
+	ExistingKeys map[string]string
+}
+
+// AchievementImportResult hasil import satu baris
+type AchievementImportResult struct {
+	Row           int    `json:"row"`
+	ExternalKey   string `json:"external_key"`
+	AchievementID string `json:"achievement_id"`
+	// created atau skipped (external key sudah pernah diimport)
+	Result string `json:"result"`
+}
+
+// AchievementImportReport hasil validasi atau import achievement historis
+type AchievementImportReport struct {
+	DryRun      bool                      `json:"dry_run"`
+	TotalRows   int                       `json:"total_rows"`
+	ValidRows   int                       `json:"valid_rows"`
+	InvalidRows int                       `json:"invalid_rows"`
+	Created     int                       `json:"created"`
+	Skipped     int                       `json:"skipped"`
+	Failed      int                       `json:"failed"`
+	Results     []AchievementImportResult `json:"results"`
+	Errors      []AchievementImportRow    `json:"errors"`
+}
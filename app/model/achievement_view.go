@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// AchievementView adalah read model (collection achievement_views) yang menggabungkan
+// data achievement MongoDB dengan reference, student dan advisor dari PostgreSQL.
+// Diperbarui setiap kali salah satu sumber datanya berubah.
+type AchievementView struct {
+	Achievement `bson:",inline"`
+
+	// Dari achievement_references
+	SubmittedAt   *time.Time `bson:"submitted_at,omitempty" json:"submitted_at,omitempty"`
+	VerifiedAt    *time.Time `bson:"verified_at,omitempty" json:"verified_at,omitempty"`
+	VerifiedBy    string     `bson:"verified_by,omitempty" json:"verified_by,omitempty"`
+	RejectionNote string     `bson:"rejection_note,omitempty" json:"rejection_note,omitempty"`
+
+	// Dari students dan users
+	StudentNumber string `bson:"student_number" json:"student_number"`
+	StudentName   string `bson:"student_name" json:"student_name"`
+	ProgramStudy  string `bson:"program_study" json:"program_study"`
+	AcademicYear  string `bson:"academic_year" json:"academic_year"`
+	AdvisorID     string `bson:"advisor_id" json:"advisor_id"`
+	AdvisorName   string `bson:"advisor_name" json:"advisor_name"`
+
+	ProjectedAt time.Time `bson:"projected_at" json:"-"`
+}
+
+// NewAchievementView menyusun read model dari data MongoDB dan PostgreSQL.
+// reference dan student boleh nil jika datanya belum ada.
+func NewAchievementView(achievement *Achievement, reference *AchievementReferences, student *StudentDetail) *AchievementView {
+	view := &AchievementView{
+		Achievement: *achievement,
+		ProjectedAt: time.Now(),
+	}
+
+	if reference != nil {
+		view.SubmittedAt = reference.SubmittedAt
+		view.VerifiedAt = reference.VerifiedAt
+		if reference.VerifiedBy != nil {
+			view.VerifiedBy = reference.VerifiedBy.String()
+		}
+		if reference.RejectionNote != nil {
+			view.RejectionNote = *reference.RejectionNote
+		}
+	}
+
+	if student != nil {
+		view.StudentNumber = student.StudentID
+		view.StudentName = student.FullName
+		view.ProgramStudy = student.ProgramStudy
+		view.AcademicYear = student.AcademicYear
+		view.AdvisorID = student.AdvisorID
+		view.AdvisorName = student.AdvisorName
+	}
+
+	return view
+}
+
+// ViewsToAchievements mengambil data achievement dari daftar read model
+func ViewsToAchievements(views []AchievementView) []Achievement {
+	achievements := make([]Achievement, len(views))
+	for i, view := range views {
+		achievements[i] = view.Achievement
+	}
+	return achievements
+}
@@ -0,0 +1,19 @@
+package models
+
+// Cursor menandai posisi pada pagination berbasis cursor (keyset). Value berisi nilai
+// sort key dalam bentuk string (nil jika kosong) dan ID sebagai penentu urutan yang unik.
+// Before bernilai true untuk cursor yang menuju halaman sebelumnya.
+type Cursor struct {
+	SortBy    string  `json:"s"`
+	SortOrder string  `json:"o"`
+	Value     *string `json:"v"`
+	ID        string  `json:"id"`
+	Before    bool    `json:"b,omitempty"`
+}
+
+// CursorPaginationMeta untuk metadata pagination berbasis cursor
+type CursorPaginationMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"`
+	PrevCursor *string `json:"prev_cursor"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status background job export
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportJob background job untuk export achievement yang terlalu besar untuk
+// di-stream langsung. Filter dan cakupan mahasiswa disimpan saat job dibuat.
+type ExportJob struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	JobID  string             `bson:"job_id" json:"job_id"`
+	UserID string             `bson:"user_id" json:"user_id"`
+	Format string             `bson:"format" json:"format"`
+	Status string             `bson:"status" json:"status"`
+	Filter AchievementFilter  `bson:"filter" json:"filter"`
+	// nil berarti semua mahasiswa
+	StudentIDs  []string   `bson:"student_ids" json:"-"`
+	Rows        int        `bson:"rows" json:"rows"`
+	Filepath    string     `bson:"filepath,omitempty" json:"-"`
+	Error       string     `bson:"error,omitempty" json:"error,omitempty"`
+	ExpiresAt   time.Time  `bson:"expires_at" json:"expires_at"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	CompletedAt *time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
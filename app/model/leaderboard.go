@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Dasar peringkat leaderboard
+const (
+	LeaderboardRankByVerified = "verified"
+	LeaderboardRankByPoints   = "points"
+)
+
+// LeaderboardFilter cakupan dan dasar peringkat leaderboard
+type LeaderboardFilter struct {
+	ProgramStudy string     `json:"program_study,omitempty"`
+	AcademicYear string     `json:"academic_year,omitempty"`
+	AdvisorID    string     `json:"advisor_id,omitempty"`
+	DateFrom     *time.Time `json:"date_from,omitempty"`
+	DateTo       *time.Time `json:"date_to,omitempty"`
+	RankBy       string     `json:"rank_by"`
+	Limit        int        `json:"limit"`
+}
+
+// CacheKey kunci cache leaderboard. Leaderboard tidak bergantung pada user yang
+// meminta, sehingga filter yang sama selalu menghasilkan kunci yang sama.
+func (f *LeaderboardFilter) CacheKey() string {
+	date := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("leaderboard:%s|%s|%s|%s|%s|%s|%d",
+		f.ProgramStudy, f.AcademicYear, f.AdvisorID, date(f.DateFrom), date(f.DateTo), f.RankBy, f.Limit)
+}
+
+// LeaderboardEntry satu mahasiswa di leaderboard. Score berisi jumlah achievement
+// verified atau total poin, sesuai dasar peringkat.
+type LeaderboardEntry struct {
+	Rank                 int    `json:"rank"`
+	StudentID            string `json:"student_id" bson:"_id"`
+	StudentNumber        string `json:"student_number" bson:"student_number"`
+	StudentName          string `json:"student_name" bson:"student_name"`
+	ProgramStudy         string `json:"program_study" bson:"program_study"`
+	AcademicYear         string `json:"academic_year" bson:"academic_year"`
+	VerifiedAchievements int    `json:"verified_achievements" bson:"verified_achievements"`
+	TotalPoints          int    `json:"total_points" bson:"total_points"`
+	Score                int    `json:"score" bson:"score"`
+}
+
+// LeaderboardAccepts mengecek apakah entry berikutnya (urutan score menurun) masih masuk
+// leaderboard: selama jumlah entries belum mencapai limit, atau score-nya sama dengan
+// entry di batas limit sehingga mahasiswa dengan peringkat sama tidak terpotong.
+func LeaderboardAccepts(entries []LeaderboardEntry, limit int, next LeaderboardEntry) bool {
+	if len(entries) < limit {
+		return true
+	}
+	return limit > 0 && next.Score == entries[limit-1].Score
+}
+
+// RankLeaderboard mengisi peringkat dari entries yang sudah terurut berdasarkan score
+// menurun. Score yang sama mendapat peringkat yang sama dan peringkat berikutnya
+// dilewati (1, 2, 2, 4).
+func RankLeaderboard(entries []LeaderboardEntry) {
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Rank = entries[i-1].Rank
+			continue
+		}
+		entries[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// RubricAnyCategory kategori rubric yang berlaku untuk semua kategori pada level tersebut
+const RubricAnyCategory = "*"
+
+// ScoringRubric poin kredit untuk achievement dengan kategori dan level tertentu
+type ScoringRubric struct {
+	ID        string    `json:"id"`
+	Category  string    `json:"category"`
+	Level     string    `json:"level"`
+	Points    int       `json:"points"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScoringRubricRequest request untuk membuat atau mengubah rubric
+type ScoringRubricRequest struct {
+	Category string `json:"category"`
+	Level    string `json:"level"`
+	Points   int    `json:"points"`
+}
+
+// ScoringRubrics kumpulan rubric yang dipakai untuk menghitung poin achievement
+type ScoringRubrics []ScoringRubric
+
+// Points menghitung poin achievement. Kategori dan level dicocokkan tanpa membedakan
+// huruf besar/kecil. Jika tidak ada rubric untuk kategori tersebut, dipakai rubric
+// kategori "*" pada level yang sama. Tanpa rubric yang cocok poinnya 0.
+func (rubrics ScoringRubrics) Points(category, level string) int {
+	category = strings.TrimSpace(category)
+	level = strings.TrimSpace(level)
+
+	points, found := 0, false
+	for _, rubric := range rubrics {
+		if !strings.EqualFold(rubric.Level, level) {
+			continue
+		}
+		if strings.EqualFold(rubric.Category, category) {
+			return rubric.Points
+		}
+		if rubric.Category == RubricAnyCategory && !found {
+			points, found = rubric.Points, true
+		}
+	}
+	return points
+}
@@ -0,0 +1,17 @@
+package models
+
+// SeedRole role bawaan aplikasi. ID dipakai langsung oleh kode (1=Admin, 2=Dosen Wali, 3=Mahasiswa).
+type SeedRole struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SeedReport hasil command seed. Hanya menghitung data yang baru dibuat,
+// data yang sudah ada tidak diubah.
+type SeedReport struct {
+	RolesCreated       int  `json:"roles_created"`
+	PermissionsCreated int  `json:"permissions_created"`
+	GrantsCreated      int  `json:"grants_created"`
+	AdminCreated       bool `json:"admin_created"`
+}
@@ -13,14 +13,15 @@ type Student struct {
 }
 
 type StudentDetail struct {
-	ID           string `json:"id"`
-	UserID       string `json:"user_id"`
-	StudentID    string `json:"student_id"`
-	FullName     string `json:"full_name"`
-	ProgramStudy string `json:"program_study"`
-	AcademicYear string `json:"academic_year"`
-	AdvisorID    string `json:"advisor_id"`
-	AdvisorName  string `json:"advisor_name"`
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	StudentID    string    `json:"student_id"`
+	FullName     string    `json:"full_name"`
+	ProgramStudy string    `json:"program_study"`
+	AcademicYear string    `json:"academic_year"`
+	AdvisorID    string    `json:"advisor_id"`
+	AdvisorName  string    `json:"advisor_name"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 // TopStudent untuk statistics top students
@@ -29,4 +30,5 @@ type TopStudent struct {
 	StudentName          string `json:"student_name"`
 	TotalAchievements    int    `json:"total_achievements"`
 	VerifiedAchievements int    `json:"verified_achievements"`
+	TotalPoints          int    `json:"total_points"`
 }
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// AchievementCategory kategori achievement dari master data. Kategori boleh memiliki
+// induk (ParentID) untuk membentuk hierarki, contoh "Kompetisi" > "Kompetisi Akademik".
+// Aliases menampung penulisan lain yang dipetakan ke kategori ini. DetailsSchema
+// berisi JSON Schema untuk field terstruktur achievement (details) kategori ini.
+type AchievementCategory struct {
+	ID            string          `json:"id"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	ParentID      *string         `json:"parent_id"`
+	Aliases       []string        `json:"aliases"`
+	DetailsSchema json.RawMessage `json:"details_schema,omitempty" swaggertype:"object"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// AchievementCategoryRequest request untuk membuat atau mengubah kategori
+type AchievementCategoryRequest struct {
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	ParentID      *string         `json:"parent_id"`
+	Aliases       []string        `json:"aliases"`
+	DetailsSchema json.RawMessage `json:"details_schema,omitempty" swaggertype:"object"`
+}
+
+// AchievementLevel tingkat achievement dari master data, diurutkan dengan SortOrder
+// (contoh: Lokal, Regional, Nasional, Internasional)
+type AchievementLevel struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	SortOrder   int       `json:"sort_order"`
+	Aliases     []string  `json:"aliases"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AchievementLevelRequest request untuk membuat atau mengubah level
+type AchievementLevelRequest struct {
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	SortOrder   int      `json:"sort_order"`
+	Aliases     []string `json:"aliases"`
+}
+
+// Taxonomy seluruh kategori dan level yang dipakai untuk memvalidasi achievement
+type Taxonomy struct {
+	Categories []AchievementCategory `json:"categories"`
+	Levels     []AchievementLevel    `json:"levels"`
+}
+
+// TaxonomyMapping hasil pemetaan satu nilai free-text ke taxonomy.
+// To kosong berarti nilai tidak bisa dipetakan.
+type TaxonomyMapping struct {
+	Field        string `json:"field"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Achievements int64  `json:"achievements"`
+	Created      bool   `json:"created"`
+}
+
+// TaxonomyMigrationReport hasil migrasi kategori dan level achievement di MongoDB
+type TaxonomyMigrationReport struct {
+	DryRun   bool              `json:"dry_run"`
+	Mappings []TaxonomyMapping `json:"mappings"`
+	Unmapped []TaxonomyMapping `json:"unmapped"`
+}
+
+// NormalizeTaxonomyValue menyamakan penulisan untuk pencocokan: spasi di awal/akhir
+// dibuang, spasi berturut-turut dijadikan satu dan huruf dijadikan kecil
+func NormalizeTaxonomyValue(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// ResolveCategory mencari kategori berdasarkan nama, kode atau alias.
+// Nama dan kode didahulukan sebelum alias.
+func (t *Taxonomy) ResolveCategory(value string) (*AchievementCategory, bool) {
+	value = NormalizeTaxonomyValue(value)
+	if value == "" {
+		return nil, false
+	}
+	for i := range t.Categories {
+		category := &t.Categories[i]
+		if NormalizeTaxonomyValue(category.Name) == value || NormalizeTaxonomyValue(category.Code) == value {
+			return category, true
+		}
+	}
+	for i := range t.Categories {
+		if containsTaxonomyValue(t.Categories[i].Aliases, value) {
+			return &t.Categories[i], true
+		}
+	}
+	return nil, false
+}
+
+// ResolveLevel mencari level berdasarkan nama, kode atau alias.
+// Nama dan kode didahulukan sebelum alias.
+func (t *Taxonomy) ResolveLevel(value string) (*AchievementLevel, bool) {
+	value = NormalizeTaxonomyValue(value)
+	if value == "" {
+		return nil, false
+	}
+	for i := range t.Levels {
+		level := &t.Levels[i]
+		if NormalizeTaxonomyValue(level.Name) == value || NormalizeTaxonomyValue(level.Code) == value {
+			return level, true
+		}
+	}
+	for i := range t.Levels {
+		if containsTaxonomyValue(t.Levels[i].Aliases, value) {
+			return &t.Levels[i], true
+		}
+	}
+	return nil, false
+}
+
+// DetailsSchema mengambil JSON Schema details kategori. Kategori tanpa schema
+// memakai schema kategori induk terdekat. Mengembalikan nil jika tidak ada.
+func (t *Taxonomy) DetailsSchema(category *AchievementCategory) json.RawMessage {
+	byID := make(map[string]*AchievementCategory, len(t.Categories))
+	for i := range t.Categories {
+		byID[t.Categories[i].ID] = &t.Categories[i]
+	}
+
+	visited := make(map[string]bool)
+	for current := category; current != nil && !visited[current.ID]; {
+		if len(current.DetailsSchema) > 0 {
+			return current.DetailsSchema
+		}
+		visited[current.ID] = true
+		if current.ParentID == nil {
+			break
+		}
+		current = byID[*current.ParentID]
+	}
+	return nil
+}
+
+// CategoryParentCreatesCycle mengecek apakah menjadikan parentID sebagai induk
+// kategori id akan membentuk siklus (induk adalah kategori itu sendiri atau turunannya)
+func CategoryParentCreatesCycle(categories []AchievementCategory, id, parentID string) bool {
+	parents := make(map[string]string, len(categories))
+	for _, category := range categories {
+		if category.ParentID != nil {
+			parents[category.ID] = *category.ParentID
+		}
+	}
+
+	visited := make(map[string]bool)
+	for current := parentID; current != ""; current = parents[current] {
+		if current == id || visited[current] {
+			return true
+		}
+		visited[current] = true
+	}
+	return false
+}
+
+func containsTaxonomyValue(values []string, normalized string) bool {
+	for _, value := range values {
+		if NormalizeTaxonomyValue(value) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxonomyCode membuat kode dari nama, contoh "Kompetisi Akademik" menjadi "kompetisi-akademik"
+func TaxonomyCode(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Transcript data transkrip prestasi mahasiswa (lampiran SKPI), hanya berisi
+// achievement yang sudah diverifikasi
+type Transcript struct {
+	Student          *StudentDetail
+	Achievements     []AchievementView
+	VerificationCode string
+	GeneratedAt      time.Time
+}
+
+// IssuedTranscript salinan transkrip yang pernah diterbitkan, disimpan per kode
+// verifikasi agar transkrip cetak tetap bisa diverifikasi walaupun daftar achievement
+// mahasiswa berubah setelahnya
+type IssuedTranscript struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	VerificationCode string             `bson:"verification_code" json:"verification_code"`
+	StudentID        string             `bson:"student_id" json:"-"`
+	StudentNumber    string             `bson:"student_number" json:"student_number"`
+	StudentName      string             `bson:"student_name" json:"student_name"`
+	ProgramStudy     string             `bson:"program_study" json:"program_study"`
+	AcademicYear     string             `bson:"academic_year" json:"academic_year"`
+	AdvisorName      string             `bson:"advisor_name" json:"advisor_name"`
+	Achievements     []TranscriptEntry  `bson:"achievements" json:"achievements"`
+	IssuedAt         time.Time          `bson:"issued_at" json:"issued_at"`
+}
+
+// TranscriptEntry satu achievement pada transkrip yang diterbitkan
+type TranscriptEntry struct {
+	AchievementID string     `bson:"achievement_id" json:"achievement_id"`
+	Title         string     `bson:"title" json:"title"`
+	Category      string     `bson:"category" json:"category"`
+	Level         string     `bson:"level" json:"level"`
+	Date          time.Time  `bson:"date" json:"date"`
+	VerifiedAt    *time.Time `bson:"verified_at,omitempty" json:"verified_at,omitempty"`
+}
+
+// NewIssuedTranscript menyusun salinan transkrip yang akan disimpan saat diterbitkan
+func NewIssuedTranscript(transcript *Transcript) *IssuedTranscript {
+	entries := make([]TranscriptEntry, 0, len(transcript.Achievements))
+	for _, achievement := range transcript.Achievements {
+		entries = append(entries, TranscriptEntry{
+			AchievementID: achievement.AchievementID,
+			Title:         achievement.Title,
+			Category:      achievement.Category,
+			Level:         achievement.Level,
+			Date:          achievement.Date,
+			VerifiedAt:    achievement.VerifiedAt,
+		})
+	}
+
+	return &IssuedTranscript{
+		VerificationCode: transcript.VerificationCode,
+		StudentID:        transcript.Student.UserID,
+		StudentNumber:    transcript.Student.StudentID,
+		StudentName:      transcript.Student.FullName,
+		ProgramStudy:     transcript.Student.ProgramStudy,
+		AcademicYear:     transcript.Student.AcademicYear,
+		AdvisorName:      transcript.Student.AdvisorName,
+		Achievements:     entries,
+		IssuedAt:         transcript.GeneratedAt,
+	}
+}
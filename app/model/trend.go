@@ -0,0 +1,24 @@
+package models
+
+// TrendFilter parameter laporan trend achievement: filter statistik, dimensi
+// pengelompokan dan ukuran periode (month, semester atau year)
+type TrendFilter struct {
+	StatisticsFilter
+	GroupBy []string `json:"group_by"`
+	Bucket  string   `json:"bucket"`
+}
+
+// TrendPoint ringkasan achievement untuk satu periode dan satu kombinasi dimensi
+type TrendPoint struct {
+	Period            string            `json:"period"`
+	Group             map[string]string `json:"group,omitempty"`
+	Total             int               `json:"total"`
+	Verified          int               `json:"verified"`
+	Rejected          int               `json:"rejected"`
+	Pending           int               `json:"pending"`
+	Draft             int               `json:"draft"`
+	VerificationRate  *float64          `json:"verification_rate"`
+	AvgHoursToVerify  *float64          `json:"avg_hours_to_verify"`
+	PreviousYearTotal *int              `json:"previous_year_total"`
+	YearOverYear      *float64          `json:"year_over_year"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UploadSession menyimpan progres upload bertahap (resumable) sebuah file
+type UploadSession struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	UploadID      string             `bson:"upload_id" json:"upload_id"`
+	AchievementID string             `bson:"achievement_id" json:"achievement_id"`
+	StudentID     string             `bson:"student_id" json:"student_id"`
+	Filename      string             `bson:"filename" json:"filename"`
+	Filesize      int64              `bson:"filesize" json:"filesize"`
+	Offset        int64              `bson:"offset" json:"offset"`
+	// Hash SHA-256 (hex) seluruh file dari client, dicek saat upload selesai
+	Checksum    string    `bson:"checksum,omitempty" json:"checksum,omitempty"`
+	PartialPath string    `bson:"partial_path" json:"-"`
+	ExpiresAt   time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CreateUploadSessionRequest request untuk memulai upload bertahap
+type CreateUploadSessionRequest struct {
+	Filename string `json:"filename"`
+	Filesize int64  `json:"filesize"`
+	Checksum string `json:"checksum"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserImportRow satu baris file import user. Kolom profil mahasiswa (student_id,
+// program_study, academic_year, advisor_nip) dan dosen (lecturer_id, department)
+// opsional sesuai role.
+type UserImportRow struct {
+	Row          int      `json:"row"`
+	Username     string   `json:"username"`
+	Email        string   `json:"email"`
+	FullName     string   `json:"full_name"`
+	Role         string   `json:"role"`
+	RoleID       string   `json:"role_id"`
+	IsActive     bool     `json:"is_active"`
+	StudentID    string   `json:"student_id,omitempty"`
+	ProgramStudy string   `json:"program_study,omitempty"`
+	AcademicYear string   `json:"academic_year,omitempty"`
+	AdvisorNIP   string   `json:"advisor_nip,omitempty"`
+	AdvisorID    string   `json:"advisor_id,omitempty"`
+	LecturerID   string   `json:"lecturer_id,omitempty"`
+	Department   string   `json:"department,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// UserImportLookup data yang sudah ada di database untuk validasi import.
+// Username, email, NIM dan NIP disimpan dalam huruf kecil.
+type UserImportLookup struct {
+	// Role berdasarkan ID dan nama (huruf kecil) ke role ID
+	Roles               map[string]string
+	ExistingUsernames   map[string]bool
+	ExistingEmails      map[string]bool
+	ExistingStudentIDs  map[string]bool
+	ExistingLecturerIDs map[string]bool
+	// NIP dosen ke user ID dosen
+	Lecturers map[string]string
+}
+
+// UserImportReport hasil validasi atau import user
+type UserImportReport struct {
+	DryRun      bool            `json:"dry_run"`
+	TotalRows   int             `json:"total_rows"`
+	ValidRows   int             `json:"valid_rows"`
+	InvalidRows int             `json:"invalid_rows"`
+	Imported    int             `json:"imported"`
+	Errors      []UserImportRow `json:"errors"`
+	// Kredensial user baru hanya bisa diunduh sekali dari CredentialsURL
+	CredentialsURL       string     `json:"credentials_url,omitempty"`
+	CredentialsExpiresAt *time.Time `json:"credentials_expires_at,omitempty"`
+}
+
+// UserImportCredentials password awal user hasil import, disimpan terenkripsi
+// (AES-256-GCM) sampai diunduh sekali oleh admin yang melakukan import
+type UserImportCredentials struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	ImportID   string             `bson:"import_id"`
+	UserID     string             `bson:"user_id"`
+	Users      int                `bson:"users"`
+	Ciphertext []byte             `bson:"ciphertext"`
+	ExpiresAt  time.Time          `bson:"expires_at"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
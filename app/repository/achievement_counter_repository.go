@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Cakupan counter statistik achievement
+const (
+	CounterScopeStudent = "student"
+	CounterScopeAdvisor = "advisor"
+)
+
+// AchievementCounterRepository mengelola counter statistik achievement (collection
+// achievement_counters) per mahasiswa dan per dosen wali, dipecah berdasarkan status,
+// kategori, level dan bulan. Counter diperbarui setiap kali read model berubah.
+type AchievementCounterRepository struct {
+	collection *mongo.Collection
+	views      *mongo.Collection
+}
+
+func NewAchievementCounterRepository(db *mongo.Database) *AchievementCounterRepository {
+	return &AchievementCounterRepository{
+		collection: db.Collection("achievement_counters"),
+		views:      db.Collection("achievement_views"),
+	}
+}
+
+type counterKey struct {
+	Scope    string `bson:"scope"`
+	OwnerID  string `bson:"owner_id"`
+	Status   string `bson:"status"`
+	Category string `bson:"category"`
+	Level    string `bson:"level"`
+	Period   string `bson:"period"`
+}
+
+// CounterPeriod periode (tahun-bulan) counter untuk tanggal achievement
+func CounterPeriod(date time.Time) string {
+	return date.UTC().Format("2006-01")
+}
+
+// CounterPeriodRange mengubah rentang tanggal filter menjadi rentang periode counter.
+// ok bernilai false jika rentangnya tidak tepat satu bulan penuh, sehingga statistik
+// harus dihitung dari read model.
+func CounterPeriodRange(filter *models.StatisticsFilter) (from, to string, ok bool) {
+	if filter == nil {
+		return "", "", true
+	}
+	if filter.DateFrom != nil {
+		if filter.DateFrom.Day() != 1 {
+			return "", "", false
+		}
+		from = CounterPeriod(*filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		if filter.DateTo.AddDate(0, 0, 1).Day() != 1 {
+			return "", "", false
+		}
+		to = CounterPeriod(*filter.DateTo)
+	}
+	return from, to, true
+}
+
+func counterKeys(view *models.AchievementView) []counterKey {
+	if view == nil {
+		return nil
+	}
+
+	key := counterKey{
+		Scope:    CounterScopeStudent,
+		OwnerID:  view.StudentID,
+		Status:   view.Status,
+		Category: view.Category,
+		Level:    view.Level,
+		Period:   CounterPeriod(view.Date),
+	}
+	keys := []counterKey{key}
+	if view.AdvisorID != "" {
+		key.Scope = CounterScopeAdvisor
+		key.OwnerID = view.AdvisorID
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// EnsureIndexes membuat index counter
+func (r *AchievementCounterRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "scope", Value: 1},
+				{Key: "owner_id", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "category", Value: 1},
+				{Key: "level", Value: 1},
+				{Key: "period", Value: 1},
+			},
+			Options: options.Index().SetName("counter_key_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "scope", Value: 1}, {Key: "period", Value: 1}},
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Apply memindahkan hitungan dari versi read model sebelumnya ke versi terbaru.
+// previous nil berarti achievement baru, current nil berarti achievement dihapus.
+func (r *AchievementCounterRepository) Apply(ctx context.Context, previous, current *models.AchievementView) error {
+	deltas := make(map[counterKey]int)
+	for _, key := range counterKeys(previous) {
+		deltas[key]--
+	}
+	for _, key := range counterKeys(current) {
+		deltas[key]++
+	}
+
+	var writes []mongo.WriteModel
+	for key, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(key).
+			SetUpdate(bson.M{"$inc": bson.M{"count": delta}}).
+			SetUpsert(true))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// GetStatistics menjumlahkan counter menjadi statistik. ownerID kosong berarti semua
+// pemilik dalam scope. Rentang tanggal filter harus bulan penuh (lihat CounterPeriodRange).
+func (r *AchievementCounterRepository) GetStatistics(ctx context.Context, scope, ownerID string, filter *models.StatisticsFilter) (map[string]interface{}, error) {
+	match := bson.M{"scope": scope, "count": bson.M{"$gt": 0}}
+	if ownerID != "" {
+		match["owner_id"] = ownerID
+	}
+	if filter != nil && filter.Status != "" {
+		match["status"] = filter.Status
+	}
+
+	from, to, _ := CounterPeriodRange(filter)
+	period := bson.M{}
+	if from != "" {
+		period["$gte"] = from
+	}
+	if to != "" {
+		period["$lte"] = to
+	}
+	if len(period) > 0 {
+		match["period"] = period
+	}
+
+	return aggregateStatistics(ctx, r.collection, statisticsFacetPipeline(match, "$count", "$period"))
+}
+
+// Count menghitung jumlah dokumen counter
+func (r *AchievementCounterRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+// Rebuild menghitung ulang seluruh counter dari read model achievement_views.
+// Dipakai untuk pemulihan jika counter tidak sinkron.
+func (r *AchievementCounterRepository) Rebuild(ctx context.Context) (int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"is_deleted": false}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"student_id": "$student_id",
+				"advisor_id": "$advisor_id",
+				"status":     "$status",
+				"category":   "$category",
+				"level":      "$level",
+				"period":     bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$date"}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.views.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Key struct {
+			StudentID string `bson:"student_id"`
+			AdvisorID string `bson:"advisor_id"`
+			Status    string `bson:"status"`
+			Category  string `bson:"category"`
+			Level     string `bson:"level"`
+			Period    string `bson:"period"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return 0, err
+	}
+
+	counts := make(map[counterKey]int)
+	for _, group := range groups {
+		key := counterKey{
+			Scope:    CounterScopeStudent,
+			OwnerID:  group.Key.StudentID,
+			Status:   group.Key.Status,
+			Category: group.Key.Category,
+			Level:    group.Key.Level,
+			Period:   group.Key.Period,
+		}
+		counts[key] += group.Count
+		if group.Key.AdvisorID != "" {
+			key.Scope = CounterScopeAdvisor
+			key.OwnerID = group.Key.AdvisorID
+			counts[key] += group.Count
+		}
+	}
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{}); err != nil {
+		return 0, err
+	}
+	if len(counts) == 0 {
+		return 0, nil
+	}
+
+	docs := make([]interface{}, 0, len(counts))
+	for key, count := range counts {
+		docs = append(docs, bson.M{
+			"scope":    key.Scope,
+			"owner_id": key.OwnerID,
+			"status":   key.Status,
+			"category": key.Category,
+			"level":    key.Level,
+			"period":   key.Period,
+			"count":    count,
+		})
+	}
+	if _, err := r.collection.InsertMany(ctx, docs); err != nil {
+		return 0, err
+	}
+
+	return len(docs), nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	models "crud-app/app/model"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// AchievementImportRepository akses data PostgreSQL untuk import achievement historis
+type AchievementImportRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementImportRepository(db *sql.DB) *AchievementImportRepository {
+	return &AchievementImportRepository{db: db}
+}
+
+// Lookup mengambil user ID mahasiswa berdasarkan NIM dan verifikator (dosen atau
+// admin aktif) berdasarkan username atau NIP yang dipakai file import
+func (r *AchievementImportRepository) Lookup(rows []models.AchievementImportRow) (*models.AchievementImportLookup, error) {
+	var studentNumbers, verifiers []string
+	for _, row := range rows {
+		if row.StudentNumber != "" {
+			studentNumbers = append(studentNumbers, strings.ToLower(row.StudentNumber))
+		}
+		if row.VerifiedBy != "" {
+			verifiers = append(verifiers, strings.ToLower(row.VerifiedBy))
+		}
+	}
+
+	lookup := &models.AchievementImportLookup{
+		Students:     make(map[string]string),
+		Verifiers:    make(map[string]string),
+		ExistingKeys: make(map[string]string),
+	}
+
+	if len(studentNumbers) > 0 {
+		err := r.collect(lookup.Students, `
+			SELECT LOWER(s.student_id), s.user_id::text
+			FROM students s
+			JOIN users u ON u.id = s.user_id
+			WHERE LOWER(s.student_id) = ANY($1) AND u.deleted_at IS NULL
+		`, pq.Array(studentNumbers))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(verifiers) > 0 {
+		err := r.collect(lookup.Verifiers, `
+			SELECT LOWER(u.username), u.id::text
+			FROM users u
+			WHERE LOWER(u.username) = ANY($1) AND u.role_id::text IN ('1', '2') AND u.deleted_at IS NULL
+			UNION ALL
+			SELECT LOWER(l.lecturer_id), u.id::text
+			FROM lecturers l
+			JOIN users u ON u.id = l.user_id
+			WHERE LOWER(l.lecturer_id) = ANY($1) AND u.deleted_at IS NULL
+		`, pq.Array(verifiers))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lookup, nil
+}
+
+func (r *AchievementImportRepository) collect(target map[string]string, query string, args ...interface{}) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		target[key] = value
+	}
+	return rows.Err()
+}
@@ -554,4 +554,27 @@ func (r *AchievementReferenceRepository) GetAllTopStudents(limit int) ([]models.
 	}
 
 	return topStudents, nil
-}
\ No newline at end of file
+}
+// CreateImported menyimpan reference achievement hasil import historis beserta
+// waktu submit dan data verifikasinya
+func (r *AchievementReferenceRepository) CreateImported(ref *models.AchievementReferences) error {
+	query := `
+		INSERT INTO achievement_references
+		(id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		ref.ID,
+		ref.StudentID,
+		ref.MongoAchievementID,
+		ref.Status,
+		ref.SubmittedAt,
+		ref.VerifiedAt,
+		ref.VerifiedBy,
+		ref.CreatedAt,
+		ref.UpdatedAt,
+	)
+	return err
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 "context"
+"errors"
 models "crud-app/app/model"
+"crud-app/app/utils"
+"regexp"
 "time"
 
 "go.mongodb.org/mongo-driver/bson"
 "go.mongodb.org/mongo-driver/bson/primitive"
 "go.mongodb.org/mongo-driver/mongo"
+"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AchievementRepository struct {
@@ -25,6 +29,7 @@ func (r *AchievementRepository) Create(ctx context.Context, achievement *models.
 achievement.ID = primitive.NewObjectID()
 achievement.CreatedAt = time.Now()
 achievement.UpdatedAt = time.Now()
+achievement.SchemaVersion = models.AchievementSchemaVersion
 
 _, err := r.collection.InsertOne(ctx, achievement)
 return err
@@ -70,6 +75,7 @@ return achievements, nil
 // Update mengupdate achievement
 func (r *AchievementRepository) Update(ctx context.Context, achievementID string, achievement *models.Achievement) error {
 achievement.UpdatedAt = time.Now()
+achievement.SchemaVersion = models.AchievementSchemaVersion
 filter := bson.M{"achievement_id": achievementID}
 update := bson.M{"$set": achievement}
 
@@ -155,72 +161,442 @@ return nil, err
 return achievements, nil
 }
 
-// GetStatisticsByStudentIDs - Get statistics untuk multiple students (FR-011)
-func (r *AchievementRepository) GetStatisticsByStudentIDs(ctx context.Context, studentIDs []string) (map[string]interface{}, error) {
+// GetStatisticsByStudentIDs - Get statistics untuk multiple students (FR-011).
+// Dihitung dengan aggregation pipeline, filter boleh nil.
+func (r *AchievementRepository) GetStatisticsByStudentIDs(ctx context.Context, studentIDs []string, filter *models.StatisticsFilter) (map[string]interface{}, error) {
+	if studentIDs == nil {
+		studentIDs = []string{}
+	}
+	return aggregateStatistics(ctx, r.collection, StatisticsPipeline(StatisticsMatch(studentIDs, filter)))
+}
+
+// GetStorageUsageByStudentID menghitung total ukuran dokumen milik student (exclude deleted)
+func (r *AchievementRepository) GetStorageUsageByStudentID(ctx context.Context, studentID string) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"student_id": studentID, "is_deleted": false}}},
+		{{Key: "$unwind", Value: "$documents"}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$documents.filesize"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0].Total, nil
+}
+
+// FindAllDocumentPaths mengambil semua filepath dokumen yang masih direferensikan
+// oleh achievement (termasuk yang soft-deleted namun belum di-purge)
+func (r *AchievementRepository) FindAllDocumentPaths(ctx context.Context) (map[string]bool, error) {
+	opts := options.Find().SetProjection(bson.M{"documents.filepath": 1, "documents.thumbnail_path": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"documents.0": bson.M{"$exists": true}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	paths := make(map[string]bool)
+	for cursor.Next(ctx) {
+		var achievement models.Achievement
+		if err := cursor.Decode(&achievement); err != nil {
+			return nil, err
+		}
+		for _, doc := range achievement.Documents {
+			paths[doc.Filepath] = true
+			if doc.ThumbnailPath != "" {
+				paths[doc.ThumbnailPath] = true
+			}
+		}
+	}
+
+	return paths, cursor.Err()
+}
+
+// FindDeletedBefore mencari achievement soft-deleted sebelum cutoff yang masih memiliki dokumen
+func (r *AchievementRepository) FindDeletedBefore(ctx context.Context, cutoff time.Time) ([]models.Achievement, error) {
+	var achievements []models.Achievement
 	filter := bson.M{
-		"student_id": bson.M{"$in": studentIDs},
-		"is_deleted": false,
+		"is_deleted":  true,
+		"deleted_at":  bson.M{"$lt": cutoff},
+		"documents.0": bson.M{"$exists": true},
 	}
 
-	// Get all achievements
-	achievements, err := r.FindAll(ctx, filter)
+	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	// Calculate statistics
-	stats := make(map[string]interface{})
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
 
-	// Summary
-	totalAchievements := len(achievements)
-	totalVerified := 0
-	totalPending := 0
-	totalRejected := 0
-	totalDraft := 0
+	return achievements, nil
+}
 
-	// Category count
-	categoryCount := make(map[string]int)
-	// Level count
-	levelCount := make(map[string]int)
-	// Period count (year-month)
-	periodCount := make(map[string]int)
+// ClearDocuments mengosongkan daftar dokumen achievement setelah file di-purge
+func (r *AchievementRepository) ClearDocuments(ctx context.Context, achievementID string) error {
+	filter := bson.M{"achievement_id": achievementID}
+	update := bson.M{
+		"$set": bson.M{
+			"documents":  []models.Document{},
+			"updated_at": time.Now(),
+		},
+	}
 
-	for _, achievement := range achievements {
-		// Count by status
-		switch achievement.Status {
-		case "verified":
-			totalVerified++
-		case "submitted":
-			totalPending++
-		case "rejected":
-			totalRejected++
-		case "draft":
-			totalDraft++
-		}
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
 
-		// Count by category
-		if achievement.Category != "" {
-			categoryCount[achievement.Category]++
-		}
+// FindByDocumentScanStatus mencari achievement yang memiliki dokumen dengan scan_status tertentu
+func (r *AchievementRepository) FindByDocumentScanStatus(ctx context.Context, scanStatus string) ([]models.Achievement, error) {
+	var achievements []models.Achievement
+	filter := bson.M{"documents.scan_status": scanStatus}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
+
+	return achievements, nil
+}
+
+// UpdateDocumentScan menyimpan hasil scan antivirus sebuah dokumen
+func (r *AchievementRepository) UpdateDocumentScan(ctx context.Context, achievementID, documentID string, scan *utils.QuarantineScan) error {
+	filter := bson.M{
+		"achievement_id": achievementID,
+		"documents.id":   documentID,
+	}
+	fields := bson.M{
+		"documents.$.filepath":       scan.Filepath,
+		"documents.$.scan_status":    scan.ScanStatus,
+		"documents.$.scan_signature": scan.ScanSignature,
+		"documents.$.scanned_at":     time.Now(),
+	}
+	if scan.Filesize > 0 {
+		fields["documents.$.filesize"] = scan.Filesize
+	}
+	if scan.ThumbnailPath != "" {
+		fields["documents.$.thumbnail_path"] = scan.ThumbnailPath
+		fields["documents.$.thumbnail_url"] = models.DocumentThumbnailURL(achievementID, documentID)
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": fields})
+	return err
+}
+
+// FindByDocumentHashes mencari achievement lain yang memiliki dokumen dengan hash SHA-256 tertentu
+func (r *AchievementRepository) FindByDocumentHashes(ctx context.Context, hashes []string, excludeAchievementID string) ([]models.Achievement, error) {
+	var achievements []models.Achievement
+	filter := bson.M{
+		"achievement_id":   bson.M{"$ne": excludeAchievementID},
+		"documents.sha256": bson.M{"$in": hashes},
+		"is_deleted":       false,
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
+
+	return achievements, nil
+}
+
+// UpdateDuplicateFlags menyimpan hasil pengecekan dokumen duplikat
+func (r *AchievementRepository) UpdateDuplicateFlags(ctx context.Context, achievementID string, flags []models.DuplicateFlag) error {
+	filter := bson.M{"achievement_id": achievementID}
+	update := bson.M{
+		"$set": bson.M{
+			"duplicate_flags": flags,
+			"updated_at":      time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// AddDocument menambahkan satu dokumen ke achievement berstatus draft
+func (r *AchievementRepository) AddDocument(ctx context.Context, achievementID string, document models.Document) error {
+	filter := bson.M{
+		"achievement_id": achievementID,
+		"status":         "draft",
+		"is_deleted":     false,
+	}
+	update := bson.M{
+		"$push": bson.M{"documents": document},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdatePoints menyimpan poin rubric achievement
+func (r *AchievementRepository) UpdatePoints(ctx context.Context, achievementID string, points int) error {
+	filter := bson.M{"achievement_id": achievementID}
+	update := bson.M{
+		"$set": bson.M{
+			"points":     points,
+			"updated_at": time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// UpdateStatusAndPoints mengupdate status dan poin achievement dalam satu update,
+// sehingga poin tidak tertinggal jika perubahan status gagal
+func (r *AchievementRepository) UpdateStatusAndPoints(ctx context.Context, achievementID string, status string, points int) error {
+	filter := bson.M{"achievement_id": achievementID}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"points":     points,
+			"updated_at": time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// DistinctValues mengambil nilai unik sebuah field (misalnya category atau level),
+// termasuk achievement yang sudah dihapus
+func (r *AchievementRepository) DistinctValues(ctx context.Context, field string) ([]string, error) {
+	raw, err := r.collection.Distinct(ctx, field, bson.M{})
+	if err != nil {
+		return nil, err
+	}
 
-		// Count by level
-		if achievement.Level != "" {
-			levelCount[achievement.Level]++
+	values := make([]string, 0, len(raw))
+	for _, value := range raw {
+		if s, ok := value.(string); ok {
+			values = append(values, s)
 		}
+	}
+	return values, nil
+}
+
+// CountByValue menghitung achievement (termasuk yang sudah dihapus) dengan nilai field persis
+func (r *AchievementRepository) CountByValue(ctx context.Context, field, value string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{field: value})
+}
+
+// CountActiveByValueIgnoreCase menghitung achievement aktif dengan nilai field
+// tanpa membedakan huruf besar/kecil
+func (r *AchievementRepository) CountActiveByValueIgnoreCase(ctx context.Context, field, value string) (int64, error) {
+	filter := bson.M{
+		field:        primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"},
+		"is_deleted": false,
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// ReplaceValue mengganti nilai field dari from menjadi to dan mengembalikan
+// achievement_id yang berubah agar read model bisa diperbarui
+func (r *AchievementRepository) ReplaceValue(ctx context.Context, field, from, to string) ([]string, error) {
+	filter := bson.M{field: from}
+	opts := options.Find().SetProjection(bson.M{"achievement_id": 1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var achievements []models.Achievement
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
 
-		// Count by period (year-month)
-		yearMonth := achievement.Date.Format("2006-01")
-		periodCount[yearMonth]++
+	update := bson.M{"$set": bson.M{field: to, "updated_at": time.Now()}}
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+
+	achievementIDs := make([]string, 0, len(achievements))
+	for _, achievement := range achievements {
+		achievementIDs = append(achievementIDs, achievement.AchievementID)
+	}
+	return achievementIDs, nil
+}
+
+// EnsureIndexes membuat index untuk query achievement: unique achievement_id, filter
+// mahasiswa/status (exclude deleted), dokumen, schema_version untuk migration dokumen,
+// dan unique external_key agar import achievement historis tidak membuat duplikat
+// walaupun dijalankan bersamaan
+func (r *AchievementRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "achievement_id", Value: 1}},
+			Options: options.Index().SetName("achievement_id_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "student_id", Value: 1}, {Key: "is_deleted", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "is_deleted", Value: 1}},
+		},
+		{
+			// Garbage collection file achievement yang sudah dihapus
+			Keys: bson.D{{Key: "is_deleted", Value: 1}, {Key: "deleted_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "documents.sha256", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "documents.scan_status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "schema_version", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "external_key", Value: 1}},
+			Options: options.Index().SetName("external_key_unique").SetUnique(true).SetSparse(true),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// AchievementJSONSchema validator $jsonSchema collection achievements sesuai models.Achievement
+func AchievementJSONSchema() bson.M {
+	stringType := bson.M{"bsonType": "string"}
+	intType := bson.M{"bsonType": bson.A{"int", "long"}}
+	return bson.M{
+		"bsonType": "object",
+		"required": bson.A{"achievement_id", "student_id", "title", "category", "level", "status", "is_deleted", "schema_version", "created_at", "updated_at"},
+		"properties": bson.M{
+			"achievement_id": bson.M{"bsonType": "string", "minLength": 1},
+			"student_id":     bson.M{"bsonType": "string", "minLength": 1},
+			"title":          stringType,
+			"category":       stringType,
+			"level":          stringType,
+			"date":           bson.M{"bsonType": "date"},
+			"description":    stringType,
+			"documents": bson.M{
+				"bsonType": bson.A{"array", "null"},
+				"items": bson.M{
+					"bsonType": "object",
+					"required": bson.A{"filename", "filepath"},
+					"properties": bson.M{
+						"id":       stringType,
+						"filename": stringType,
+						"filepath": stringType,
+						"filesize": intType,
+					},
+				},
+			},
+			"status":          bson.M{"enum": bson.A{"draft", "submitted", "verified", "rejected"}},
+			"points":          intType,
+			"is_deleted":      bson.M{"bsonType": "bool"},
+			"deleted_at":      bson.M{"bsonType": bson.A{"date", "null"}},
+			"created_at":      bson.M{"bsonType": "date"},
+			"updated_at":      bson.M{"bsonType": "date"},
+			"duplicate_flags": bson.M{"bsonType": bson.A{"array", "null"}},
+			"details":         bson.M{"bsonType": bson.A{"object", "null"}},
+			"external_key":    stringType,
+			"schema_version":  bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1},
+		},
+	}
+}
+
+// EnsureValidator memasang validator $jsonSchema ke collection achievements. Level
+// moderate: dokumen lama yang belum valid tetap bisa diupdate sampai dimigrasi.
+func (r *AchievementRepository) EnsureValidator(ctx context.Context) error {
+	validator := bson.M{"$jsonSchema": AchievementJSONSchema()}
+	db := r.collection.Database()
+
+	err := db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: r.collection.Name()},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}).Err()
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Code == 26 { // NamespaceNotFound
+		opts := options.CreateCollection().
+			SetValidator(validator).
+			SetValidationLevel("moderate").
+			SetValidationAction("error")
+		return db.CreateCollection(ctx, r.collection.Name(), opts)
+	}
+	return err
+}
+
+// FindBelowSchemaVersion mengambil maksimal limit dokumen mentah dengan schema_version
+// di bawah version (termasuk dokumen lama tanpa schema_version), untuk migration dokumen
+func (r *AchievementRepository) FindBelowSchemaVersion(ctx context.Context, version int, limit int64) ([]bson.M, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"schema_version": bson.M{"$exists": false}},
+		bson.M{"schema_version": bson.M{"$lt": version}},
+	}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
 	}
+	var documents []bson.M
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
 
-	stats["total_achievements"] = totalAchievements
-	stats["total_verified"] = totalVerified
-	stats["total_pending"] = totalPending
-	stats["total_rejected"] = totalRejected
-	stats["total_draft"] = totalDraft
-	stats["category_count"] = categoryCount
-	stats["level_count"] = levelCount
-	stats["period_count"] = periodCount
+// ReplaceRaw mengganti dokumen mentah hasil migration berdasarkan _id
+func (r *AchievementRepository) ReplaceRaw(ctx context.Context, document bson.M) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": document["_id"]}, document)
+	return err
+}
+
+// FindByExternalKeys mengambil achievement_id dari external key yang sudah pernah
+// diimport, termasuk achievement yang sudah dihapus
+func (r *AchievementRepository) FindByExternalKeys(ctx context.Context, keys []string) (map[string]string, error) {
+	existing := make(map[string]string)
+	if len(keys) == 0 {
+		return existing, nil
+	}
 
-	return stats, nil
-}
\ No newline at end of file
+	filter := bson.M{"external_key": bson.M{"$in": keys}}
+	opts := options.Find().SetProjection(bson.M{"achievement_id": 1, "external_key": 1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var achievements []models.Achievement
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, err
+	}
+
+	for _, achievement := range achievements {
+		existing[achievement.ExternalKey] = achievement.AchievementID
+	}
+	return existing, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StatisticsMatch menyusun filter $match statistik achievement.
+// studentIDs nil berarti semua mahasiswa.
+func StatisticsMatch(studentIDs []string, filter *models.StatisticsFilter) bson.M {
+	match := bson.M{"is_deleted": false}
+	if studentIDs != nil {
+		match["student_id"] = bson.M{"$in": studentIDs}
+	}
+	if filter == nil {
+		return match
+	}
+
+	if filter.Status != "" {
+		match["status"] = filter.Status
+	}
+	dateRange := bson.M{}
+	if filter.DateFrom != nil {
+		dateRange["$gte"] = *filter.DateFrom
+	}
+	if filter.DateTo != nil {
+		// date_to inklusif sampai akhir hari
+		dateRange["$lt"] = filter.DateTo.AddDate(0, 0, 1)
+	}
+	if len(dateRange) > 0 {
+		match["date"] = dateRange
+	}
+	return match
+}
+
+// StatisticsPipeline menghitung ringkasan status, kategori, level dan periode
+// (tahun-bulan) dalam satu aggregation $facet
+func StatisticsPipeline(match bson.M) mongo.Pipeline {
+	period := bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$date"}}
+	return statisticsFacetPipeline(match, 1, period)
+}
+
+// statisticsFacetPipeline menjumlahkan count per status, kategori, level dan periode.
+// count berupa 1 untuk dokumen achievement atau "$count" untuk dokumen counter.
+func statisticsFacetPipeline(match bson.M, count interface{}, period interface{}) mongo.Pipeline {
+	countBy := func(field interface{}) bson.M {
+		return bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": count}}}
+	}
+	notEmpty := func(field string) bson.M {
+		return bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{nil, ""}}}}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"by_status":   bson.A{countBy("$status")},
+			"by_category": bson.A{notEmpty("category"), countBy("$category")},
+			"by_level":    bson.A{notEmpty("level"), countBy("$level")},
+			"by_period":   bson.A{countBy(period)},
+		}}},
+	}
+}
+
+// StatisticsFacet hasil $facet dari StatisticsPipeline
+type StatisticsFacet struct {
+	ByStatus   []StatisticsBucket `bson:"by_status"`
+	ByCategory []StatisticsBucket `bson:"by_category"`
+	ByLevel    []StatisticsBucket `bson:"by_level"`
+	ByPeriod   []StatisticsBucket `bson:"by_period"`
+}
+
+type StatisticsBucket struct {
+	Key   *string `bson:"_id"`
+	Count int     `bson:"count"`
+}
+
+// ToMap mengubah hasil aggregation menjadi map statistik yang dipakai buildStatisticsResponse
+func (f StatisticsFacet) ToMap() map[string]interface{} {
+	statusCount := make(map[string]int)
+	totalAchievements := 0
+	for _, bucket := range f.ByStatus {
+		totalAchievements += bucket.Count
+		if bucket.Key != nil {
+			statusCount[*bucket.Key] += bucket.Count
+		}
+	}
+
+	return map[string]interface{}{
+		"total_achievements": totalAchievements,
+		"total_verified":     statusCount["verified"],
+		"total_pending":      statusCount["submitted"],
+		"total_rejected":     statusCount["rejected"],
+		"total_draft":        statusCount["draft"],
+		"category_count":     bucketCounts(f.ByCategory),
+		"level_count":        bucketCounts(f.ByLevel),
+		"period_count":       bucketCounts(f.ByPeriod),
+	}
+}
+
+func bucketCounts(buckets []StatisticsBucket) map[string]int {
+	counts := make(map[string]int, len(buckets))
+	for _, bucket := range buckets {
+		if bucket.Key != nil {
+			counts[*bucket.Key] += bucket.Count
+		}
+	}
+	return counts
+}
+
+// aggregateStatistics menjalankan pipeline statistik $facet pada collection
+func aggregateStatistics(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline) (map[string]interface{}, error) {
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facet StatisticsFacet
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&facet); err != nil {
+			return nil, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return facet.ToMap(), nil
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ukuran periode laporan trend
+const (
+	TrendBucketMonth    = "month"
+	TrendBucketSemester = "semester"
+	TrendBucketYear     = "year"
+)
+
+// TrendDimensions dimensi pengelompokan trend dan nama field-nya di read model
+var TrendDimensions = map[string]string{
+	"program_study": "program_study",
+	"academic_year": "academic_year",
+	"category":      "category",
+	"level":         "level",
+	"advisor":       "advisor_id",
+}
+
+// TrendAdvisorNameKey key nama dosen wali pada TrendPoint.Group saat dikelompokkan per
+// advisor. Pengelompokan memakai advisor_id agar dosen dengan nama sama tidak tergabung.
+const TrendAdvisorNameKey = "advisor_name"
+
+// TrendPeriod periode trend untuk tanggal achievement. Semester mengikuti semester
+// akademik: Ganjil Agustus-Januari dan Genap Februari-Juli, contoh "2023/2024-Ganjil".
+func TrendPeriod(bucket string, date time.Time) string {
+	date = date.UTC()
+	switch bucket {
+	case TrendBucketYear:
+		return date.Format("2006")
+	case TrendBucketSemester:
+		startYear, semester := date.Year()-1, "Genap"
+		switch {
+		case date.Month() >= time.August:
+			startYear, semester = date.Year(), "Ganjil"
+		case date.Month() == time.January:
+			semester = "Ganjil"
+		}
+		return fmt.Sprintf("%d/%d-%s", startYear, startYear+1, semester)
+	}
+	return date.Format("2006-01")
+}
+
+// trendPeriodExpression ekspresi aggregation yang sama dengan TrendPeriod
+func trendPeriodExpression(bucket string) interface{} {
+	switch bucket {
+	case TrendBucketYear:
+		return bson.M{"$dateToString": bson.M{"format": "%Y", "date": "$date"}}
+	case TrendBucketSemester:
+		return bson.M{"$let": bson.M{
+			"vars": bson.M{
+				"month": bson.M{"$month": "$date"},
+				"year":  bson.M{"$year": "$date"},
+			},
+			"in": bson.M{"$let": bson.M{
+				"vars": bson.M{
+					"start": bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$$month", 8}}, "$$year", bson.M{"$subtract": bson.A{"$$year", 1}}}},
+				},
+				"in": bson.M{"$concat": bson.A{
+					bson.M{"$toString": "$$start"},
+					"/",
+					bson.M{"$toString": bson.M{"$add": bson.A{"$$start", 1}}},
+					bson.M{"$cond": bson.A{
+						bson.M{"$or": bson.A{bson.M{"$gte": bson.A{"$$month", 8}}, bson.M{"$eq": bson.A{"$$month", 1}}}},
+						"-Ganjil",
+						"-Genap",
+					}},
+				}},
+			}},
+		}}
+	}
+	return bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$date"}}
+}
+
+// TrendsPipeline mengelompokkan achievement per periode dan dimensi, menghitung
+// jumlah per status dan rata-rata waktu dari submit sampai verified (milidetik)
+func TrendsPipeline(match bson.M, groupBy []string, bucket string) mongo.Pipeline {
+	id := bson.D{{Key: "period", Value: trendPeriodExpression(bucket)}}
+	sort := bson.D{{Key: "_id.period", Value: 1}}
+	for _, dimension := range groupBy {
+		id = append(id, bson.E{Key: dimension, Value: "$" + TrendDimensions[dimension]})
+		sort = append(sort, bson.E{Key: "_id." + dimension, Value: 1})
+	}
+
+	countStatus := func(status string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", status}}, 1, 0}}}
+	}
+
+	group := bson.M{
+		"_id":       id,
+		"total":     bson.M{"$sum": 1},
+		"verified":  countStatus("verified"),
+		"rejected":  countStatus("rejected"),
+		"submitted": countStatus("submitted"),
+		"draft":     countStatus("draft"),
+		// $avg mengabaikan null, sehingga hanya achievement verified yang dihitung
+		"avg_verify_ms": bson.M{"$avg": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{"$status", "verified"}},
+			bson.M{"$subtract": bson.A{"$verified_at", "$submitted_at"}},
+			nil,
+		}}},
+	}
+	for _, dimension := range groupBy {
+		if dimension == "advisor" {
+			group[TrendAdvisorNameKey] = bson.M{"$max": "$advisor_name"}
+		}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: group}},
+		{{Key: "$sort", Value: sort}},
+	}
+}
+
+type trendRow struct {
+	ID          bson.M   `bson:"_id"`
+	Total       int      `bson:"total"`
+	Verified    int      `bson:"verified"`
+	Rejected    int      `bson:"rejected"`
+	Submitted   int      `bson:"submitted"`
+	Draft       int      `bson:"draft"`
+	AvgVerifyMS *float64 `bson:"avg_verify_ms"`
+	AdvisorName string   `bson:"advisor_name"`
+}
+
+func (row trendRow) toPoint(groupBy []string) models.TrendPoint {
+	point := models.TrendPoint{
+		Total:    row.Total,
+		Verified: row.Verified,
+		Rejected: row.Rejected,
+		Pending:  row.Submitted,
+		Draft:    row.Draft,
+	}
+	point.Period, _ = row.ID["period"].(string)
+	if len(groupBy) > 0 {
+		point.Group = make(map[string]string, len(groupBy))
+		for _, dimension := range groupBy {
+			value, _ := row.ID[dimension].(string)
+			point.Group[dimension] = value
+			if dimension == "advisor" {
+				point.Group[TrendAdvisorNameKey] = row.AdvisorName
+			}
+		}
+	}
+
+	if decided := row.Verified + row.Rejected; decided > 0 {
+		rate := roundTo(float64(row.Verified)/float64(decided), 4)
+		point.VerificationRate = &rate
+	}
+	if row.AvgVerifyMS != nil {
+		hours := roundTo(*row.AvgVerifyMS/float64(time.Hour/time.Millisecond), 2)
+		point.AvgHoursToVerify = &hours
+	}
+	return point
+}
+
+// ApplyYearOverYear mengisi total periode yang sama setahun sebelumnya dan
+// pertumbuhannya (persen) untuk setiap kombinasi dimensi
+func ApplyYearOverYear(points []models.TrendPoint, groupBy []string) {
+	totals := make(map[string]int, len(points))
+	for _, point := range points {
+		totals[trendKey(point.Period, point.Group, groupBy)] = point.Total
+	}
+
+	for i := range points {
+		previousPeriod, ok := previousYearPeriod(points[i].Period)
+		if !ok {
+			continue
+		}
+		previous, ok := totals[trendKey(previousPeriod, points[i].Group, groupBy)]
+		if !ok {
+			continue
+		}
+		points[i].PreviousYearTotal = &previous
+		if previous > 0 {
+			growth := roundTo(float64(points[i].Total-previous)/float64(previous)*100, 2)
+			points[i].YearOverYear = &growth
+		}
+	}
+}
+
+func trendKey(period string, group map[string]string, groupBy []string) string {
+	parts := []string{period}
+	for _, dimension := range groupBy {
+		parts = append(parts, group[dimension])
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// previousYearPeriod "2024-03" -> "2023-03", "2024" -> "2023",
+// "2024/2025-Ganjil" -> "2023/2024-Ganjil"
+func previousYearPeriod(period string) (string, bool) {
+	if len(period) < 4 {
+		return "", false
+	}
+	year, err := strconv.Atoi(period[:4])
+	if err != nil {
+		return "", false
+	}
+	rest := period[4:]
+	if len(rest) >= 5 && rest[0] == '/' {
+		endYear, err := strconv.Atoi(rest[1:5])
+		if err != nil {
+			return "", false
+		}
+		return fmt.Sprintf("%04d/%04d%s", year-1, endYear-1, rest[5:]), true
+	}
+	return fmt.Sprintf("%04d%s", year-1, rest), true
+}
+
+func roundTo(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
+
+// GetTrends menghitung trend achievement per periode dan dimensi.
+// studentIDs nil berarti semua mahasiswa. Data setahun sebelum date_from ikut
+// diambil agar periode pertama tetap punya pembanding year-over-year.
+func (r *AchievementViewRepository) GetTrends(ctx context.Context, studentIDs []string, filter *models.TrendFilter) ([]models.TrendPoint, error) {
+	statsFilter := filter.StatisticsFilter
+	if filter.DateFrom != nil {
+		from := filter.DateFrom.AddDate(-1, 0, 0)
+		statsFilter.DateFrom = &from
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, TrendsPipeline(StatisticsMatch(studentIDs, &statsFilter), filter.GroupBy, filter.Bucket))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []trendRow
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	points := make([]models.TrendPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, row.toPoint(filter.GroupBy))
+	}
+	ApplyYearOverYear(points, filter.GroupBy)
+
+	if filter.DateFrom == nil {
+		return points, nil
+	}
+	firstPeriod := TrendPeriod(filter.Bucket, *filter.DateFrom)
+	trends := make([]models.TrendPoint, 0, len(points))
+	for _, point := range points {
+		if point.Period >= firstPeriod {
+			trends = append(trends, point)
+		}
+	}
+	return trends, nil
+}
@@ -0,0 +1,597 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AchievementViewRepository mengakses read model achievement_views
+type AchievementViewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAchievementViewRepository(db *mongo.Database) *AchievementViewRepository {
+	return &AchievementViewRepository{
+		collection: db.Collection("achievement_views"),
+	}
+}
+
+// EnsureIndexes membuat index untuk lookup, filter list dan pencarian full-text.
+// Title diberi bobot lebih tinggi agar kecocokan pada judul lebih relevan.
+func (r *AchievementViewRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "achievement_id", Value: 1}},
+			Options: options.Index().SetName("achievement_id_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "student_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "advisor_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "date", Value: -1}},
+		},
+		{
+			// Statistik per mahasiswa dengan filter rentang tanggal
+			Keys: bson.D{{Key: "student_id", Value: 1}, {Key: "date", Value: -1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "description", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("achievements_text_search").
+				SetWeights(bson.M{"title": 10, "description": 1}).
+				SetDefaultLanguage("none"),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Upsert menyimpan atau mengganti read model sebuah achievement dan mengembalikan
+// versi sebelumnya (nil jika belum ada), dibaca secara atomik dengan penggantiannya
+func (r *AchievementViewRepository) Upsert(ctx context.Context, view *models.AchievementView) (*models.AchievementView, error) {
+	filter := bson.M{"achievement_id": view.AchievementID}
+	opts := options.FindOneAndReplace().
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	var previous models.AchievementView
+	err := r.collection.FindOneAndReplace(ctx, filter, view, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// Delete menghapus read model achievement dan mengembalikan versi yang dihapus
+// (nil jika tidak ada)
+func (r *AchievementViewRepository) Delete(ctx context.Context, achievementID string) (*models.AchievementView, error) {
+	var previous models.AchievementView
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"achievement_id": achievementID}).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// DeleteExcept menghapus read model yang achievement-nya tidak ada di daftar
+func (r *AchievementViewRepository) DeleteExcept(ctx context.Context, achievementIDs []string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"achievement_id": bson.M{"$nin": achievementIDs}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// FindAchievementIDsByUser mencari achievement_id yang memuat data user sebagai
+// mahasiswa maupun sebagai advisor
+func (r *AchievementViewRepository) FindAchievementIDsByUser(ctx context.Context, userID string) ([]string, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"student_id": userID},
+		bson.M{"advisor_id": userID},
+	}}
+	opts := options.Find().SetProjection(bson.M{"achievement_id": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		AchievementID string `bson:"achievement_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.AchievementID
+	}
+	return ids, nil
+}
+
+// FindAll mencari read model dengan filter, diurutkan dari yang terbaru
+func (r *AchievementViewRepository) FindAll(ctx context.Context, filter bson.M) ([]models.AchievementView, error) {
+	views := []models.AchievementView{}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// FindByStudentID mencari read model achievement milik student (exclude deleted)
+func (r *AchievementViewRepository) FindByStudentID(ctx context.Context, studentID string) ([]models.AchievementView, error) {
+	return r.FindAll(ctx, bson.M{
+		"student_id": studentID,
+		"is_deleted": false,
+	})
+}
+
+// FindVerifiedByStudentID mencari achievement verified milik student, diurutkan
+// dari tanggal achievement paling awal (untuk transkrip prestasi)
+func (r *AchievementViewRepository) FindVerifiedByStudentID(ctx context.Context, studentID string) ([]models.AchievementView, error) {
+	views := []models.AchievementView{}
+	filter := bson.M{
+		"student_id": studentID,
+		"status":     "verified",
+		"is_deleted": false,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// FindWithFilter mencari read model dengan filter, sorting dan pagination
+func (r *AchievementViewRepository) FindWithFilter(ctx context.Context, filter bson.M, sortBy string, ascending bool, limit, offset int) ([]models.AchievementView, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+	sort := bson.D{{Key: sortBy, Value: direction}}
+	if sortBy != "created_at" {
+		sort = append(sort, bson.E{Key: "created_at", Value: -1})
+	}
+	sort = append(sort, bson.E{Key: "_id", Value: 1})
+
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	views := []models.AchievementView{}
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, 0, err
+	}
+
+	return views, total, nil
+}
+
+// CountWithFilter menghitung read model yang cocok dengan filter
+func (r *AchievementViewRepository) CountWithFilter(ctx context.Context, filter bson.M) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// Each membaca read model dengan filter dan sorting satu per satu tanpa menampung
+// semuanya di memori, untuk export berukuran besar
+func (r *AchievementViewRepository) Each(ctx context.Context, filter bson.M, sortBy string, ascending bool, fn func(*models.AchievementView) error) error {
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+	sort := bson.D{{Key: sortBy, Value: direction}, {Key: "_id", Value: 1}}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var view models.AchievementView
+		if err := cursor.Decode(&view); err != nil {
+			return err
+		}
+		if err := fn(&view); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// Field read model bertipe tanggal, nilai cursor-nya disimpan dalam format RFC3339
+var achievementViewTimeFields = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"date":         true,
+	"submitted_at": true,
+	"verified_at":  true,
+}
+
+// FindWithCursor mencari read model dengan pagination berbasis cursor (keyset) pada
+// field sortBy dan _id. Mengambil limit+1 dokumen agar pemanggil tahu masih ada
+// halaman berikutnya. Untuk cursor Before, hasil dikembalikan dalam urutan terbalik.
+func (r *AchievementViewRepository) FindWithCursor(ctx context.Context, filter bson.M, sortBy string, ascending bool, cursor *models.Cursor, limit int) ([]models.AchievementView, error) {
+	if cursor != nil && cursor.Before {
+		ascending = !ascending
+	}
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+
+	query := filter
+	if cursor != nil {
+		after, err := cursorCondition(sortBy, ascending, cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(limit + 1))
+
+	cursorResult, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursorResult.Close(ctx)
+
+	views := []models.AchievementView{}
+	if err := cursorResult.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// cursorCondition menyusun kondisi dokumen yang berada setelah cursor sesuai arah sorting.
+// Nilai null diurutkan paling awal oleh MongoDB sehingga perlu ditangani terpisah.
+func cursorCondition(sortBy string, ascending bool, cursor *models.Cursor) (bson.M, error) {
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	idOperator := "$lt"
+	valueOperator := "$lt"
+	if ascending {
+		idOperator = "$gt"
+		valueOperator = "$gt"
+	}
+
+	if cursor.Value == nil {
+		sameValue := bson.M{sortBy: nil, "_id": bson.M{idOperator: id}}
+		if ascending {
+			return bson.M{"$or": bson.A{sameValue, bson.M{sortBy: bson.M{"$ne": nil}}}}, nil
+		}
+		return sameValue, nil
+	}
+
+	var value interface{} = *cursor.Value
+	if achievementViewTimeFields[sortBy] {
+		parsed, err := time.Parse(time.RFC3339Nano, *cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+		value = parsed
+	}
+
+	conditions := bson.A{
+		bson.M{sortBy: bson.M{valueOperator: value}},
+		bson.M{sortBy: value, "_id": bson.M{idOperator: id}},
+	}
+	if !ascending {
+		conditions = append(conditions, bson.M{sortBy: nil})
+	}
+	return bson.M{"$or": conditions}, nil
+}
+
+// Search mencari achievement dengan text index, diurutkan berdasarkan relevansi.
+// studentIDs nil berarti tanpa batasan mahasiswa (admin).
+func (r *AchievementViewRepository) Search(ctx context.Context, query string, studentIDs []string, status string, limit, offset int) ([]models.AchievementSearchResult, int64, error) {
+	filter := bson.M{
+		"$text":      bson.M{"$search": query},
+		"is_deleted": false,
+	}
+	if studentIDs != nil {
+		filter["student_id"] = bson.M{"$in": studentIDs}
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.AchievementSearchResult
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// SearchWithCursor sama seperti Search, tetapi memakai pagination berbasis cursor pada
+// skor relevansi dan _id. Mengambil limit+1 dokumen; untuk cursor Before hasil
+// dikembalikan dalam urutan terbalik.
+func (r *AchievementViewRepository) SearchWithCursor(ctx context.Context, query string, studentIDs []string, status string, cursor *models.Cursor, limit int) ([]models.AchievementSearchResult, error) {
+	filter := bson.M{
+		"$text":      bson.M{"$search": query},
+		"is_deleted": false,
+	}
+	if studentIDs != nil {
+		filter["student_id"] = bson.M{"$in": studentIDs}
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	// Relevansi tertinggi lebih dulu, cursor Before membalik arah
+	ascending := cursor != nil && cursor.Before
+	direction := -1
+	if ascending {
+		direction = 1
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$set", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	if cursor != nil {
+		after, err := searchCursorCondition(ascending, cursor)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: after}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "score", Value: direction}, {Key: "_id", Value: direction}}}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	)
+
+	result, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close(ctx)
+
+	results := []models.AchievementSearchResult{}
+	if err := result.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// searchCursorCondition menyusun kondisi hasil pencarian setelah cursor (skor, _id)
+func searchCursorCondition(ascending bool, cursor *models.Cursor) (bson.M, error) {
+	if cursor.Value == nil {
+		return nil, errors.New("cursor tanpa nilai score")
+	}
+	score, err := strconv.ParseFloat(*cursor.Value, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := "$lt"
+	if ascending {
+		operator = "$gt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"score": bson.M{operator: score}},
+		bson.M{"score": score, "_id": bson.M{operator: id}},
+	}}, nil
+}
+
+// GetStatistics menghitung statistik achievement dengan aggregation pipeline.
+// studentIDs nil berarti semua mahasiswa, filter boleh nil.
+func (r *AchievementViewRepository) GetStatistics(ctx context.Context, studentIDs []string, filter *models.StatisticsFilter) (map[string]interface{}, error) {
+	return aggregateStatistics(ctx, r.collection, StatisticsPipeline(StatisticsMatch(studentIDs, filter)))
+}
+
+// GetTopStudents mencari mahasiswa dengan achievement terbanyak.
+// studentIDs nil berarti semua mahasiswa, filter boleh nil.
+func (r *AchievementViewRepository) GetTopStudents(ctx context.Context, studentIDs []string, filter *models.StatisticsFilter, limit int) ([]models.TopStudent, error) {
+	match := StatisticsMatch(studentIDs, filter)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                "$student_id",
+			"student_name":       bson.M{"$first": "$student_name"},
+			"total_achievements": bson.M{"$sum": 1},
+			"verified_achievements": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$status", "verified"}}, 1, 0},
+			}},
+			// Poin rubric hanya dihitung dari achievement verified
+			"total_points": bson.M{"$sum": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$status", "verified"}}, "$points", 0},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "total_achievements", Value: -1},
+			{Key: "verified_achievements", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		StudentID            string `bson:"_id"`
+		StudentName          string `bson:"student_name"`
+		TotalAchievements    int    `bson:"total_achievements"`
+		VerifiedAchievements int    `bson:"verified_achievements"`
+		TotalPoints          int    `bson:"total_points"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	topStudents := make([]models.TopStudent, len(rows))
+	for i, row := range rows {
+		topStudents[i] = models.TopStudent{
+			StudentID:            row.StudentID,
+			StudentName:          row.StudentName,
+			TotalAchievements:    row.TotalAchievements,
+			VerifiedAchievements: row.VerifiedAchievements,
+			TotalPoints:          row.TotalPoints,
+		}
+	}
+	return topStudents, nil
+}
+
+// Count menghitung jumlah dokumen read model
+func (r *AchievementViewRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+// GetLeaderboard mengurutkan mahasiswa berdasarkan achievement verified atau total
+// poin dalam cakupan program studi, angkatan, dosen wali dan rentang tanggal.
+// Score yang sama diurutkan berdasarkan nama agar urutannya stabil. Mahasiswa dengan
+// score sama seperti entry terakhir di batas limit ikut dikembalikan, sehingga hasilnya
+// bisa lebih dari limit.
+func (r *AchievementViewRepository) GetLeaderboard(ctx context.Context, filter *models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	match := StatisticsMatch(nil, &models.StatisticsFilter{
+		Status:   "verified",
+		DateFrom: filter.DateFrom,
+		DateTo:   filter.DateTo,
+	})
+	if filter.ProgramStudy != "" {
+		match["program_study"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.ProgramStudy) + "$", Options: "i"}
+	}
+	if filter.AcademicYear != "" {
+		match["academic_year"] = filter.AcademicYear
+	}
+	if filter.AdvisorID != "" {
+		match["advisor_id"] = filter.AdvisorID
+	}
+
+	score := "$verified_achievements"
+	if filter.RankBy == models.LeaderboardRankByPoints {
+		score = "$total_points"
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                   "$student_id",
+			"student_number":        bson.M{"$first": "$student_number"},
+			"student_name":          bson.M{"$first": "$student_name"},
+			"program_study":         bson.M{"$first": "$program_study"},
+			"academic_year":         bson.M{"$first": "$academic_year"},
+			"verified_achievements": bson.M{"$sum": 1},
+			"total_points":          bson.M{"$sum": "$points"},
+		}}},
+		{{Key: "$set", Value: bson.M{"score": score}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "score", Value: -1},
+			{Key: "student_name", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// Ambil sampai limit, lalu lanjutkan selama score masih sama dengan entry terakhir
+	entries := []models.LeaderboardEntry{}
+	for cursor.Next(ctx) {
+		var entry models.LeaderboardEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		if !models.LeaderboardAccepts(entries, filter.Limit, entry) {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	models.RankLeaderboard(entries)
+	return entries, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ExportJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewExportJobRepository(db *mongo.Database) *ExportJobRepository {
+	return &ExportJobRepository{
+		collection: db.Collection("export_jobs"),
+	}
+}
+
+// Create menyimpan job export baru
+func (r *ExportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, job)
+	return err
+}
+
+// FindByID mencari job export milik user
+func (r *ExportJobRepository) FindByID(ctx context.Context, jobID, userID string) (*models.ExportJob, error) {
+	var job models.ExportJob
+	filter := bson.M{
+		"job_id":  jobID,
+		"user_id": userID,
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateStatus mengubah status job export
+func (r *ExportJobRepository) UpdateStatus(ctx context.Context, jobID, status string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"job_id": jobID}, bson.M{
+		"$set": bson.M{"status": status},
+	})
+	return err
+}
+
+// Complete menandai job export selesai beserta file hasilnya
+func (r *ExportJobRepository) Complete(ctx context.Context, jobID string, rows int, filepath string) error {
+	now := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"job_id": jobID}, bson.M{
+		"$set": bson.M{
+			"status":       models.ExportStatusCompleted,
+			"rows":         rows,
+			"filepath":     filepath,
+			"completed_at": now,
+		},
+	})
+	return err
+}
+
+// Fail menandai job export gagal
+func (r *ExportJobRepository) Fail(ctx context.Context, jobID, message string) error {
+	now := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"job_id": jobID}, bson.M{
+		"$set": bson.M{
+			"status":       models.ExportStatusFailed,
+			"error":        message,
+			"completed_at": now,
+		},
+	})
+	return err
+}
+
+// FindExpired mencari job export yang sudah melewati batas waktu
+func (r *ExportJobRepository) FindExpired(ctx context.Context, now time.Time) ([]models.ExportJob, error) {
+	var jobs []models.ExportJob
+	filter := bson.M{"expires_at": bson.M{"$lte": now}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Delete menghapus job export
+func (r *ExportJobRepository) Delete(ctx context.Context, jobID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"job_id": jobID})
+	return err
+}
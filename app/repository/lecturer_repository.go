@@ -3,6 +3,8 @@ package repository
 import (
 models "crud-app/app/model"
 "database/sql"
+"errors"
+"fmt"
 )
 
 type LecturerRepository struct {
@@ -174,4 +176,57 @@ func (r *LecturerRepository) FindAll(limit, offset int) ([]models.LecturerDetail
 	}
 
 	return lecturers, total, nil
-}
\ No newline at end of file
+}
+
+// FindWithCursor mencari lecturers dengan pagination berbasis cursor (keyset) pada nama dan id.
+// Mengambil limit+1 baris agar pemanggil tahu masih ada halaman berikutnya.
+func (r *LecturerRepository) FindWithCursor(limit int, cursor *models.Cursor) ([]models.LecturerDetail, error) {
+	query := `
+		SELECT l.id, l.user_id, l.lecturer_id, u.full_name, l.department
+		FROM lecturers l
+		INNER JOIN users u ON l.user_id = u.id
+	`
+	args := []interface{}{}
+
+	order := "ASC"
+	if cursor != nil {
+		if cursor.Value == nil {
+			return nil, errors.New("cursor tanpa nilai full_name")
+		}
+
+		operator := ">"
+		if cursor.Before {
+			operator = "<"
+			order = "DESC"
+		}
+		args = append(args, *cursor.Value, cursor.ID)
+		query += fmt.Sprintf(` WHERE (u.full_name, l.id) %s ($1, $2)`, operator)
+	}
+
+	args = append(args, limit+1)
+	query += fmt.Sprintf(` ORDER BY u.full_name %s, l.id %s LIMIT $%d`, order, order, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lecturers []models.LecturerDetail
+	for rows.Next() {
+		var lecturer models.LecturerDetail
+		err := rows.Scan(
+			&lecturer.ID,
+			&lecturer.UserID,
+			&lecturer.LecturerID,
+			&lecturer.FullName,
+			&lecturer.Department,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lecturers = append(lecturers, lecturer)
+	}
+
+	return lecturers, rows.Err()
+}
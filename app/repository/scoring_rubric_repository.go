@@ -0,0 +1,151 @@
+package repository
+
+import (
+	models "crud-app/app/model"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScoringRubricRepository struct {
+	db *sql.DB
+}
+
+func NewScoringRubricRepository(db *sql.DB) *ScoringRubricRepository {
+	return &ScoringRubricRepository{db: db}
+}
+
+// FindAll mengambil semua rubric
+func (r *ScoringRubricRepository) FindAll() (models.ScoringRubrics, error) {
+	query := `
+		SELECT id, category, level, points, created_at, updated_at
+		FROM scoring_rubrics
+		ORDER BY category ASC, level ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rubrics := models.ScoringRubrics{}
+	for rows.Next() {
+		var rubric models.ScoringRubric
+		err := rows.Scan(
+			&rubric.ID,
+			&rubric.Category,
+			&rubric.Level,
+			&rubric.Points,
+			&rubric.CreatedAt,
+			&rubric.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rubrics = append(rubrics, rubric)
+	}
+
+	return rubrics, rows.Err()
+}
+
+// FindByID mencari rubric berdasarkan ID
+func (r *ScoringRubricRepository) FindByID(id string) (*models.ScoringRubric, error) {
+	query := `
+		SELECT id, category, level, points, created_at, updated_at
+		FROM scoring_rubrics
+		WHERE id = $1
+	`
+
+	var rubric models.ScoringRubric
+	err := r.db.QueryRow(query, id).Scan(
+		&rubric.ID,
+		&rubric.Category,
+		&rubric.Level,
+		&rubric.Points,
+		&rubric.CreatedAt,
+		&rubric.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rubric, nil
+}
+
+// ExistsByCategoryLevel mengecek apakah rubric kategori dan level sudah ada,
+// selain rubric dengan excludeID
+func (r *ScoringRubricRepository) ExistsByCategoryLevel(category, level, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM scoring_rubrics
+			WHERE LOWER(category) = LOWER($1) AND LOWER(level) = LOWER($2)
+			  AND id::text <> $3
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, category, level, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// Create membuat rubric baru
+func (r *ScoringRubricRepository) Create(rubric *models.ScoringRubric) error {
+	rubric.ID = uuid.New().String()
+	rubric.CreatedAt = time.Now()
+	rubric.UpdatedAt = rubric.CreatedAt
+
+	query := `
+		INSERT INTO scoring_rubrics (id, category, level, points, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		rubric.ID,
+		rubric.Category,
+		rubric.Level,
+		rubric.Points,
+		rubric.CreatedAt,
+		rubric.UpdatedAt,
+	)
+
+	return err
+}
+
+// Update mengubah kategori, level dan poin rubric
+func (r *ScoringRubricRepository) Update(rubric *models.ScoringRubric) error {
+	rubric.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE scoring_rubrics
+		SET category = $1, level = $2, points = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.Exec(query, rubric.Category, rubric.Level, rubric.Points, rubric.UpdatedAt, rubric.ID)
+	return err
+}
+
+// Delete menghapus rubric
+func (r *ScoringRubricRepository) Delete(id string) error {
+	query := `DELETE FROM scoring_rubrics WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
+
+// RenameValue mengganti nama kategori atau level (field "category" atau "level")
+// pada rubric setelah taxonomy diubah
+func (r *ScoringRubricRepository) RenameValue(field, from, to string) error {
+	query := `UPDATE scoring_rubrics SET category = $2, updated_at = NOW() WHERE LOWER(category) = LOWER($1)`
+	if field == "level" {
+		query = `UPDATE scoring_rubrics SET level = $2, updated_at = NOW() WHERE LOWER(level) = LOWER($1)`
+	}
+	_, err := r.db.Exec(query, from, to)
+	return err
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	models "crud-app/app/model"
+	"database/sql"
+)
+
+// SeedRepository menulis data awal (role, permission, grant dan admin) dalam satu
+// transaksi. Semua insert idempotent: data yang sudah ada tidak diubah.
+type SeedRepository struct {
+	db *sql.DB
+}
+
+func NewSeedRepository(db *sql.DB) *SeedRepository {
+	return &SeedRepository{db: db}
+}
+
+// Seed membuat role, permission dan grant role_permissions yang belum ada, lalu membuat
+// admin jika username/email admin belum dipakai user aktif. admin boleh nil.
+func (r *SeedRepository) Seed(roles []models.SeedRole, permissions []models.Permissions, grants map[string][]string, admin *models.User) (*models.SeedReport, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	report := &models.SeedReport{}
+
+	for _, role := range roles {
+		created, err := execCount(tx, `
+			INSERT INTO roles (id, name, description, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (id) DO NOTHING
+		`, role.ID, role.Name, role.Description)
+		if err != nil {
+			return nil, err
+		}
+		report.RolesCreated += created
+	}
+
+	for _, permission := range permissions {
+		created, err := execCount(tx, `
+			INSERT INTO permissions (id, name, resource, action, description)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (name) DO NOTHING
+		`, permission.ID, permission.Name, permission.Resource, permission.Action, permission.Description)
+		if err != nil {
+			return nil, err
+		}
+		report.PermissionsCreated += created
+	}
+
+	for roleID, names := range grants {
+		for _, name := range names {
+			created, err := execCount(tx, `
+				INSERT INTO role_permissions (role_id, permission_id)
+				SELECT $1::text, id FROM permissions WHERE name = $2
+				ON CONFLICT DO NOTHING
+			`, roleID, name)
+			if err != nil {
+				return nil, err
+			}
+			report.GrantsCreated += created
+		}
+	}
+
+	if admin != nil {
+		created, err := execCount(tx, `
+			INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at)
+			SELECT $1::uuid, $2::text, $3::text, $4, $5, $6, $7::boolean, $8::timestamp, $9::timestamp
+			WHERE NOT EXISTS (
+				SELECT 1 FROM users
+				WHERE deleted_at IS NULL AND (LOWER(username) = LOWER($2) OR LOWER(email) = LOWER($3))
+			)
+		`, admin.ID, admin.Username, admin.Email, admin.PasswordHash, admin.FullName, admin.RoleID, admin.IsActive, admin.CreatedAt, admin.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		report.AdminCreated = created > 0
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func execCount(tx *sql.Tx, query string, args ...interface{}) (int, error) {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
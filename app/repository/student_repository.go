@@ -3,6 +3,9 @@ package repository
 import (
 models "crud-app/app/model"
 "database/sql"
+"errors"
+"fmt"
+"time"
 )
 
 type StudentRepository struct {
@@ -68,7 +71,7 @@ func (r *StudentRepository) FindByID(id string) (*models.StudentDetail, error) {
 query := `
 		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study, 
 		       s.academic_year, s.advisor_id, 
-		       COALESCE(u2.full_name, '') as advisor_name
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
 		FROM students s
 		INNER JOIN users u ON s.user_id = u.id
 		LEFT JOIN users u2 ON s.advisor_id = u2.id
@@ -85,6 +88,7 @@ err := r.db.QueryRow(query, id).Scan(
 &student.AcademicYear,
 &student.AdvisorID,
 &student.AdvisorName,
+&student.CreatedAt,
 )
 
 if err == sql.ErrNoRows {
@@ -183,7 +187,7 @@ func (r *StudentRepository) FindAll(limit, offset int) ([]models.StudentDetail,
 	query := `
 		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study, 
 		       s.academic_year, s.advisor_id, 
-		       COALESCE(u2.full_name, '') as advisor_name
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
 		FROM students s
 		INNER JOIN users u ON s.user_id = u.id
 		LEFT JOIN users u2 ON s.advisor_id = u2.id
@@ -209,6 +213,7 @@ func (r *StudentRepository) FindAll(limit, offset int) ([]models.StudentDetail,
 			&student.AcademicYear,
 			&student.AdvisorID,
 			&student.AdvisorName,
+			&student.CreatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -224,7 +229,7 @@ func (r *StudentRepository) FindByAdvisorID(advisorID string) ([]models.StudentD
 	query := `
 		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study, 
 		       s.academic_year, s.advisor_id, 
-		       COALESCE(u2.full_name, '') as advisor_name
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
 		FROM students s
 		INNER JOIN users u ON s.user_id = u.id
 		LEFT JOIN users u2 ON s.advisor_id = u2.id
@@ -250,6 +255,7 @@ func (r *StudentRepository) FindByAdvisorID(advisorID string) ([]models.StudentD
 			&student.AcademicYear,
 			&student.AdvisorID,
 			&student.AdvisorName,
+			&student.CreatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -258,4 +264,165 @@ func (r *StudentRepository) FindByAdvisorID(advisorID string) ([]models.StudentD
 	}
 
 	return students, nil
-}
\ No newline at end of file
+}
+
+// FindDetailByUserID mencari detail student (termasuk nama dan nama advisor) berdasarkan user_id
+func (r *StudentRepository) FindDetailByUserID(userID string) (*models.StudentDetail, error) {
+	query := `
+		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study,
+		       s.academic_year, COALESCE(s.advisor_id::text, ''),
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
+		FROM students s
+		INNER JOIN users u ON s.user_id = u.id
+		LEFT JOIN users u2 ON s.advisor_id = u2.id
+		WHERE s.user_id = $1
+	`
+
+	var student models.StudentDetail
+	err := r.db.QueryRow(query, userID).Scan(
+		&student.ID,
+		&student.UserID,
+		&student.StudentID,
+		&student.FullName,
+		&student.ProgramStudy,
+		&student.AcademicYear,
+		&student.AdvisorID,
+		&student.AdvisorName,
+		&student.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &student, nil
+}
+
+// FindWithCursor mencari students dengan pagination berbasis cursor (keyset) pada created_at dan id.
+// Mengambil limit+1 baris agar pemanggil tahu masih ada halaman berikutnya.
+func (r *StudentRepository) FindWithCursor(limit int, cursor *models.Cursor) ([]models.StudentDetail, error) {
+	query := `
+		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study,
+		       s.academic_year, s.advisor_id,
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
+		FROM students s
+		INNER JOIN users u ON s.user_id = u.id
+		LEFT JOIN users u2 ON s.advisor_id = u2.id
+	`
+	args := []interface{}{}
+
+	order := "DESC"
+	if cursor != nil {
+		if cursor.Value == nil {
+			return nil, errors.New("cursor tanpa nilai created_at")
+		}
+		createdAt, err := time.Parse(time.RFC3339Nano, *cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		operator := "<"
+		if cursor.Before {
+			operator = ">"
+			order = "ASC"
+		}
+		args = append(args, createdAt, cursor.ID)
+		query += fmt.Sprintf(` WHERE (s.created_at, s.id) %s ($1, $2)`, operator)
+	}
+
+	args = append(args, limit+1)
+	query += fmt.Sprintf(` ORDER BY s.created_at %s, s.id %s LIMIT $%d`, order, order, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []models.StudentDetail
+	for rows.Next() {
+		var student models.StudentDetail
+		err := rows.Scan(
+			&student.ID,
+			&student.UserID,
+			&student.StudentID,
+			&student.FullName,
+			&student.ProgramStudy,
+			&student.AcademicYear,
+			&student.AdvisorID,
+			&student.AdvisorName,
+			&student.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		students = append(students, student)
+	}
+
+	return students, rows.Err()
+}
+
+// FindByAdvisorIDWithCursor mencari students bimbingan dengan pagination berbasis cursor
+// pada (full_name, id). Mengambil limit+1 baris agar pemanggil tahu masih ada halaman
+// berikutnya. Untuk cursor Before, hasil dikembalikan dalam urutan terbalik.
+func (r *StudentRepository) FindByAdvisorIDWithCursor(advisorID string, limit int, cursor *models.Cursor) ([]models.StudentDetail, error) {
+	query := `
+		SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study,
+		       s.academic_year, s.advisor_id,
+		       COALESCE(u2.full_name, '') as advisor_name, s.created_at
+		FROM students s
+		INNER JOIN users u ON s.user_id = u.id
+		LEFT JOIN users u2 ON s.advisor_id = u2.id
+		WHERE s.advisor_id = $1
+	`
+	args := []interface{}{advisorID}
+
+	order := "ASC"
+	if cursor != nil {
+		if cursor.Value == nil {
+			return nil, errors.New("cursor tanpa nilai full_name")
+		}
+
+		operator := ">"
+		if cursor.Before {
+			operator = "<"
+			order = "DESC"
+		}
+		args = append(args, *cursor.Value, cursor.ID)
+		query += fmt.Sprintf(` AND (u.full_name, s.id) %s ($2, $3)`, operator)
+	}
+
+	args = append(args, limit+1)
+	query += fmt.Sprintf(` ORDER BY u.full_name %s, s.id %s LIMIT $%d`, order, order, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []models.StudentDetail
+	for rows.Next() {
+		var student models.StudentDetail
+		err := rows.Scan(
+			&student.ID,
+			&student.UserID,
+			&student.StudentID,
+			&student.FullName,
+			&student.ProgramStudy,
+			&student.AcademicYear,
+			&student.AdvisorID,
+			&student.AdvisorName,
+			&student.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		students = append(students, student)
+	}
+
+	return students, rows.Err()
+}
@@ -0,0 +1,336 @@
+package repository
+
+import (
+	models "crud-app/app/model"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type TaxonomyRepository struct {
+	db *sql.DB
+}
+
+func NewTaxonomyRepository(db *sql.DB) *TaxonomyRepository {
+	return &TaxonomyRepository{db: db}
+}
+
+// FindTaxonomy mengambil semua kategori dan level
+func (r *TaxonomyRepository) FindTaxonomy() (*models.Taxonomy, error) {
+	categories, err := r.FindCategories()
+	if err != nil {
+		return nil, err
+	}
+	levels, err := r.FindLevels()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Taxonomy{Categories: categories, Levels: levels}, nil
+}
+
+// FindCategories mengambil semua kategori
+func (r *TaxonomyRepository) FindCategories() ([]models.AchievementCategory, error) {
+	query := `
+		SELECT id, code, name, description, parent_id, aliases, details_schema, created_at, updated_at
+		FROM achievement_categories
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []models.AchievementCategory{}
+	for rows.Next() {
+		var category models.AchievementCategory
+		err := rows.Scan(
+			&category.ID,
+			&category.Code,
+			&category.Name,
+			&category.Description,
+			&category.ParentID,
+			pq.Array(&category.Aliases),
+			(*[]byte)(&category.DetailsSchema),
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// FindCategoryByID mencari kategori berdasarkan ID
+func (r *TaxonomyRepository) FindCategoryByID(id string) (*models.AchievementCategory, error) {
+	query := `
+		SELECT id, code, name, description, parent_id, aliases, details_schema, created_at, updated_at
+		FROM achievement_categories
+		WHERE id::text = $1
+	`
+
+	var category models.AchievementCategory
+	err := r.db.QueryRow(query, id).Scan(
+		&category.ID,
+		&category.Code,
+		&category.Name,
+		&category.Description,
+		&category.ParentID,
+		pq.Array(&category.Aliases),
+		(*[]byte)(&category.DetailsSchema),
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// ExistsCategory mengecek apakah kode atau nama kategori sudah dipakai,
+// selain kategori dengan excludeID
+func (r *TaxonomyRepository) ExistsCategory(code, name, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM achievement_categories
+			WHERE (LOWER(code) = LOWER($1) OR LOWER(name) = LOWER($2))
+			  AND id::text <> $3
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, code, name, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// CountChildCategories menghitung sub-kategori langsung sebuah kategori
+func (r *TaxonomyRepository) CountChildCategories(id string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM achievement_categories WHERE parent_id::text = $1`, id).Scan(&count)
+	return count, err
+}
+
+// CreateCategory membuat kategori baru
+func (r *TaxonomyRepository) CreateCategory(category *models.AchievementCategory) error {
+	category.ID = uuid.New().String()
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+	if category.Aliases == nil {
+		category.Aliases = []string{}
+	}
+
+	query := `
+		INSERT INTO achievement_categories (id, code, name, description, parent_id, aliases, details_schema, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		category.ID,
+		category.Code,
+		category.Name,
+		category.Description,
+		category.ParentID,
+		pq.Array(category.Aliases),
+		nullableJSON(category.DetailsSchema),
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+
+	return err
+}
+
+// UpdateCategory mengubah kategori
+func (r *TaxonomyRepository) UpdateCategory(category *models.AchievementCategory) error {
+	category.UpdatedAt = time.Now()
+	if category.Aliases == nil {
+		category.Aliases = []string{}
+	}
+
+	query := `
+		UPDATE achievement_categories
+		SET code = $1, name = $2, description = $3, parent_id = $4, aliases = $5, details_schema = $6, updated_at = $7
+		WHERE id = $8
+	`
+
+	_, err := r.db.Exec(
+		query,
+		category.Code,
+		category.Name,
+		category.Description,
+		category.ParentID,
+		pq.Array(category.Aliases),
+		nullableJSON(category.DetailsSchema),
+		category.UpdatedAt,
+		category.ID,
+	)
+	return err
+}
+
+// DeleteCategory menghapus kategori
+func (r *TaxonomyRepository) DeleteCategory(id string) error {
+	_, err := r.db.Exec(`DELETE FROM achievement_categories WHERE id = $1`, id)
+	return err
+}
+
+// FindLevels mengambil semua level sesuai urutan
+func (r *TaxonomyRepository) FindLevels() ([]models.AchievementLevel, error) {
+	query := `
+		SELECT id, code, name, description, sort_order, aliases, created_at, updated_at
+		FROM achievement_levels
+		ORDER BY sort_order ASC, name ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []models.AchievementLevel{}
+	for rows.Next() {
+		var level models.AchievementLevel
+		err := rows.Scan(
+			&level.ID,
+			&level.Code,
+			&level.Name,
+			&level.Description,
+			&level.SortOrder,
+			pq.Array(&level.Aliases),
+			&level.CreatedAt,
+			&level.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+
+	return levels, rows.Err()
+}
+
+// FindLevelByID mencari level berdasarkan ID
+func (r *TaxonomyRepository) FindLevelByID(id string) (*models.AchievementLevel, error) {
+	query := `
+		SELECT id, code, name, description, sort_order, aliases, created_at, updated_at
+		FROM achievement_levels
+		WHERE id::text = $1
+	`
+
+	var level models.AchievementLevel
+	err := r.db.QueryRow(query, id).Scan(
+		&level.ID,
+		&level.Code,
+		&level.Name,
+		&level.Description,
+		&level.SortOrder,
+		pq.Array(&level.Aliases),
+		&level.CreatedAt,
+		&level.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &level, nil
+}
+
+// ExistsLevel mengecek apakah kode atau nama level sudah dipakai,
+// selain level dengan excludeID
+func (r *TaxonomyRepository) ExistsLevel(code, name, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM achievement_levels
+			WHERE (LOWER(code) = LOWER($1) OR LOWER(name) = LOWER($2))
+			  AND id::text <> $3
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, code, name, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// CreateLevel membuat level baru
+func (r *TaxonomyRepository) CreateLevel(level *models.AchievementLevel) error {
+	level.ID = uuid.New().String()
+	level.CreatedAt = time.Now()
+	level.UpdatedAt = level.CreatedAt
+	if level.Aliases == nil {
+		level.Aliases = []string{}
+	}
+
+	query := `
+		INSERT INTO achievement_levels (id, code, name, description, sort_order, aliases, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		level.ID,
+		level.Code,
+		level.Name,
+		level.Description,
+		level.SortOrder,
+		pq.Array(level.Aliases),
+		level.CreatedAt,
+		level.UpdatedAt,
+	)
+
+	return err
+}
+
+// UpdateLevel mengubah level
+func (r *TaxonomyRepository) UpdateLevel(level *models.AchievementLevel) error {
+	level.UpdatedAt = time.Now()
+	if level.Aliases == nil {
+		level.Aliases = []string{}
+	}
+
+	query := `
+		UPDATE achievement_levels
+		SET code = $1, name = $2, description = $3, sort_order = $4, aliases = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	_, err := r.db.Exec(
+		query,
+		level.Code,
+		level.Name,
+		level.Description,
+		level.SortOrder,
+		pq.Array(level.Aliases),
+		level.UpdatedAt,
+		level.ID,
+	)
+	return err
+}
+
+// DeleteLevel menghapus level
+func (r *TaxonomyRepository) DeleteLevel(id string) error {
+	_, err := r.db.Exec(`DELETE FROM achievement_levels WHERE id = $1`, id)
+	return err
+}
+
+// nullableJSON menyimpan JSON kosong sebagai NULL
+func nullableJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
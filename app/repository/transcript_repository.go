@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TranscriptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTranscriptRepository(db *mongo.Database) *TranscriptRepository {
+	return &TranscriptRepository{
+		collection: db.Collection("issued_transcripts"),
+	}
+}
+
+// EnsureIndexes membuat unique index kode verifikasi
+func (r *TranscriptRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "verification_code", Value: 1}},
+		Options: options.Index().SetName("verification_code_unique").SetUnique(true),
+	})
+	return err
+}
+
+// Issue menyimpan transkrip yang diterbitkan. Kode verifikasi dihitung dari isi transkrip,
+// jadi transkrip dengan isi yang sama tetap memakai salinan dan tanggal terbit pertama.
+// Mengembalikan salinan yang tersimpan.
+func (r *TranscriptRepository) Issue(ctx context.Context, transcript *models.IssuedTranscript) (*models.IssuedTranscript, error) {
+	filter := bson.M{"verification_code": transcript.VerificationCode}
+	update := bson.M{"$setOnInsert": transcript}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var stored models.IssuedTranscript
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored); err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// FindByCode mencari transkrip yang diterbitkan berdasarkan kode verifikasi
+func (r *TranscriptRepository) FindByCode(ctx context.Context, code string) (*models.IssuedTranscript, error) {
+	var transcript models.IssuedTranscript
+	err := r.collection.FindOne(ctx, bson.M{"verification_code": code}).Decode(&transcript)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transcript, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UploadSessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUploadSessionRepository(db *mongo.Database) *UploadSessionRepository {
+	return &UploadSessionRepository{
+		collection: db.Collection("upload_sessions"),
+	}
+}
+
+// Create menyimpan session upload baru
+func (r *UploadSessionRepository) Create(ctx context.Context, session *models.UploadSession) error {
+	session.ID = primitive.NewObjectID()
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, session)
+	return err
+}
+
+// FindByID mencari session upload yang belum kedaluwarsa
+func (r *UploadSessionRepository) FindByID(ctx context.Context, uploadID string) (*models.UploadSession, error) {
+	var session models.UploadSession
+	filter := bson.M{
+		"upload_id":  uploadID,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// UpdateOffset memajukan offset session hanya jika offset saat ini masih sama,
+// sehingga hanya satu chunk yang bisa mengklaim offset tersebut. Dipakai juga untuk
+// mengembalikan klaim jika penulisan chunk gagal
+func (r *UploadSessionRepository) UpdateOffset(ctx context.Context, uploadID string, currentOffset, newOffset int64, expiresAt time.Time) error {
+	filter := bson.M{
+		"upload_id": uploadID,
+		"offset":    currentOffset,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"offset":     newOffset,
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete menghapus session upload
+func (r *UploadSessionRepository) Delete(ctx context.Context, uploadID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"upload_id": uploadID})
+	return err
+}
+
+// FindExpired mencari session upload yang sudah melewati batas waktu
+func (r *UploadSessionRepository) FindExpired(ctx context.Context, now time.Time) ([]models.UploadSession, error) {
+	var sessions []models.UploadSession
+	filter := bson.M{"expires_at": bson.M{"$lte": now}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetPendingSizeByStudentID menghitung total ukuran upload bertahap yang masih berjalan
+func (r *UploadSessionRepository) GetPendingSizeByStudentID(ctx context.Context, studentID string) (int64, error) {
+	return r.pendingSize(ctx, bson.M{"student_id": studentID})
+}
+
+// GetPendingSizeByAchievementID menghitung total ukuran upload bertahap yang masih
+// berjalan untuk satu achievement
+func (r *UploadSessionRepository) GetPendingSizeByAchievementID(ctx context.Context, achievementID string) (int64, error) {
+	return r.pendingSize(ctx, bson.M{"achievement_id": achievementID})
+}
+
+func (r *UploadSessionRepository) pendingSize(ctx context.Context, match bson.M) (int64, error) {
+	match["expires_at"] = bson.M{"$gt": time.Now()}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$filesize"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+	return results[0].Total, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserImportCredentialsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUserImportCredentialsRepository(db *mongo.Database) *UserImportCredentialsRepository {
+	return &UserImportCredentialsRepository{
+		collection: db.Collection("user_import_credentials"),
+	}
+}
+
+// EnsureIndexes membuat TTL index agar kredensial yang tidak diunduh terhapus otomatis
+func (r *UserImportCredentialsRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+		{
+			Keys:    bson.D{{Key: "import_id", Value: 1}},
+			Options: options.Index().SetName("import_id_unique").SetUnique(true),
+		},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Create menyimpan kredensial terenkripsi hasil import
+func (r *UserImportCredentialsRepository) Create(ctx context.Context, credentials *models.UserImportCredentials) error {
+	credentials.ID = primitive.NewObjectID()
+	credentials.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, credentials)
+	return err
+}
+
+// Take mengambil sekaligus menghapus kredensial milik user yang belum kedaluwarsa,
+// sehingga kredensial hanya bisa diunduh satu kali
+func (r *UserImportCredentialsRepository) Take(ctx context.Context, importID, userID string) (*models.UserImportCredentials, error) {
+	var credentials models.UserImportCredentials
+	filter := bson.M{
+		"import_id":  importID,
+		"user_id":    userID,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+
+	err := r.collection.FindOneAndDelete(ctx, filter).Decode(&credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return &credentials, nil
+}
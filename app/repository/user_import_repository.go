@@ -0,0 +1,180 @@
+package repository
+
+import (
+	models "crud-app/app/model"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// UserImportRepository akses data untuk import user massal. Semua user baru beserta
+// profil mahasiswa/dosennya disimpan dalam satu transaksi.
+type UserImportRepository struct {
+	db *sql.DB
+}
+
+func NewUserImportRepository(db *sql.DB) *UserImportRepository {
+	return &UserImportRepository{db: db}
+}
+
+// UserImportEntry user baru beserta profil opsionalnya
+type UserImportEntry struct {
+	User     *models.User
+	Student  *models.Student
+	Lecturer *models.Lecturer
+}
+
+// Lookup mengambil role, username, email, NIM dan NIP yang sudah ada untuk
+// nilai-nilai yang dipakai file import
+func (r *UserImportRepository) Lookup(rows []models.UserImportRow) (*models.UserImportLookup, error) {
+	var usernames, emails, studentIDs, nips []string
+	for _, row := range rows {
+		usernames = append(usernames, strings.ToLower(row.Username))
+		emails = append(emails, strings.ToLower(row.Email))
+		if row.StudentID != "" {
+			studentIDs = append(studentIDs, strings.ToLower(row.StudentID))
+		}
+		if row.LecturerID != "" {
+			nips = append(nips, strings.ToLower(row.LecturerID))
+		}
+		if row.AdvisorNIP != "" {
+			nips = append(nips, strings.ToLower(row.AdvisorNIP))
+		}
+	}
+
+	lookup := &models.UserImportLookup{
+		Roles:               make(map[string]string),
+		ExistingUsernames:   make(map[string]bool),
+		ExistingEmails:      make(map[string]bool),
+		ExistingStudentIDs:  make(map[string]bool),
+		ExistingLecturerIDs: make(map[string]bool),
+		Lecturers:           make(map[string]string),
+	}
+
+	roles, err := r.db.Query(`SELECT id::text, name FROM roles`)
+	if err != nil {
+		return nil, err
+	}
+	defer roles.Close()
+	for roles.Next() {
+		var id, name string
+		if err := roles.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		lookup.Roles[strings.ToLower(id)] = id
+		lookup.Roles[strings.ToLower(name)] = id
+	}
+	if err := roles.Err(); err != nil {
+		return nil, err
+	}
+
+	sets := []struct {
+		query  string
+		values []string
+		target map[string]bool
+	}{
+		{`SELECT LOWER(username) FROM users WHERE LOWER(username) = ANY($1)`, usernames, lookup.ExistingUsernames},
+		{`SELECT LOWER(email) FROM users WHERE LOWER(email) = ANY($1)`, emails, lookup.ExistingEmails},
+		{`SELECT LOWER(student_id) FROM students WHERE LOWER(student_id) = ANY($1)`, studentIDs, lookup.ExistingStudentIDs},
+		{`SELECT LOWER(lecturer_id) FROM lecturers WHERE LOWER(lecturer_id) = ANY($1)`, nips, lookup.ExistingLecturerIDs},
+	}
+	for _, set := range sets {
+		if err := r.collect(set.query, set.values, set.target); err != nil {
+			return nil, err
+		}
+	}
+
+	// Dosen wali yang sudah terdaftar: NIP ke user ID
+	lecturers, err := r.db.Query(`
+		SELECT LOWER(l.lecturer_id), l.user_id::text
+		FROM lecturers l
+		JOIN users u ON u.id = l.user_id
+		WHERE LOWER(l.lecturer_id) = ANY($1) AND u.deleted_at IS NULL
+	`, pq.Array(nips))
+	if err != nil {
+		return nil, err
+	}
+	defer lecturers.Close()
+	for lecturers.Next() {
+		var nip, userID string
+		if err := lecturers.Scan(&nip, &userID); err != nil {
+			return nil, err
+		}
+		lookup.Lecturers[nip] = userID
+	}
+
+	return lookup, lecturers.Err()
+}
+
+func (r *UserImportRepository) collect(query string, values []string, target map[string]bool) error {
+	if len(values) == 0 {
+		return nil
+	}
+	rows, err := r.db.Query(query, pq.Array(values))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return err
+		}
+		target[value] = true
+	}
+	return rows.Err()
+}
+
+// CreateAll menyimpan semua user dalam satu transaksi. User dibuat lebih dulu,
+// lalu profil dosen, lalu profil mahasiswa, sehingga mahasiswa boleh memakai
+// dosen wali yang diimport di file yang sama. Jika satu gagal, semua dibatalkan.
+func (r *UserImportRepository) CreateAll(entries []UserImportEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, entry := range entries {
+		user := entry.User
+		_, err := tx.Exec(`
+			INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, user.ID, user.Username, user.Email, user.PasswordHash, user.FullName, user.RoleID, user.IsActive, user.CreatedAt, user.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if lecturer := entry.Lecturer; lecturer != nil {
+			_, err := tx.Exec(`
+				INSERT INTO lecturers (id, user_id, lecturer_id, department, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, lecturer.ID, lecturer.UserID, lecturer.LecturerID, lecturer.Department, lecturer.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if student := entry.Student; student != nil {
+			var advisorID interface{}
+			if student.AdvisorID != "" {
+				advisorID = student.AdvisorID
+			}
+			_, err := tx.Exec(`
+				INSERT INTO students (id, user_id, student_id, program_study, academic_year, advisor_id, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`, student.ID, student.UserID, student.StudentID, student.ProgramStudy, student.AcademicYear, advisorID, student.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
@@ -4,6 +4,7 @@ import (
 models "crud-app/app/model"
 "database/sql"
 "errors"
+"fmt"
 	"time"
 )
 
@@ -305,4 +306,70 @@ func (r *UserRepository) CheckRoleExists(roleID string) (bool, error) {
 	}
 
 	return exists, nil
-}
\ No newline at end of file
+}
+
+// FindWithCursor mencari users dengan pagination berbasis cursor (keyset) pada created_at dan id.
+// Mengambil limit+1 baris agar pemanggil tahu masih ada halaman berikutnya.
+func (r *UserRepository) FindWithCursor(limit int, roleFilter string, cursor *models.Cursor) ([]models.User, error) {
+	query := `
+		SELECT id, username, email, password_hash, full_name, role_id, is_active, created_at, updated_at
+		FROM users
+		WHERE deleted_at IS NULL
+	`
+	args := []interface{}{}
+
+	if roleFilter != "" {
+		args = append(args, roleFilter)
+		query += fmt.Sprintf(` AND role_id = $%d`, len(args))
+	}
+
+	order := "DESC"
+	if cursor != nil {
+		if cursor.Value == nil {
+			return nil, errors.New("cursor tanpa nilai created_at")
+		}
+		createdAt, err := time.Parse(time.RFC3339Nano, *cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		operator := "<"
+		if cursor.Before {
+			operator = ">"
+			order = "ASC"
+		}
+		args = append(args, createdAt, cursor.ID)
+		query += fmt.Sprintf(` AND (created_at, id) %s ($%d, $%d)`, operator, len(args)-1, len(args))
+	}
+
+	args = append(args, limit+1)
+	query += fmt.Sprintf(` ORDER BY created_at %s, id %s LIMIT $%d`, order, order, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.PasswordHash,
+			&user.FullName,
+			&user.RoleID,
+			&user.IsActive,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/utils"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Kolom export list achievement
+var achievementExportHeader = []interface{}{
+	"achievement_id", "student_number", "student_name", "program_study", "academic_year",
+	"advisor_name", "title", "category", "level", "date", "status",
+	"submitted_at", "verified_at", "rejection_note", "created_at",
+}
+
+// ParseExportFormat membaca query parameter format export (csv atau xlsx)
+func ParseExportFormat(c *fiber.Ctx) (string, error) {
+	format := strings.ToLower(c.Query("format", utils.ExportFormatCSV))
+	if format != utils.ExportFormatCSV && format != utils.ExportFormatXLSX {
+		return "", fmt.Errorf("Invalid format. Valid values: csv, xlsx")
+	}
+	return format, nil
+}
+
+// ExportAchievements godoc
+// @Summary Export achievements
+// @Description Export the achievement list as CSV or XLSX with the same filters (including details.<field>=value), sorting and access scoping as the list endpoints (admin: all students, lecturer: advisees, student: own achievements). Small exports are streamed directly. Exports with more rows than the sync limit, or requested with async=true, run as a background job; poll GET /exports/{id} and download the file from GET /exports/{id}/download.
+// @Tags Statistics & Reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, xlsx)" default(csv)
+// @Param async query bool false "Always run as a background job"
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param category query string false "Filter by category (case-insensitive)"
+// @Param level query string false "Filter by level (case-insensitive)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Param student_id query string false "Filter by student (user) ID"
+// @Param program_study query string false "Filter by student program study"
+// @Param academic_year query string false "Filter by student academic year"
+// @Param advisor_id query string false "Filter by advisor (lecturer user) ID"
+// @Param sort_by query string false "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at" default(created_at)
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Success 200 {file} file "Export file"
+// @Success 202 {object} object{status=string,message=string,data=models.ExportJob} "Export job created"
+// @Failure 400 {object} map[string]interface{} "Invalid format or filter parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to export achievements"
+// @Router /achievements/export [get]
+func (s *AchievementService) ExportAchievements(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	roleID, _ := c.Locals("role_id").(string)
+
+	format, err := ParseExportFormat(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+	filter, err := ParseAchievementFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	// Step 1: Tentukan cakupan data sesuai role
+	scope, err := s.achievementScope(userID, roleID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data mahasiswa bimbingan",
+		})
+	}
+
+	ctx := context.Background()
+
+	// Step 2: Export besar dijalankan sebagai background job
+	query, ok := scopedAchievementViewFilter(filter, scope)
+	var total int64
+	if ok {
+		total, err = s.viewRepo.CountWithFilter(ctx, query)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal menghitung data export",
+			})
+		}
+	}
+	if c.QueryBool("async") || total > s.exportConfig.SyncRowLimit {
+		job := &models.ExportJob{
+			JobID:      uuid.New().String(),
+			UserID:     userID,
+			Format:     format,
+			Status:     models.ExportStatusPending,
+			Filter:     *filter,
+			StudentIDs: scope,
+			ExpiresAt:  time.Now().Add(s.exportConfig.Retention),
+		}
+		if err := s.exportJobRepo.Create(ctx, job); err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal membuat job export",
+			})
+		}
+		go s.runExportJob(job)
+
+		return c.Status(202).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Export sedang diproses",
+			"data":    job,
+		})
+	}
+
+	// Step 3: Stream langsung ke response
+	setExportHeaders(c, "achievements", format)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if _, err := s.writeAchievementExport(context.Background(), w, format, filter, scope); err != nil {
+			log.Printf("Gagal stream export achievement: %v", err)
+		}
+	})
+	return nil
+}
+
+// ExportStatistics godoc
+// @Summary Export achievement statistics
+// @Description Export achievement statistics (summary, category, level and period breakdown) as CSV or XLSX, scoped like the list endpoints (admin: all students, lecturer: advisees, student: own achievements).
+// @Tags Statistics & Reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, xlsx)" default(csv)
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} map[string]interface{} "Invalid format, status or date filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to export statistics"
+// @Router /reports/statistics/export [get]
+func (s *AchievementService) ExportStatistics(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	roleID, _ := c.Locals("role_id").(string)
+
+	format, err := ParseExportFormat(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+	statsFilter, err := ParseStatisticsFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	scope, err := s.achievementScope(userID, roleID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data mahasiswa bimbingan",
+		})
+	}
+
+	stats, err := s.viewRepo.GetStatistics(context.Background(), scope, statsFilter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil statistik",
+		})
+	}
+
+	setExportHeaders(c, "statistics", format)
+	return WriteStatisticsExport(c.Response().BodyWriter(), format, stats)
+}
+
+// GetExportJob godoc
+// @Summary Get export job
+// @Description Get the status of a background export job created by the current user. When status is completed the file can be downloaded from /exports/{id}/download until expires_at.
+// @Tags Statistics & Reports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export job ID"
+// @Success 200 {object} object{status=string,message=string,data=models.ExportJob} "Export job retrieved"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 404 {object} map[string]interface{} "Export job not found"
+// @Router /exports/{id} [get]
+func (s *AchievementService) GetExportJob(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	job, err := s.exportJobRepo.FindByID(context.Background(), c.Params("id"), userID)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Job export tidak ditemukan",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Job export berhasil diambil",
+		"data":    job,
+	})
+}
+
+// DownloadExport godoc
+// @Summary Download export file
+// @Description Download the file produced by a completed background export job created by the current user.
+// @Tags Statistics & Reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param id path string true "Export job ID"
+// @Success 200 {file} file "Export file"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 404 {object} map[string]interface{} "Export job not found or expired"
+// @Failure 409 {object} map[string]interface{} "Export job not completed yet"
+// @Router /exports/{id}/download [get]
+func (s *AchievementService) DownloadExport(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	job, err := s.exportJobRepo.FindByID(context.Background(), c.Params("id"), userID)
+	if err != nil || time.Now().After(job.ExpiresAt) {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Job export tidak ditemukan",
+		})
+	}
+	if job.Status != models.ExportStatusCompleted {
+		return c.Status(409).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Export belum selesai",
+			"data":    job,
+		})
+	}
+
+	setExportHeaders(c, "achievements", job.Format)
+	return c.SendFile(job.Filepath)
+}
+
+// runExportJob menulis hasil export ke file. File ditulis ke nama sementara lalu
+// di-rename agar download tidak pernah membaca file yang belum lengkap.
+func (s *AchievementService) runExportJob(job *models.ExportJob) {
+	ctx := context.Background()
+	if err := s.exportJobRepo.UpdateStatus(ctx, job.JobID, models.ExportStatusRunning); err != nil {
+		log.Printf("Gagal memperbarui job export %s: %v", job.JobID, err)
+	}
+
+	path := filepath.Join(s.exportConfig.ExportPath, job.JobID+"."+job.Format)
+	rows, err := s.writeExportFile(ctx, path, job)
+	if err != nil {
+		log.Printf("Job export %s gagal: %v", job.JobID, err)
+		if err := s.exportJobRepo.Fail(ctx, job.JobID, "Gagal membuat file export"); err != nil {
+			log.Printf("Gagal memperbarui job export %s: %v", job.JobID, err)
+		}
+		return
+	}
+
+	if err := s.exportJobRepo.Complete(ctx, job.JobID, rows, path); err != nil {
+		log.Printf("Gagal memperbarui job export %s: %v", job.JobID, err)
+	}
+}
+
+func (s *AchievementService) writeExportFile(ctx context.Context, path string, job *models.ExportJob) (int, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+
+	w := bufio.NewWriter(file)
+	rows, err := s.writeAchievementExport(ctx, w, job.Format, &job.Filter, job.StudentIDs)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return 0, err
+	}
+
+	return rows, os.Rename(tmpPath, path)
+}
+
+// writeAchievementExport menulis list achievement sesuai filter dan cakupan ke w
+func (s *AchievementService) writeAchievementExport(ctx context.Context, w io.Writer, format string, filter *models.AchievementFilter, scope []string) (int, error) {
+	table, err := utils.NewTableWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+	if err := table.WriteRow(achievementExportHeader...); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	if query, ok := scopedAchievementViewFilter(filter, scope); ok {
+		err = s.viewRepo.Each(ctx, query, achievementSortFields[filter.SortBy], filter.SortOrder == "asc", func(view *models.AchievementView) error {
+			rows++
+			return table.WriteRow(achievementExportRow(view)...)
+		})
+		if err != nil {
+			return rows, err
+		}
+	}
+
+	return rows, table.Close()
+}
+
+func achievementExportRow(view *models.AchievementView) []interface{} {
+	return []interface{}{
+		view.AchievementID,
+		view.StudentNumber,
+		view.StudentName,
+		view.ProgramStudy,
+		view.AcademicYear,
+		view.AdvisorName,
+		view.Title,
+		view.Category,
+		view.Level,
+		view.Date.Format("2006-01-02"),
+		view.Status,
+		view.SubmittedAt,
+		view.VerifiedAt,
+		view.RejectionNote,
+		view.CreatedAt,
+	}
+}
+
+// WriteStatisticsExport menulis statistik sebagai tabel section, key dan count
+func WriteStatisticsExport(w io.Writer, format string, stats map[string]interface{}) error {
+	table, err := utils.NewTableWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{{"section", "key", "count"}}
+	for _, key := range []string{"total_achievements", "total_verified", "total_pending", "total_rejected", "total_draft"} {
+		rows = append(rows, []interface{}{"summary", key, stats[key].(int)})
+	}
+	for _, section := range []struct{ name, key string }{
+		{"category", "category_count"},
+		{"level", "level_count"},
+		{"period", "period_count"},
+	} {
+		counts := stats[section.key].(map[string]int)
+		keys := make([]string, 0, len(counts))
+		for key := range counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			rows = append(rows, []interface{}{section.name, key, counts[key]})
+		}
+	}
+
+	for _, row := range rows {
+		if err := table.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return table.Close()
+}
+
+func setExportHeaders(c *fiber.Ctx, name, format string) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, utils.ExportContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Field sorting yang didukung list achievement dan nama field-nya di read model
+var achievementSortFields = map[string]string{
+	"created_at":    "created_at",
+	"updated_at":    "updated_at",
+	"date":          "date",
+	"title":         "title",
+	"category":      "category",
+	"level":         "level",
+	"status":        "status",
+	"program_study": "program_study",
+	"academic_year": "academic_year",
+	"advisor":       "advisor_name",
+	"submitted_at":  "submitted_at",
+	"verified_at":   "verified_at",
+}
+
+// ParseAchievementFilter membaca query parameter filter dan sorting list achievement
+func ParseAchievementFilter(c *fiber.Ctx) (*models.AchievementFilter, error) {
+	filter := &models.AchievementFilter{
+		Status:       c.Query("status"),
+		Category:     strings.TrimSpace(c.Query("category")),
+		Level:        strings.TrimSpace(c.Query("level")),
+		StudentID:    c.Query("student_id"),
+		ProgramStudy: strings.TrimSpace(c.Query("program_study")),
+		AcademicYear: strings.TrimSpace(c.Query("academic_year")),
+		AdvisorID:    c.Query("advisor_id"),
+		SortBy:       c.Query("sort_by", "created_at"),
+		SortOrder:    strings.ToLower(c.Query("sort_order", "desc")),
+	}
+
+	if err := validateStatusFilter(filter.Status); err != nil {
+		return nil, err
+	}
+
+	var err error
+	filter.DateFrom, filter.DateTo, err = parseDateRange(c)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Details, err = parseDetailsFilter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := achievementSortFields[filter.SortBy]; !ok {
+		return nil, fmt.Errorf("Invalid sort_by. Valid values: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at")
+	}
+	if filter.SortOrder != "asc" && filter.SortOrder != "desc" {
+		return nil, fmt.Errorf("Invalid sort_order. Valid values: asc, desc")
+	}
+
+	return filter, nil
+}
+
+// ParseStatisticsFilter membaca query parameter filter statistik (status dan rentang tanggal)
+func ParseStatisticsFilter(c *fiber.Ctx) (*models.StatisticsFilter, error) {
+	filter := &models.StatisticsFilter{Status: c.Query("status")}
+	if err := validateStatusFilter(filter.Status); err != nil {
+		return nil, err
+	}
+
+	var err error
+	filter.DateFrom, filter.DateTo, err = parseDateRange(c)
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// ParseTrendFilter membaca query parameter laporan trend: filter statistik,
+// group_by (dipisah koma) dan bucket (month, semester, year)
+func ParseTrendFilter(c *fiber.Ctx) (*models.TrendFilter, error) {
+	statsFilter, err := ParseStatisticsFilter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.TrendFilter{
+		StatisticsFilter: *statsFilter,
+		Bucket:           strings.ToLower(c.Query("bucket", repository.TrendBucketMonth)),
+	}
+	switch filter.Bucket {
+	case repository.TrendBucketMonth, repository.TrendBucketSemester, repository.TrendBucketYear:
+	default:
+		return nil, fmt.Errorf("Invalid bucket. Valid values: month, semester, year")
+	}
+
+	seen := make(map[string]bool)
+	for _, dimension := range strings.Split(c.Query("group_by"), ",") {
+		dimension = strings.TrimSpace(dimension)
+		if dimension == "" || seen[dimension] {
+			continue
+		}
+		if _, ok := repository.TrendDimensions[dimension]; !ok {
+			return nil, fmt.Errorf("Invalid group_by. Valid values: program_study, academic_year, category, level, advisor")
+		}
+		seen[dimension] = true
+		filter.GroupBy = append(filter.GroupBy, dimension)
+	}
+	return filter, nil
+}
+
+// ParseLeaderboardFilter membaca cakupan (program_study, academic_year, advisor_id,
+// date_from, date_to), dasar peringkat (rank_by) dan jumlah entri (limit) leaderboard
+func ParseLeaderboardFilter(c *fiber.Ctx) (*models.LeaderboardFilter, error) {
+	filter := &models.LeaderboardFilter{
+		ProgramStudy: strings.TrimSpace(c.Query("program_study")),
+		AcademicYear: strings.TrimSpace(c.Query("academic_year")),
+		AdvisorID:    strings.TrimSpace(c.Query("advisor_id")),
+		RankBy:       strings.ToLower(c.Query("rank_by", models.LeaderboardRankByVerified)),
+		Limit:        c.QueryInt("limit", 10),
+	}
+
+	switch filter.RankBy {
+	case models.LeaderboardRankByVerified, models.LeaderboardRankByPoints:
+	default:
+		return nil, fmt.Errorf("Invalid rank_by. Valid values: verified, points")
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		return nil, fmt.Errorf("limit harus antara 1 dan 100")
+	}
+
+	var err error
+	filter.DateFrom, filter.DateTo, err = parseDateRange(c)
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// detailsFieldPattern nama field details yang boleh difilter, boleh bersarang dengan titik
+var detailsFieldPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// parseDetailsFilter membaca query parameter details.<field>=value
+func parseDetailsFilter(c *fiber.Ctx) (map[string]string, error) {
+	var details map[string]string
+	var err error
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		field, ok := strings.CutPrefix(string(key), "details.")
+		if !ok || err != nil {
+			return
+		}
+		if !detailsFieldPattern.MatchString(field) {
+			err = fmt.Errorf("Invalid details filter %q. Use details.<field>=value", string(key))
+			return
+		}
+		if details == nil {
+			details = make(map[string]string)
+		}
+		details[field] = strings.TrimSpace(string(value))
+	})
+	return details, err
+}
+
+func validateStatusFilter(status string) error {
+	if status == "" {
+		return nil
+	}
+	validStatuses := map[string]bool{
+		"draft":     true,
+		"submitted": true,
+		"verified":  true,
+		"rejected":  true,
+	}
+	if !validStatuses[status] {
+		return fmt.Errorf("Invalid status filter. Valid values: draft, submitted, verified, rejected")
+	}
+	return nil
+}
+
+// parseDateRange membaca date_from dan date_to (YYYY-MM-DD)
+func parseDateRange(c *fiber.Ctx) (*time.Time, *time.Time, error) {
+	var dateFrom, dateTo *time.Time
+	if value := c.Query("date_from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Format date_from tidak valid. Gunakan YYYY-MM-DD")
+		}
+		dateFrom = &parsed
+	}
+	if value := c.Query("date_to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Format date_to tidak valid. Gunakan YYYY-MM-DD")
+		}
+		dateTo = &parsed
+	}
+	if dateFrom != nil && dateTo != nil && dateTo.Before(*dateFrom) {
+		return nil, nil, fmt.Errorf("date_to tidak boleh lebih awal dari date_from")
+	}
+	return dateFrom, dateTo, nil
+}
+
+// findAchievementsWithFilter menjalankan filter, sorting dan pagination di read model
+// achievement_views yang sudah memuat field PostgreSQL, sehingga total dan urutan
+// selalu konsisten. scope nil berarti semua mahasiswa.
+func (s *AchievementService) findAchievementsWithFilter(ctx context.Context, filter *models.AchievementFilter, scope []string, limit, offset int) ([]models.AchievementView, int64, error) {
+	query, ok := scopedAchievementViewFilter(filter, scope)
+	if !ok {
+		return []models.AchievementView{}, 0, nil
+	}
+
+	return s.viewRepo.FindWithFilter(
+		ctx,
+		query,
+		achievementSortFields[filter.SortBy],
+		filter.SortOrder == "asc",
+		limit,
+		offset,
+	)
+}
+
+// findAchievementsWithCursor sama seperti findAchievementsWithFilter, tetapi memakai
+// pagination berbasis cursor pada field sorting dan _id
+func (s *AchievementService) findAchievementsWithCursor(ctx context.Context, filter *models.AchievementFilter, scope []string, cursor *models.Cursor, limit int) ([]models.AchievementView, models.CursorPaginationMeta, error) {
+	query, ok := scopedAchievementViewFilter(filter, scope)
+	if !ok {
+		return []models.AchievementView{}, models.CursorPaginationMeta{Limit: limit}, nil
+	}
+
+	views, err := s.viewRepo.FindWithCursor(
+		ctx,
+		query,
+		achievementSortFields[filter.SortBy],
+		filter.SortOrder == "asc",
+		cursor,
+		limit,
+	)
+	if err != nil {
+		return nil, models.CursorPaginationMeta{}, err
+	}
+
+	views, pagination := utils.CursorPage(views, limit, cursor, achievementViewCursor(filter), s.cursorSecret)
+	return views, pagination, nil
+}
+
+// scopedAchievementViewFilter menggabungkan filter dengan cakupan mahasiswa.
+// Mengembalikan false jika cakupannya kosong sehingga tidak perlu query.
+func scopedAchievementViewFilter(filter *models.AchievementFilter, scope []string) (bson.M, bool) {
+	studentIDs := scope
+	if filter.StudentID != "" {
+		studentIDs = intersectStudentIDs(studentIDs, []string{filter.StudentID})
+	}
+	if studentIDs != nil && len(studentIDs) == 0 {
+		return nil, false
+	}
+	return buildAchievementViewFilter(filter, studentIDs), true
+}
+
+// buildAchievementViewFilter menyusun filter MongoDB untuk read model achievement_views
+func buildAchievementViewFilter(filter *models.AchievementFilter, studentIDs []string) bson.M {
+	query := bson.M{"is_deleted": false}
+
+	if studentIDs != nil {
+		query["student_id"] = bson.M{"$in": studentIDs}
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	// Category, level dan program studi berupa teks bebas, dicocokkan tanpa membedakan huruf besar/kecil
+	if filter.Category != "" {
+		query["category"] = exactMatchIgnoreCase(filter.Category)
+	}
+	if filter.Level != "" {
+		query["level"] = exactMatchIgnoreCase(filter.Level)
+	}
+	if filter.ProgramStudy != "" {
+		query["program_study"] = exactMatchIgnoreCase(filter.ProgramStudy)
+	}
+	if filter.AcademicYear != "" {
+		query["academic_year"] = filter.AcademicYear
+	}
+	if filter.AdvisorID != "" {
+		query["advisor_id"] = filter.AdvisorID
+	}
+
+	for field, value := range filter.Details {
+		query["details."+field] = detailsValueMatch(value)
+	}
+
+	dateRange := bson.M{}
+	if filter.DateFrom != nil {
+		dateRange["$gte"] = *filter.DateFrom
+	}
+	if filter.DateTo != nil {
+		// date_to inklusif sampai akhir hari
+		dateRange["$lt"] = filter.DateTo.AddDate(0, 0, 1)
+	}
+	if len(dateRange) > 0 {
+		query["date"] = dateRange
+	}
+
+	return query
+}
+
+func exactMatchIgnoreCase(value string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"}
+}
+
+// detailsValueMatch mencocokkan nilai filter details sebagai teks (tanpa membedakan
+// huruf besar/kecil), angka atau boolean karena tipe field bergantung schema kategori
+func detailsValueMatch(value string) bson.M {
+	candidates := []interface{}{exactMatchIgnoreCase(value)}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		candidates = append(candidates, number)
+	}
+	if value == "true" || value == "false" {
+		candidates = append(candidates, value == "true")
+	}
+	return bson.M{"$in": candidates}
+}
+
+// intersectStudentIDs mengembalikan irisan dua daftar student_id. nil berarti tanpa batasan.
+func intersectStudentIDs(a, b []string) []string {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	allowed := make(map[string]bool, len(b))
+	for _, id := range b {
+		allowed[id] = true
+	}
+	result := []string{}
+	for _, id := range a {
+		if allowed[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// achievementScope menentukan mahasiswa yang boleh dilihat sesuai role.
+// nil berarti semua mahasiswa (admin).
+func (s *AchievementService) achievementScope(userID, roleID string) ([]string, error) {
+	switch roleID {
+	case "1":
+		return nil, nil
+	case "2":
+		advisees, err := s.studentRepo.FindStudentIDsByAdvisorID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{}, advisees...), nil
+	}
+	return []string{userID}, nil
+}
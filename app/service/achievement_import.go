@@ -0,0 +1,523 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// AchievementImportMaxRows batas jumlah baris data dalam satu file import achievement
+	AchievementImportMaxRows = 5000
+	// achievementImportMaxFileSize batas ukuran file tabel import achievement (10MB)
+	achievementImportMaxFileSize = 10 << 20
+)
+
+// Hasil import per baris
+const (
+	AchievementImportCreated = "created"
+	AchievementImportSkipped = "skipped"
+	// Dry run: baris valid dan akan dibuat
+	AchievementImportValid = "valid"
+)
+
+// achievementImportColumns kolom file import achievement dan apakah wajib diisi
+var achievementImportColumns = map[string]bool{
+	"external_key": true,
+	"student_id":   true,
+	"title":        true,
+	"category":     true,
+	"level":        true,
+	"date":         true,
+	"description":  false,
+	"details":      false,
+	"status":       false,
+	"verified_by":  false,
+	"verified_at":  false,
+	"documents":    false,
+}
+
+// errAchievementImportExists external key sudah dipakai achievement lain (import bersamaan)
+var errAchievementImportExists = errors.New("external key sudah diimport")
+
+// ParseAchievementImportTable mengubah tabel hasil utils.ReadTable menjadi baris import
+// achievement. Header tidak case-sensitive, baris kosong dilewati dan nomor baris dihitung
+// dari 1 termasuk header. details berisi JSON object dan documents berisi path file di
+// dalam ZIP dokumen, dipisah ";".
+func ParseAchievementImportTable(table [][]string) ([]models.AchievementImportRow, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("file import kosong")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range table[0] {
+		name := strings.ToLower(strings.TrimSpace(header))
+		if name == "" {
+			continue
+		}
+		if _, known := achievementImportColumns[name]; !known {
+			return nil, fmt.Errorf("kolom %q tidak dikenal", header)
+		}
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("kolom %q muncul lebih dari sekali", header)
+		}
+		columns[name] = i
+	}
+	for name, required := range achievementImportColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("kolom wajib %q tidak ditemukan", name)
+		}
+	}
+
+	rows := []models.AchievementImportRow{}
+	for i, record := range table[1:] {
+		value := func(name string) string {
+			index, ok := columns[name]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows) == AchievementImportMaxRows {
+			return nil, fmt.Errorf("file import maksimal %d baris data", AchievementImportMaxRows)
+		}
+
+		row := models.AchievementImportRow{
+			Row:           i + 2,
+			ExternalKey:   value("external_key"),
+			StudentNumber: value("student_id"),
+			Title:         value("title"),
+			Category:      value("category"),
+			Level:         value("level"),
+			Date:          value("date"),
+			Description:   value("description"),
+			Status:        strings.ToLower(value("status")),
+			VerifiedBy:    value("verified_by"),
+			VerifiedAt:    value("verified_at"),
+		}
+		if row.Status == "" {
+			row.Status = "submitted"
+		}
+		if details := value("details"); details != "" {
+			if err := json.Unmarshal([]byte(details), &row.Details); err != nil || row.Details == nil {
+				row.Errors = append(row.Errors, "details harus berupa JSON object")
+			}
+		}
+		for _, document := range strings.Split(value("documents"), ";") {
+			if document = strings.TrimSpace(document); document != "" {
+				row.Documents = append(row.Documents, document)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ValidateAchievementImportRows memvalidasi baris import terhadap data yang sudah ada,
+// taxonomy dan isi ZIP dokumen (bundle boleh nil jika tidak ada dokumen). Baris dengan
+// external key yang sudah pernah diimport mendapat ExistingAchievementID dan tidak
+// divalidasi ulang. Kategori dan level diganti dengan nama bakunya.
+func ValidateAchievementImportRows(rows []models.AchievementImportRow, lookup *models.AchievementImportLookup, taxonomy *models.Taxonomy, bundle *utils.DocumentBundle) {
+	seenKeys := make(map[string]int)
+	today := time.Now()
+
+	for i := range rows {
+		row := &rows[i]
+
+		if row.ExternalKey == "" {
+			row.Errors = append(row.Errors, "external_key wajib diisi")
+		} else if first, ok := seenKeys[row.ExternalKey]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("external_key %q duplikat dengan baris %d", row.ExternalKey, first))
+			continue
+		} else {
+			seenKeys[row.ExternalKey] = row.Row
+			if achievementID, ok := lookup.ExistingKeys[row.ExternalKey]; ok {
+				row.ExistingAchievementID = achievementID
+				row.Errors = nil
+				continue
+			}
+		}
+
+		if row.StudentNumber == "" {
+			row.Errors = append(row.Errors, "student_id wajib diisi")
+		} else if row.StudentUserID = lookup.Students[strings.ToLower(row.StudentNumber)]; row.StudentUserID == "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("mahasiswa dengan NIM %q tidak ditemukan", row.StudentNumber))
+		}
+		if row.Title == "" {
+			row.Errors = append(row.Errors, "title wajib diisi")
+		}
+
+		categoryValid := false
+		if row.Category == "" {
+			row.Errors = append(row.Errors, "category wajib diisi")
+		} else if category, ok := taxonomy.ResolveCategory(row.Category); !ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("kategori %q tidak terdaftar", row.Category))
+		} else {
+			row.Category = category.Name
+			categoryValid = true
+		}
+		if row.Level == "" {
+			row.Errors = append(row.Errors, "level wajib diisi")
+		} else if level, ok := taxonomy.ResolveLevel(row.Level); !ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("level %q tidak terdaftar", row.Level))
+		} else {
+			row.Level = level.Name
+		}
+		if categoryValid {
+			var validationErr *utils.DetailsValidationError
+			if err := validateDetails(taxonomy, row.Category, row.Details); errors.As(err, &validationErr) {
+				for _, message := range validationErr.Errors {
+					row.Errors = append(row.Errors, "details "+message)
+				}
+			} else if err != nil {
+				row.Errors = append(row.Errors, err.Error())
+			}
+		}
+
+		if row.Date == "" {
+			row.Errors = append(row.Errors, "date wajib diisi")
+		} else if date, err := time.Parse("2006-01-02", row.Date); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("date %q tidak valid, gunakan format YYYY-MM-DD", row.Date))
+		} else if date.After(today) {
+			row.Errors = append(row.Errors, "date tidak boleh di masa depan")
+		} else {
+			row.AchievementDate = date
+		}
+
+		switch row.Status {
+		case "verified":
+			if row.VerifiedBy == "" {
+				row.Errors = append(row.Errors, "verified_by wajib diisi untuk status verified")
+			} else if row.VerifierID = lookup.Verifiers[strings.ToLower(row.VerifiedBy)]; row.VerifierID == "" {
+				row.Errors = append(row.Errors, fmt.Sprintf("verifikator %q tidak ditemukan (username atau NIP dosen/admin)", row.VerifiedBy))
+			}
+			row.VerificationDate = today
+			if row.VerifiedAt != "" {
+				verifiedAt, err := time.Parse("2006-01-02", row.VerifiedAt)
+				switch {
+				case err != nil:
+					row.Errors = append(row.Errors, fmt.Sprintf("verified_at %q tidak valid, gunakan format YYYY-MM-DD", row.VerifiedAt))
+				case verifiedAt.After(today):
+					row.Errors = append(row.Errors, "verified_at tidak boleh di masa depan")
+				case !row.AchievementDate.IsZero() && verifiedAt.Before(row.AchievementDate):
+					row.Errors = append(row.Errors, "verified_at tidak boleh sebelum date")
+				default:
+					row.VerificationDate = verifiedAt
+				}
+			}
+		case "draft", "submitted":
+			if row.VerifiedBy != "" || row.VerifiedAt != "" {
+				row.Errors = append(row.Errors, "verified_by dan verified_at hanya untuk status verified")
+			}
+		default:
+			row.Errors = append(row.Errors, fmt.Sprintf("status %q tidak valid, gunakan draft, submitted atau verified", row.Status))
+		}
+
+		for _, document := range row.Documents {
+			if bundle == nil {
+				row.Errors = append(row.Errors, "documents diisi tetapi file ZIP dokumen tidak diupload")
+				break
+			}
+			if !bundle.Has(document) {
+				row.Errors = append(row.Errors, fmt.Sprintf("dokumen %q tidak ada di file ZIP", document))
+			}
+		}
+	}
+}
+
+// ImportHistoricalAchievements memvalidasi lalu membuat achievement beserta reference
+// untuk setiap baris valid. Baris yang external key-nya sudah pernah diimport dilewati,
+// sehingga import yang gagal sebagian bisa dijalankan ulang dengan file yang sama.
+// Achievement berstatus verified langsung mendapat poin rubric dan verifikator.
+func (s *AchievementService) ImportHistoricalAchievements(ctx context.Context, rows []models.AchievementImportRow, bundle *utils.DocumentBundle, dryRun bool) (*models.AchievementImportReport, error) {
+	lookup, err := s.importRepo.Lookup(rows)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.ExternalKey != "" {
+			keys = append(keys, row.ExternalKey)
+		}
+	}
+	if lookup.ExistingKeys, err = s.achievementRepo.FindByExternalKeys(ctx, keys); err != nil {
+		return nil, err
+	}
+	taxonomy, err := s.taxonomyRepo.FindTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+	rubrics, err := s.rubricRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	ValidateAchievementImportRows(rows, lookup, taxonomy, bundle)
+
+	report := &models.AchievementImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Results:   []models.AchievementImportResult{},
+		Errors:    []models.AchievementImportRow{},
+	}
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			report.InvalidRows++
+			report.Errors = append(report.Errors, row)
+			continue
+		}
+		report.ValidRows++
+
+		result := models.AchievementImportResult{
+			Row:           row.Row,
+			ExternalKey:   row.ExternalKey,
+			AchievementID: row.ExistingAchievementID,
+		}
+		switch {
+		case row.ExistingAchievementID != "":
+			result.Result = AchievementImportSkipped
+			report.Skipped++
+		case dryRun:
+			result.Result = AchievementImportValid
+		default:
+			achievementID, err := s.createImportedAchievement(ctx, row, bundle, rubrics.Points(row.Category, row.Level))
+			if errors.Is(err, errAchievementImportExists) {
+				result.Result = AchievementImportSkipped
+				report.Skipped++
+				break
+			}
+			if err != nil {
+				row.Errors = append(row.Errors, err.Error())
+				report.Failed++
+				report.Errors = append(report.Errors, row)
+				continue
+			}
+			result.AchievementID = achievementID
+			result.Result = AchievementImportCreated
+			report.Created++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// createImportedAchievement menyimpan dokumen dari ZIP, achievement di MongoDB dan
+// reference di PostgreSQL. Jika salah satu gagal, data yang sudah tersimpan dihapus.
+func (s *AchievementService) createImportedAchievement(ctx context.Context, row models.AchievementImportRow, bundle *utils.DocumentBundle, points int) (string, error) {
+	achievementID := uuid.New().String()
+
+	// Step 1: Simpan dokumen dengan validasi, kuota dan scan yang sama seperti upload
+	var documents []models.Document
+	if len(row.Documents) > 0 {
+		studentUsage, err := s.achievementRepo.GetStorageUsageByStudentID(ctx, row.StudentUserID)
+		if err != nil {
+			return "", fmt.Errorf("gagal menghitung kuota penyimpanan: %v", err)
+		}
+		var achievementUsage int64
+		for _, name := range row.Documents {
+			data, err := bundle.Read(name, s.uploadConfig.MaxFileSize)
+			if err == nil {
+				err = utils.CheckStorageQuota(int64(len(data)), achievementUsage, studentUsage, s.uploadConfig)
+			}
+			var saved *utils.SavedFile
+			if err == nil {
+				saved, err = utils.SaveFileBytes(path.Base(utils.NormalizeBundlePath(name)), data, s.uploadConfig)
+			}
+			if err != nil {
+				deleteDocumentFiles(documents)
+				return "", fmt.Errorf("dokumen %s: %v", name, err)
+			}
+			achievementUsage += saved.Filesize
+			studentUsage += saved.Filesize
+			documents = append(documents, newDocument(saved, path.Base(utils.NormalizeBundlePath(name)), achievementID))
+		}
+	}
+
+	// Step 2: Simpan ke MongoDB
+	achievement := &models.Achievement{
+		AchievementID: achievementID,
+		StudentID:     row.StudentUserID,
+		Title:         row.Title,
+		Category:      row.Category,
+		Level:         row.Level,
+		Date:          row.AchievementDate,
+		Description:   row.Description,
+		Documents:     documents,
+		Details:       row.Details,
+		Status:        row.Status,
+		ExternalKey:   row.ExternalKey,
+	}
+	if row.Status == "verified" {
+		achievement.Points = points
+	}
+	if err := s.achievementRepo.Create(ctx, achievement); err != nil {
+		deleteDocumentFiles(documents)
+		if mongo.IsDuplicateKeyError(err) {
+			return "", errAchievementImportExists
+		}
+		return "", fmt.Errorf("gagal menyimpan achievement ke MongoDB: %v", err)
+	}
+
+	// Step 3: Simpan reference ke PostgreSQL
+	now := time.Now()
+	reference := &models.AchievementReferences{
+		ID:                 uuid.New(),
+		StudentID:          uuid.MustParse(row.StudentUserID),
+		MongoAchievementID: achievementID,
+		Status:             row.Status,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	switch row.Status {
+	case "submitted":
+		reference.SubmittedAt = &now
+	case "verified":
+		verifiedBy := uuid.MustParse(row.VerifierID)
+		reference.SubmittedAt = &row.VerificationDate
+		reference.VerifiedAt = &row.VerificationDate
+		reference.VerifiedBy = &verifiedBy
+	}
+	if err := s.referenceRepo.CreateImported(reference); err != nil {
+		s.achievementRepo.Delete(ctx, achievementID)
+		deleteDocumentFiles(documents)
+		return "", fmt.Errorf("gagal menyimpan reference ke PostgreSQL: %v", err)
+	}
+	refreshReadModel(s.projector, achievementID)
+
+	return achievementID, nil
+}
+
+// ImportAchievements godoc
+// @Summary Import historical achievements
+// @Description Admin imports historical achievements of existing students from a CSV or XLSX file (first sheet). Required columns: external_key (unique key from the source records), student_id (NIM), title, category, level (name, code or alias from the taxonomy), date (YYYY-MM-DD). Optional columns: description, details (JSON object validated against the category schema), status (draft, submitted or verified; default submitted), verified_by (username or NIP of a lecturer/admin, required for verified), verified_at (YYYY-MM-DD, default import time) and documents (paths inside the documents ZIP, separated by ";"). Rows whose external_key was already imported are skipped, so the same file can be imported again safely. Verified rows receive rubric points. With dry_run=true rows are only validated. For very large document bundles use the CLI: go run . import-achievements --file data.xlsx --documents dokumen.zip.
+// @Tags Achievements
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file (max 10MB, max 5000 rows)"
+// @Param documents formData file false "ZIP bundle with supporting documents referenced in the documents column"
+// @Param dry_run formData bool false "Only validate the file without creating achievements"
+// @Success 200 {object} object{status=string,message=string,data=models.AchievementImportReport} "Import or dry run report (per-row results and errors)"
+// @Failure 400 {object} map[string]interface{} "Missing or unreadable file, unsupported format, invalid header or invalid ZIP"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 500 {object} map[string]interface{} "Failed to validate or import achievements"
+// @Router /achievements/import [post]
+func (s *AchievementService) ImportAchievements(c *fiber.Ctx) error {
+	// Step 1: Baca file tabel
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "File import wajib diupload",
+		})
+	}
+	if fileHeader.Size > achievementImportMaxFileSize {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Ukuran file import maksimal 10MB",
+		})
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	if format != utils.ExportFormatCSV && format != utils.ExportFormatXLSX {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Format file harus csv atau xlsx",
+		})
+	}
+	dryRun := false
+	if value := c.FormValue("dry_run", c.Query("dry_run")); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": "dry_run harus berupa boolean",
+			})
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membaca file import",
+		})
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membaca file import",
+		})
+	}
+	table, err := utils.ReadTable(format, data)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+	rows, err := ParseAchievementImportTable(table)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	// Step 2: Buka ZIP dokumen (opsional)
+	var bundle *utils.DocumentBundle
+	if bundleHeader, err := c.FormFile("documents"); err == nil {
+		bundleFile, err := bundleHeader.Open()
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal membaca file ZIP dokumen",
+			})
+		}
+		defer bundleFile.Close()
+
+		if bundle, err = utils.OpenDocumentBundle(bundleFile, bundleHeader.Size); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	// Step 3: Validasi dan import
+	report, err := s.ImportHistoricalAchievements(context.Background(), rows, bundle, dryRun)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengimport achievement",
+		})
+	}
+
+	message := "Import achievement selesai"
+	if dryRun {
+		message = "Validasi import achievement selesai"
+	}
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": message,
+		"data":    report,
+	})
+}
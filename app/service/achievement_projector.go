@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"database/sql"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AchievementProjector menjaga read model achievement_views tetap sinkron dengan
+// data achievement (MongoDB), reference, student dan advisor (PostgreSQL),
+// sekaligus memperbarui counter statistik dari selisih versi lama dan baru.
+type AchievementProjector struct {
+	achievementRepo *repository.AchievementRepository
+	referenceRepo   *repository.AchievementReferenceRepository
+	studentRepo     *repository.StudentRepository
+	viewRepo        *repository.AchievementViewRepository
+	counterRepo     *repository.AchievementCounterRepository
+}
+
+func NewAchievementProjector(mongoDB *mongo.Database, postgresDB *sql.DB) *AchievementProjector {
+	return &AchievementProjector{
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+		referenceRepo:   repository.NewAchievementReferenceRepository(postgresDB),
+		studentRepo:     repository.NewStudentRepository(postgresDB),
+		viewRepo:        repository.NewAchievementViewRepository(mongoDB),
+		counterRepo:     repository.NewAchievementCounterRepository(mongoDB),
+	}
+}
+
+// Refresh memproyeksikan ulang satu achievement. Achievement yang sudah dihapus
+// akan dihapus juga dari read model.
+func (p *AchievementProjector) Refresh(ctx context.Context, achievementID string) error {
+	achievement, err := p.achievementRepo.FindByID(ctx, achievementID)
+	if err == mongo.ErrNoDocuments {
+		previous, err := p.viewRepo.Delete(ctx, achievementID)
+		if err != nil {
+			return err
+		}
+		return p.counterRepo.Apply(ctx, previous, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	student, err := p.studentRepo.FindDetailByUserID(achievement.StudentID)
+	if err != nil {
+		return err
+	}
+	return p.project(ctx, achievement, student)
+}
+
+// RefreshUser memproyeksikan ulang semua achievement yang memuat data user,
+// baik sebagai mahasiswa maupun sebagai dosen wali (misalnya setelah ganti nama)
+func (p *AchievementProjector) RefreshUser(ctx context.Context, userID string) error {
+	achievementIDs, err := p.viewRepo.FindAchievementIDsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, achievementID := range achievementIDs {
+		if err := p.Refresh(ctx, achievementID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshStudent memproyeksikan ulang semua achievement milik mahasiswa
+// (misalnya setelah profil atau dosen wali berubah)
+func (p *AchievementProjector) RefreshStudent(ctx context.Context, userID string) error {
+	achievements, err := p.achievementRepo.FindByStudentID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	student, err := p.studentRepo.FindDetailByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range achievements {
+		if err := p.project(ctx, &achievements[i], student); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rebuild membangun ulang seluruh read model dari data sumber
+func (p *AchievementProjector) Rebuild(ctx context.Context) (int, error) {
+	achievements, err := p.achievementRepo.FindAll(ctx, bson.M{"is_deleted": false})
+	if err != nil {
+		return 0, err
+	}
+
+	students := make(map[string]*models.StudentDetail)
+	achievementIDs := make([]string, 0, len(achievements))
+	for i := range achievements {
+		achievement := &achievements[i]
+		student, ok := students[achievement.StudentID]
+		if !ok {
+			student, err = p.studentRepo.FindDetailByUserID(achievement.StudentID)
+			if err != nil {
+				return 0, err
+			}
+			students[achievement.StudentID] = student
+		}
+
+		if err := p.project(ctx, achievement, student); err != nil {
+			return 0, err
+		}
+		achievementIDs = append(achievementIDs, achievement.AchievementID)
+	}
+
+	// Hapus read model achievement yang sudah dihapus
+	if _, err := p.viewRepo.DeleteExcept(ctx, achievementIDs); err != nil {
+		return 0, err
+	}
+
+	// Counter dihitung ulang dari read model yang sudah lengkap
+	if _, err := p.counterRepo.Rebuild(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(achievementIDs), nil
+}
+
+// RebuildStatistics menghitung ulang counter statistik dari read model
+func (p *AchievementProjector) RebuildStatistics(ctx context.Context) (int, error) {
+	return p.counterRepo.Rebuild(ctx)
+}
+
+// RebuildIfEmpty membangun read model dan counter statistik saat pertama kali dijalankan
+func (p *AchievementProjector) RebuildIfEmpty(ctx context.Context) error {
+	count, err := p.viewRepo.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		projected, err := p.Rebuild(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Read model achievement dibangun: %d achievement", projected)
+		return nil
+	}
+
+	counters, err := p.counterRepo.Count(ctx)
+	if err != nil || counters > 0 {
+		return err
+	}
+	rebuilt, err := p.RebuildStatistics(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Counter statistik achievement dibangun: %d counter", rebuilt)
+	return nil
+}
+
+func (p *AchievementProjector) project(ctx context.Context, achievement *models.Achievement, student *models.StudentDetail) error {
+	reference, err := p.referenceRepo.FindByMongoID(achievement.AchievementID)
+	if err != nil {
+		return err
+	}
+
+	view := models.NewAchievementView(achievement, reference, student)
+	previous, err := p.viewRepo.Upsert(ctx, view)
+	if err != nil {
+		return err
+	}
+	return p.counterRepo.Apply(ctx, previous, view)
+}
+
+// refreshReadModel memperbarui read model setelah write. Kegagalan hanya dicatat,
+// read model dan counter bisa diperbaiki dengan perintah rebuild-read-model.
+func refreshReadModel(projector *AchievementProjector, achievementID string) {
+	if err := projector.Refresh(context.Background(), achievementID); err != nil {
+		log.Printf("Gagal memperbarui read model achievement %s: %v", achievementID, err)
+	}
+}
+
+// refreshUserReadModel memperbarui read model setelah data user berubah
+func refreshUserReadModel(projector *AchievementProjector, userID string) {
+	if err := projector.RefreshUser(context.Background(), userID); err != nil {
+		log.Printf("Gagal memperbarui read model user %s: %v", userID, err)
+	}
+}
+
+// refreshStudentReadModel memperbarui read model setelah profil mahasiswa berubah
+func refreshStudentReadModel(projector *AchievementProjector, userID string) {
+	if err := projector.RefreshStudent(context.Background(), userID); err != nil {
+		log.Printf("Gagal memperbarui read model mahasiswa %s: %v", userID, err)
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AchievementDocumentMigration mengubah satu dokumen achievement mentah ke bentuk Version.
+// Migrate hanya dipanggil untuk dokumen dengan schema_version di bawah Version.
+type AchievementDocumentMigration struct {
+	Version     int
+	Description string
+	Migrate     func(document bson.M) error
+}
+
+// AchievementDocumentMigrations daftar migration dokumen achievement, urut versi. Versi
+// terakhir harus sama dengan models.AchievementSchemaVersion.
+var AchievementDocumentMigrations = []AchievementDocumentMigration{
+	{
+		Version:     1,
+		Description: "isi default field yang belum ada pada dokumen sebelum schema_version",
+		Migrate: func(document bson.M) error {
+			defaults := bson.M{"is_deleted": false, "points": int32(0), "description": "", "status": "draft"}
+			for field, value := range defaults {
+				if _, ok := document[field]; !ok {
+					document[field] = value
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "isi id dokumen lampiran yang diupload sebelum dokumen memiliki id",
+		Migrate: func(document bson.M) error {
+			documents, ok := document["documents"].(bson.A)
+			if !ok {
+				return nil
+			}
+			for i, item := range documents {
+				switch doc := item.(type) {
+				case bson.M:
+					if id, _ := doc["id"].(string); id == "" {
+						doc["id"] = uuid.New().String()
+					}
+				case primitive.D:
+					if id, _ := doc.Map()["id"].(string); id == "" {
+						documents[i] = append(doc, primitive.E{Key: "id", Value: uuid.New().String()})
+					}
+				default:
+					return fmt.Errorf("documents[%d] bukan dokumen", i)
+				}
+			}
+			return nil
+		},
+	},
+}
+
+// achievementMigrationBatchSize jumlah dokumen yang dimigrasi per query
+const achievementMigrationBatchSize = 500
+
+// AchievementSchemaService menyiapkan collection achievements: migration dokumen,
+// index dan validator $jsonSchema
+type AchievementSchemaService struct {
+	achievementRepo *repository.AchievementRepository
+}
+
+func NewAchievementSchemaService(mongoDB *mongo.Database) *AchievementSchemaService {
+	return &AchievementSchemaService{
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+	}
+}
+
+// Bootstrap memigrasi dokumen lama ke versi terbaru lalu memasang index dan validator.
+// Migration dijalankan lebih dulu agar dokumen lama lolos validator.
+func (s *AchievementSchemaService) Bootstrap(ctx context.Context) error {
+	migrated, err := s.MigrateDocuments(ctx)
+	if err != nil {
+		return fmt.Errorf("migration dokumen achievement gagal: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migration dokumen achievement: %d dokumen diperbarui ke schema_version %d", migrated, models.AchievementSchemaVersion)
+	}
+	if err := s.achievementRepo.EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("gagal membuat index achievements: %v", err)
+	}
+	if err := s.achievementRepo.EnsureValidator(ctx); err != nil {
+		return fmt.Errorf("gagal memasang validator achievements: %v", err)
+	}
+	return nil
+}
+
+// MigrateDocuments memigrasi semua dokumen dengan schema_version lama, per batch.
+// Mengembalikan jumlah dokumen yang diperbarui.
+func (s *AchievementSchemaService) MigrateDocuments(ctx context.Context) (int, error) {
+	migrated := 0
+	for {
+		documents, err := s.achievementRepo.FindBelowSchemaVersion(ctx, models.AchievementSchemaVersion, achievementMigrationBatchSize)
+		if err != nil {
+			return migrated, err
+		}
+		if len(documents) == 0 {
+			return migrated, nil
+		}
+
+		for _, document := range documents {
+			if err := MigrateAchievementDocument(document); err != nil {
+				return migrated, fmt.Errorf("achievement %v: %v", document["achievement_id"], err)
+			}
+			if err := s.achievementRepo.ReplaceRaw(ctx, document); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+}
+
+// MigrateAchievementDocument menjalankan semua migration yang belum diterapkan pada
+// satu dokumen mentah dan menyimpan versinya di schema_version
+func MigrateAchievementDocument(document bson.M) error {
+	version := documentSchemaVersion(document)
+	if version > models.AchievementSchemaVersion {
+		return fmt.Errorf("schema_version %d lebih baru dari versi aplikasi %d", version, models.AchievementSchemaVersion)
+	}
+
+	for _, migration := range AchievementDocumentMigrations {
+		if migration.Version <= version {
+			continue
+		}
+		if err := migration.Migrate(document); err != nil {
+			return fmt.Errorf("migration versi %d: %v", migration.Version, err)
+		}
+		version = migration.Version
+	}
+	document["schema_version"] = int32(version)
+	return nil
+}
+
+// documentSchemaVersion membaca schema_version dokumen mentah, 0 jika belum ada
+func documentSchemaVersion(document bson.M) int {
+	switch version := document["schema_version"].(type) {
+	case int32:
+		return int(version)
+	case int64:
+		return int(version)
+	case int:
+		return version
+	case float64:
+		return int(version)
+	}
+	return 0
+}
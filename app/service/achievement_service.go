@@ -1,12 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	models "crud-app/app/model"
 	"crud-app/app/repository"
 	"crud-app/app/utils"
+	"crud-app/config"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"mime/multipart"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -19,33 +29,66 @@ type AchievementService struct {
 	achievementRepo *repository.AchievementRepository
 	referenceRepo   *repository.AchievementReferenceRepository
 	studentRepo     *repository.StudentRepository
+	viewRepo        *repository.AchievementViewRepository
+	counterRepo     *repository.AchievementCounterRepository
+	rubricRepo      *repository.ScoringRubricRepository
+	taxonomyRepo    *repository.TaxonomyRepository
+	exportJobRepo   *repository.ExportJobRepository
+	importRepo      *repository.AchievementImportRepository
+	transcriptRepo  *repository.TranscriptRepository
+	projector       *AchievementProjector
 	uploadConfig    utils.FileUploadConfig
+	exportConfig    utils.ExportConfig
+
+	cursorSecret     []byte
+	transcriptSecret []byte
+	// cache leaderboard, nil jika dijalankan dari command line
+	cache          *utils.PermissionCache
+	leaderboardTTL time.Duration
 }
 
-func NewAchievementService(mongoDB *mongo.Database, postgresDB *sql.DB) *AchievementService {
+// NewAchievementService membuat service achievement. cache boleh nil (leaderboard tidak di-cache).
+func NewAchievementService(mongoDB *mongo.Database, postgresDB *sql.DB, cfg *config.Config, cache *utils.PermissionCache) *AchievementService {
 	return &AchievementService{
 		achievementRepo: repository.NewAchievementRepository(mongoDB),
 		referenceRepo:   repository.NewAchievementReferenceRepository(postgresDB),
 		studentRepo:     repository.NewStudentRepository(postgresDB),
-		uploadConfig:    utils.DefaultUploadConfig,
+		viewRepo:        repository.NewAchievementViewRepository(mongoDB),
+		counterRepo:     repository.NewAchievementCounterRepository(mongoDB),
+		rubricRepo:      repository.NewScoringRubricRepository(postgresDB),
+		taxonomyRepo:    repository.NewTaxonomyRepository(postgresDB),
+		exportJobRepo:   repository.NewExportJobRepository(mongoDB),
+		importRepo:      repository.NewAchievementImportRepository(postgresDB),
+		transcriptRepo:  repository.NewTranscriptRepository(mongoDB),
+		projector:       NewAchievementProjector(mongoDB, postgresDB),
+		uploadConfig:    FileUploadConfig(cfg.Upload),
+		exportConfig:    ExportJobConfig(cfg.Export),
+
+		cursorSecret:     []byte(cfg.Auth.CursorSecret),
+		transcriptSecret: []byte(cfg.Auth.TranscriptSecret),
+		cache:            cache,
+		leaderboardTTL:   cfg.Cache.LeaderboardTTL,
 	}
 }
 
 // SubmitAchievement godoc
 // @Summary Submit new achievement
-// @Description Student submits a new achievement with supporting documents. Uses hybrid database storage (MongoDB + PostgreSQL).
+// @Description Student submits a new achievement with supporting documents. Uses hybrid database storage (MongoDB + PostgreSQL). Category-specific structured fields are sent in details as a JSON object and validated against the JSON Schema of the category (or its nearest parent).
 // @Tags Achievements
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
 // @Param title formData string true "Achievement title"
-// @Param category formData string true "Achievement category (e.g., Academic Competition, Research, etc.)"
-// @Param level formData string true "Achievement level (Local/Regional/National/International)"
+// @Param category formData string true "Achievement category name, code or alias from GET /taxonomy/categories"
+// @Param level formData string true "Achievement level name, code or alias from GET /taxonomy/levels"
 // @Param date formData string true "Achievement date (YYYY-MM-DD format)"
 // @Param description formData string false "Detailed achievement description"
+// @Param details formData string false "Category-specific structured fields as a JSON object string, e.g. {\"rank\":1,\"organizer\":\"Kemendikbud\"}"
 // @Param documents formData file false "Supporting documents (certificates, photos, etc. - multiple files allowed)"
 // @Success 201 {object} object{status=string,message=string,data=models.Achievement} "Achievement created successfully with draft status"
-// @Failure 400 {object} map[string]interface{} "Invalid request, missing required fields, or file upload error"
+// @Failure 400 {object} map[string]interface{} "Invalid request, missing required fields, category or level not in taxonomy, details not matching the category schema (errors lists each violation), or file upload error (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED, FILE_CONTENT_MISMATCH)"
+// @Failure 413 {object} map[string]interface{} "Storage quota exceeded (code: ACHIEVEMENT_QUOTA_EXCEEDED, STUDENT_QUOTA_EXCEEDED)"
+// @Failure 422 {object} map[string]interface{} "Malware detected by antivirus scan (code: FILE_INFECTED)"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.create)"
 // @Failure 500 {object} map[string]interface{} "Internal server error - database or file system error"
@@ -77,6 +120,27 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := parseFormDetails(c, &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	// Kategori dan level harus terdaftar di taxonomy, disimpan dengan nama bakunya
+	taxonomy, status, message := s.resolveTaxonomy(&req)
+	if status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	// Details divalidasi dengan JSON Schema kategori
+	if err := validateDetails(taxonomy, req.Category, req.Details); err != nil {
+		return detailsErrorResponse(c, err)
+	}
+
 	// Parse date
 	achievementDate, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
@@ -86,36 +150,20 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 		})
 	}
 
+	// Generate achievement ID
+	achievementID := uuid.New().String()
+
 	// Step 3: Handle file upload (dokumen pendukung)
 	form, err := c.MultipartForm()
 	var documents []models.Document
 
 	if err == nil && form != nil {
-		files := form.File["documents"]
-		for _, file := range files {
-			// Save file
-			filepath, err := utils.SaveUploadedFile(file, s.uploadConfig)
-			if err != nil {
-				return c.Status(400).JSON(fiber.Map{
-					"status":  "error",
-					"message": fmt.Sprintf("Gagal upload file: %v", err),
-				})
-			}
-
-			// Add to documents
-			documents = append(documents, models.Document{
-				Filename:   file.Filename,
-				Filepath:   filepath,
-				Filesize:   file.Size,
-				Mimetype:   file.Header.Get("Content-Type"),
-				UploadedAt: time.Now(),
-			})
+		documents, err = s.saveDocuments(context.Background(), form.File["documents"], achievementID, userID, nil)
+		if err != nil {
+			return uploadErrorResponse(c, err)
 		}
 	}
 
-	// Generate achievement ID
-	achievementID := uuid.New().String()
-
 	// Step 4: Simpan ke MongoDB (full document)
 	achievement := &models.Achievement{
 		AchievementID: achievementID,
@@ -126,6 +174,7 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 		Date:          achievementDate,
 		Description:   req.Description,
 		Documents:     documents,
+		Details:       req.Details,
 		Status:        "draft", // Status awal: draft
 		IsDeleted:     false,
 		DeletedAt:     nil,
@@ -136,9 +185,7 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 	ctx := context.Background()
 	if err := s.achievementRepo.Create(ctx, achievement); err != nil {
 		// Rollback: hapus uploaded files
-		for _, doc := range documents {
-			utils.DeleteFile(doc.Filepath)
-		}
+		deleteDocumentFiles(documents)
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
 			"message": "Gagal menyimpan achievement ke MongoDB",
@@ -158,14 +205,13 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 	if err := s.referenceRepo.Create(reference); err != nil {
 		// Rollback: hapus dari MongoDB dan files
 		s.achievementRepo.Delete(ctx, achievementID)
-		for _, doc := range documents {
-			utils.DeleteFile(doc.Filepath)
-		}
+		deleteDocumentFiles(documents)
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
 			"message": "Gagal menyimpan reference ke PostgreSQL",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	// Step 6: Return achievement data
 	response := models.AchievementResponse{
@@ -181,6 +227,7 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 		Status:        achievement.Status,
 		CreatedAt:     achievement.CreatedAt,
 		UpdatedAt:     achievement.UpdatedAt,
+		Details:       achievement.Details,
 	}
 
 	return c.Status(201).JSON(fiber.Map{
@@ -190,6 +237,101 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 	})
 }
 
+// resolveTaxonomy memvalidasi kategori dan level request terhadap taxonomy lalu
+// menggantinya dengan nama baku. Field kosong dilewati (update sebagian).
+// Mengembalikan taxonomy untuk validasi details, atau status HTTP dan pesan jika tidak valid.
+func (s *AchievementService) resolveTaxonomy(req *models.SubmitAchievementRequest) (*models.Taxonomy, int, string) {
+	taxonomy, err := s.taxonomyRepo.FindTaxonomy()
+	if err != nil {
+		return nil, 500, "Gagal memeriksa kategori dan level achievement"
+	}
+
+	if req.Category != "" {
+		category, ok := taxonomy.ResolveCategory(req.Category)
+		if !ok {
+			return nil, 400, "Kategori tidak terdaftar. Lihat GET /taxonomy/categories"
+		}
+		req.Category = category.Name
+	}
+	if req.Level != "" {
+		level, ok := taxonomy.ResolveLevel(req.Level)
+		if !ok {
+			return nil, 400, "Level tidak terdaftar. Lihat GET /taxonomy/levels"
+		}
+		req.Level = level.Name
+	}
+	return taxonomy, 0, ""
+}
+
+// validateDetails memvalidasi details terhadap JSON Schema kategori atau induk
+// terdekatnya. Kategori tanpa schema menerima details apa adanya.
+func validateDetails(taxonomy *models.Taxonomy, category string, details map[string]interface{}) error {
+	entry, ok := taxonomy.ResolveCategory(category)
+	if !ok {
+		return nil
+	}
+	raw := taxonomy.DetailsSchema(entry)
+	if raw == nil {
+		return nil
+	}
+
+	schema, err := utils.CompileDetailsSchema(raw)
+	if err != nil {
+		return err
+	}
+	return utils.ValidateDetails(schema, details)
+}
+
+// detailsErrorResponse mengembalikan daftar pelanggaran schema details
+func detailsErrorResponse(c *fiber.Ctx, err error) error {
+	var validationErr *utils.DetailsValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "details tidak sesuai schema kategori",
+			"errors":  validationErr.Errors,
+		})
+	}
+	log.Printf("Gagal memvalidasi details achievement: %v", err)
+	return c.Status(500).JSON(fiber.Map{
+		"status":  "error",
+		"message": "Gagal memvalidasi details achievement",
+	})
+}
+
+// parseFormDetails membaca details dari form berupa string JSON object. Request JSON
+// sudah diisi langsung oleh BodyParser; pada form, map kosong dari BodyParser dibuang
+// agar details yang tidak dikirim tidak dianggap sebagai details kosong.
+func parseFormDetails(c *fiber.Ctx, req *models.SubmitAchievementRequest) error {
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return nil
+	}
+	req.Details = nil
+	value := strings.TrimSpace(c.FormValue("details"))
+	if value == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), &req.Details); err != nil {
+		return fmt.Errorf("details harus berupa JSON object")
+	}
+	return nil
+}
+
+// plainDetails mengubah details hasil decode MongoDB (primitive.A, int32, ...) menjadi
+// tipe JSON biasa agar bisa divalidasi ulang dengan JSON Schema
+func plainDetails(details map[string]interface{}) (map[string]interface{}, error) {
+	if details == nil {
+		return nil, nil
+	}
+	raw, err := bson.MarshalExtJSON(details, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var plain map[string]interface{}
+	err = json.Unmarshal(raw, &plain)
+	return plain, err
+}
+
 // GetMyAchievements godoc
 // @Summary Get my achievements
 // @Description Get all achievements belonging to the authenticated user (all statuses included).
@@ -197,7 +339,7 @@ func (s *AchievementService) SubmitAchievement(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} object{status=string,message=string,data=[]models.Achievement} "Achievements retrieved successfully"
+// @Success 200 {object} object{status=string,message=string,data=[]models.AchievementView} "Achievements retrieved successfully"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve achievements from database"
@@ -212,7 +354,7 @@ func (s *AchievementService) GetMyAchievements(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
-	achievements, err := s.achievementRepo.FindByStudentID(ctx, userID)
+	achievements, err := s.viewRepo.FindByStudentID(ctx, userID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -272,7 +414,7 @@ func (s *AchievementService) GetAchievementByID(c *fiber.Ctx) error {
 
 // UpdateAchievement godoc
 // @Summary Update achievement
-// @Description Update achievement information (only if status is draft). Validates ownership and status before updating.
+// @Description Update achievement information (only if status is draft). Validates ownership and status before updating. details replaces the structured fields and is validated against the category JSON Schema; when only the category changes, the existing details are validated against the new category.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -280,7 +422,7 @@ func (s *AchievementService) GetAchievementByID(c *fiber.Ctx) error {
 // @Param id path string true "Achievement ID"
 // @Param request body models.SubmitAchievementRequest true "Achievement update request"
 // @Success 200 {object} object{status=string,message=string,data=models.Achievement} "Achievement updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request or achievement cannot be updated (not draft status)"
+// @Failure 400 {object} map[string]interface{} "Invalid request, category or level not in taxonomy, details not matching the category schema (errors lists each violation), or achievement cannot be updated (not draft status)"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Access denied - not owner or insufficient permissions"
 // @Failure 404 {object} map[string]interface{} "Achievement not found"
@@ -326,6 +468,42 @@ func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := parseFormDetails(c, &req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	taxonomy, status, message := s.resolveTaxonomy(&req)
+	if status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	// Details divalidasi ulang jika details atau kategori berubah
+	if req.Details != nil || req.Category != "" {
+		category := existing.Category
+		if req.Category != "" {
+			category = req.Category
+		}
+		details := req.Details
+		if details == nil {
+			if details, err = plainDetails(existing.Details); err != nil {
+				return c.Status(500).JSON(fiber.Map{
+					"status":  "error",
+					"message": "Gagal membaca details achievement",
+				})
+			}
+		}
+		if err := validateDetails(taxonomy, category, details); err != nil {
+			return detailsErrorResponse(c, err)
+		}
+		existing.Details = details
+	}
+
 	// Update fields
 	if req.Title != "" {
 		existing.Title = req.Title
@@ -353,6 +531,7 @@ func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
 			"message": "Gagal mengupdate achievement",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -363,7 +542,7 @@ func (s *AchievementService) UpdateAchievement(c *fiber.Ctx) error {
 
 // DeleteAchievement godoc
 // @Summary Delete achievement
-// @Description Soft delete an achievement (only if status is draft). Validates ownership and status before deletion.
+// @Description Soft delete an achievement (only if status is draft). Validates ownership and status before deletion. Attached files are purged by the file garbage collector after the retention period.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -430,6 +609,7 @@ func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
 			"message": "Gagal menghapus reference di PostgreSQL",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	// Step 4: Return success message
 	return c.Status(200).JSON(fiber.Map{
@@ -440,14 +620,27 @@ func (s *AchievementService) DeleteAchievement(c *fiber.Ctx) error {
 
 // GetAdviseeAchievements godoc
 // @Summary Get advisee achievements
-// @Description Lecturer gets paginated list of achievements from their advisees (students under supervision).
+// @Description Lecturer gets paginated list of achievements from their advisees (students under supervision). Supports the same filters and sorting as the all achievements list, in page mode (page/limit) or cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta; a cursor is only valid for the sort_by and sort_order it was issued with. Structured details can be filtered with details.<field>=value (e.g. details.rank=1), matched as case-insensitive text, number or boolean.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
-// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.Achievement,pagination=models.PaginationMeta}} "Advisee achievements retrieved successfully"
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param category query string false "Filter by category (case-insensitive)"
+// @Param level query string false "Filter by level (case-insensitive)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Param student_id query string false "Filter by student (user) ID"
+// @Param program_study query string false "Filter by student program study"
+// @Param academic_year query string false "Filter by student academic year"
+// @Param sort_by query string false "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at" default(created_at)
+// @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.AchievementView,pagination=models.PaginationMeta,filters=models.AchievementFilter}} "Advisee achievements retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter parameters or cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires lecturer access)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve achievements"
@@ -472,6 +665,22 @@ func (s *AchievementService) GetAdviseeAchievements(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
+	filter, err := ParseAchievementFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, filter.SortBy, filter.SortOrder)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid untuk sorting ini",
+		})
+	}
+
 	ctx := context.Background()
 
 	// Step 1: Get list student IDs dari tabel students where advisor_id
@@ -483,61 +692,54 @@ func (s *AchievementService) GetAdviseeAchievements(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check if advisor has students
-	if len(studentIDs) == 0 {
+	if useCursor {
+		// Cakupan kosong menghasilkan halaman kosong tanpa query
+		if studentIDs == nil {
+			studentIDs = []string{}
+		}
+		achievements, pagination, err := s.findAchievementsWithCursor(ctx, filter, studentIDs, cursor, limit)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data prestasi mahasiswa bimbingan",
+			})
+		}
+
 		return c.Status(200).JSON(fiber.Map{
 			"status":  "success",
-			"message": "Tidak ada mahasiswa bimbingan",
+			"message": "Data prestasi mahasiswa bimbingan berhasil diambil",
 			"data": fiber.Map{
-				"achievements": []models.Achievement{},
-				"pagination": models.PaginationMeta{
-					Page:       page,
-					Limit:      limit,
-					TotalItems: 0,
-					TotalPages: 0,
-				},
+				"achievements": achievements,
+				"pagination":   pagination,
+				"filters":      filter,
 			},
 		})
 	}
 
-	// Step 2: Get achievements references dengan filter student_ids
-	references, total, err := s.referenceRepo.FindByStudentIDs(studentIDs, limit, offset)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"status":  "error",
-			"message": "Gagal mengambil data achievement references",
-		})
-	}
-
-	// Check if no achievements found
-	if len(references) == 0 {
+	// Check if advisor has students
+	if len(studentIDs) == 0 {
 		return c.Status(200).JSON(fiber.Map{
 			"status":  "success",
-			"message": "Tidak ada prestasi mahasiswa bimbingan",
+			"message": "Tidak ada mahasiswa bimbingan",
 			"data": fiber.Map{
-				"achievements": []models.Achievement{},
+				"achievements": []models.AchievementView{},
 				"pagination": models.PaginationMeta{
 					Page:       page,
 					Limit:      limit,
-					TotalItems: total,
+					TotalItems: 0,
 					TotalPages: 0,
 				},
+				"filters": filter,
 			},
 		})
 	}
 
-	// Step 3: Extract achievement IDs untuk fetch dari MongoDB
-	achievementIDs := make([]string, len(references))
-	for i, ref := range references {
-		achievementIDs[i] = ref.MongoAchievementID
-	}
-
-	// Step 4: Fetch detail dari MongoDB
-	achievements, err := s.achievementRepo.FindByAchievementIDs(ctx, achievementIDs)
+	// Step 2: Filter, sort dan paginate achievements mahasiswa bimbingan
+	achievements, total, err := s.findAchievementsWithFilter(ctx, filter, studentIDs, limit, offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
-			"message": "Gagal mengambil detail achievements dari MongoDB",
+			"message": "Gagal mengambil data prestasi mahasiswa bimbingan",
 		})
 	}
 
@@ -547,7 +749,7 @@ func (s *AchievementService) GetAdviseeAchievements(c *fiber.Ctx) error {
 		totalPages++
 	}
 
-	// Step 5: Return list dengan pagination
+	// Step 3: Return list dengan pagination
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Data prestasi mahasiswa bimbingan berhasil diambil",
@@ -559,20 +761,21 @@ func (s *AchievementService) GetAdviseeAchievements(c *fiber.Ctx) error {
 				TotalItems: total,
 				TotalPages: totalPages,
 			},
+			"filters": filter,
 		},
 	})
 }
 
 // SubmitForVerification godoc
 // @Summary Submit achievement for verification
-// @Description Submit a draft achievement for verification by lecturer. Changes status from 'draft' to 'submitted'.
+// @Description Submit a draft achievement for verification by lecturer. Changes status from 'draft' to 'submitted'. Documents whose SHA-256 hash matches a document on another achievement are flagged as possible duplicates (the submission is not blocked).
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Achievement ID"
-// @Success 200 {object} object{status=string,message=string,data=object{achievement_id=string,status=string,updated_at=string}} "Achievement submitted successfully"
-// @Failure 400 {object} map[string]interface{} "Achievement cannot be submitted (not draft status)"
+// @Success 200 {object} object{status=string,message=string,data=object{achievement_id=string,status=string,updated_at=string,duplicate_flags=[]models.DuplicateFlag}} "Achievement submitted successfully"
+// @Failure 400 {object} map[string]interface{} "Achievement cannot be submitted (not draft status or documents not cleared by antivirus scan)"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Access denied - not owner or insufficient permissions"
 // @Failure 404 {object} map[string]interface{} "Achievement not found"
@@ -615,7 +818,33 @@ func (s *AchievementService) SubmitForVerification(c *fiber.Ctx) error {
 		})
 	}
 
-	// Step 2: Update status menjadi 'submitted' di MongoDB
+	// Precondition: Semua dokumen harus sudah lolos scan antivirus
+	for _, doc := range achievement.Documents {
+		if doc.ScanStatus == utils.ScanStatusPending || doc.ScanStatus == utils.ScanStatusInfected {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": fmt.Sprintf("Dokumen %s belum lolos scan antivirus (status: %s)", doc.Filename, doc.ScanStatus),
+			})
+		}
+	}
+
+	// Step 2: Cek dokumen duplikat di achievement lain. Duplikat tidak memblokir
+	// submit, tetapi ditandai agar dosen wali bisa memeriksanya saat review.
+	duplicateFlags, err := s.findDuplicateDocuments(ctx, achievement)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal memeriksa dokumen duplikat",
+		})
+	}
+	if err := s.achievementRepo.UpdateDuplicateFlags(ctx, achievementID, duplicateFlags); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menyimpan hasil pengecekan duplikat",
+		})
+	}
+
+	// Step 3: Update status menjadi 'submitted' di MongoDB
 	if err := s.achievementRepo.UpdateStatus(ctx, achievementID, "submitted"); err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -623,7 +852,7 @@ func (s *AchievementService) SubmitForVerification(c *fiber.Ctx) error {
 		})
 	}
 
-	// Step 3: Update status dan submitted_at di PostgreSQL
+	// Step 4: Update status dan submitted_at di PostgreSQL
 	if err := s.referenceRepo.UpdateSubmittedStatus(achievementID); err != nil {
 		// Rollback MongoDB
 		s.achievementRepo.UpdateStatus(ctx, achievementID, "draft")
@@ -632,8 +861,9 @@ func (s *AchievementService) SubmitForVerification(c *fiber.Ctx) error {
 			"message": "Gagal mengupdate status di PostgreSQL",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
-	// Step 4: Return updated status
+	// Step 5: Return updated status
 	achievement.Status = "submitted"
 	achievement.UpdatedAt = time.Now()
 
@@ -641,23 +871,27 @@ func (s *AchievementService) SubmitForVerification(c *fiber.Ctx) error {
 		"status":  "success",
 		"message": "Prestasi berhasil disubmit untuk verifikasi",
 		"data": fiber.Map{
-			"achievement_id": achievement.AchievementID,
-			"status":         achievement.Status,
-			"updated_at":     achievement.UpdatedAt,
+			"achievement_id":  achievement.AchievementID,
+			"status":          achievement.Status,
+			"updated_at":      achievement.UpdatedAt,
+			"duplicate_flags": duplicateFlags,
 		},
 	})
 }
 
 // GetPendingVerification godoc
 // @Summary Get pending verification achievements
-// @Description Get paginated list of achievements that are pending verification (submitted status).
+// @Description Get paginated list of achievements that are pending verification (submitted status), longest waiting first. Supports page mode (page/limit) and cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
-// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.Achievement,pagination=object}} "Pending achievements retrieved successfully"
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
+// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.AchievementView,pagination=object}} "Pending achievements retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or tampered cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.verify)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve pending achievements"
@@ -666,26 +900,51 @@ func (s *AchievementService) GetPendingVerification(c *fiber.Ctx) error {
 	// Get pagination params
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 10)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
 	offset := (page - 1) * limit
 
-	// Get pending achievements dari PostgreSQL
-	references, total, err := s.referenceRepo.FindPendingVerification(limit, offset)
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "submitted_at", "asc")
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
+		return c.Status(400).JSON(fiber.Map{
 			"status":  "error",
-			"message": "Gagal mengambil data pending verification",
+			"message": "Cursor tidak valid",
 		})
 	}
 
-	// Get full data dari MongoDB
+	// Get pending achievements dari read model, diurutkan dari yang paling lama menunggu
 	ctx := context.Background()
-	var achievements []models.Achievement
-
-	for _, ref := range references {
-		achievement, err := s.achievementRepo.FindByID(ctx, ref.MongoAchievementID)
-		if err == nil && achievement != nil {
-			achievements = append(achievements, *achievement)
+	filter := bson.M{"status": "submitted", "is_deleted": false}
+	if useCursor {
+		achievements, err := s.viewRepo.FindWithCursor(ctx, filter, "submitted_at", true, cursor, limit)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data pending verification",
+			})
 		}
+
+		achievements, pagination := utils.CursorPage(achievements, limit, cursor, pendingCursor, s.cursorSecret)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Data pending verification berhasil diambil",
+			"data": fiber.Map{
+				"achievements": achievements,
+				"pagination":   pagination,
+			},
+		})
+	}
+
+	achievements, total, err := s.viewRepo.FindWithFilter(ctx, filter, "submitted_at", true, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data pending verification",
+		})
 	}
 
 	return c.Status(200).JSON(fiber.Map{
@@ -705,20 +964,21 @@ func (s *AchievementService) GetPendingVerification(c *fiber.Ctx) error {
 
 // ReviewAchievementDetail godoc
 // @Summary Review achievement detail
-// @Description Lecturer reviews detailed information of an achievement for verification process.
+// @Description Lecturer reviews detailed information of an achievement for verification process, including the antivirus scan status of each document and a warning when a document was already uploaded on another achievement. Lecturers can open their advisees' achievements and achievements sharing a document with one of them, so duplicate links stay reachable.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Achievement ID"
-// @Success 200 {object} object{status=string,message=string,data=object{achievement=models.Achievement,reference=object}} "Achievement details retrieved successfully"
+// @Success 200 {object} object{status=string,message=string,data=object{achievement=models.Achievement,reference=object,scan_summary=object,duplicate_warning=object{message=string,matches=[]models.DuplicateFlag}}} "Achievement details retrieved successfully"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
-// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.verify)"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.verify), or lecturer is not the student's advisor and no advisee has an identical document"
 // @Failure 404 {object} map[string]interface{} "Achievement not found"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve achievement details"
 // @Router /achievements/{id}/review [get]
 func (s *AchievementService) ReviewAchievementDetail(c *fiber.Ctx) error {
 	achievementID := c.Params("id")
+	userID, _ := c.Locals("user_id").(string)
 
 	ctx := context.Background()
 	achievement, err := s.achievementRepo.FindByID(ctx, achievementID)
@@ -729,6 +989,25 @@ func (s *AchievementService) ReviewAchievementDetail(c *fiber.Ctx) error {
 		})
 	}
 
+	// Dosen hanya bisa mereview achievement mahasiswa bimbingannya, atau achievement
+	// lain yang dokumennya identik dengan dokumen mahasiswa bimbingan (link duplikat)
+	roleID, _ := c.Locals("role_id").(string)
+	if roleID == "2" {
+		allowed, err := s.canLecturerReview(ctx, userID, achievement)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data mahasiswa bimbingan",
+			})
+		}
+		if !allowed {
+			return c.Status(403).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Anda hanya dapat mereview achievement mahasiswa bimbingan",
+			})
+		}
+	}
+
 	// Get reference data untuk info tambahan
 	reference, err := s.referenceRepo.FindByMongoID(achievementID)
 	if err != nil {
@@ -738,19 +1017,52 @@ func (s *AchievementService) ReviewAchievementDetail(c *fiber.Ctx) error {
 		})
 	}
 
+	// Ringkasan scan antivirus dokumen untuk reviewer
+	scanSummary := fiber.Map{}
+	for _, doc := range achievement.Documents {
+		status := doc.ScanStatus
+		if status == "" {
+			status = utils.ScanStatusSkipped
+		}
+		if count, ok := scanSummary[status].(int); ok {
+			scanSummary[status] = count + 1
+		} else {
+			scanSummary[status] = 1
+		}
+	}
+
+	// Cek ulang duplikat karena achievement lain bisa saja diupload setelah submit
+	duplicateFlags, err := s.findDuplicateDocuments(ctx, achievement)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal memeriksa dokumen duplikat",
+		})
+	}
+
+	var duplicateWarning fiber.Map
+	if len(duplicateFlags) > 0 {
+		duplicateWarning = fiber.Map{
+			"message": fmt.Sprintf("%d dokumen identik dengan dokumen pada achievement lain", len(duplicateFlags)),
+			"matches": duplicateFlags,
+		}
+	}
+
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Data achievement berhasil diambil",
 		"data": fiber.Map{
-			"achievement": achievement,
-			"reference":   reference,
+			"achievement":       achievement,
+			"reference":         reference,
+			"scan_summary":      scanSummary,
+			"duplicate_warning": duplicateWarning,
 		},
 	})
 }
 
 // ApproveAchievement godoc
 // @Summary Approve achievement
-// @Description Lecturer approves a submitted achievement. Changes status from 'submitted' to 'verified'.
+// @Description Lecturer approves a submitted achievement. Changes status from 'submitted' to 'verified' and awards points from the scoring rubric for its category and level.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -786,8 +1098,17 @@ func (s *AchievementService) ApproveAchievement(c *fiber.Ctx) error {
 		})
 	}
 
-	// Update status di MongoDB
-	if err := s.achievementRepo.UpdateStatus(ctx, achievementID, "verified"); err != nil {
+	// Hitung poin dari rubric penilaian
+	rubrics, err := s.rubricRepo.FindAll()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil rubric penilaian",
+		})
+	}
+	// Update status dan poin di MongoDB sekaligus
+	points := rubrics.Points(existing.Category, existing.Level)
+	if err := s.achievementRepo.UpdateStatusAndPoints(ctx, achievementID, "verified", points); err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
 			"message": "Gagal mengupdate status di MongoDB",
@@ -796,13 +1117,14 @@ func (s *AchievementService) ApproveAchievement(c *fiber.Ctx) error {
 
 	// Update verification di PostgreSQL
 	if err := s.referenceRepo.UpdateVerification(achievementID, userID, "verified"); err != nil {
-		// Rollback MongoDB
-		s.achievementRepo.UpdateStatus(ctx, achievementID, "submitted")
+		// Rollback MongoDB, termasuk poin
+		s.achievementRepo.UpdateStatusAndPoints(ctx, achievementID, "submitted", existing.Points)
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
 			"message": "Gagal mengupdate verification di PostgreSQL",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	// Get updated data
 	updated, _ := s.achievementRepo.FindByID(ctx, achievementID)
@@ -892,6 +1214,7 @@ func (s *AchievementService) RejectAchievement(c *fiber.Ctx) error {
 			"message": "Gagal mengupdate rejection di PostgreSQL",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	// Get updated data
 	updated, _ := s.achievementRepo.FindByID(ctx, achievementID)
@@ -908,32 +1231,40 @@ func (s *AchievementService) RejectAchievement(c *fiber.Ctx) error {
 }
 
 // GetAllAchievements godoc
-// @Summary Get all achievements (Admin)
-// @Description Admin gets paginated list of all achievements with filtering and sorting options.
+// @Summary Get all achievements
+// @Description Paginated list of achievements with combined filtering and sorting. Filters on achievement fields (category, level, date, status) and student fields (program study, academic year, advisor) are applied together so totals and ordering stay correct. Admin sees all achievements, lecturers see their advisees and students see their own. Supports page mode (page/limit) and cursor mode (cursor/limit) which returns pagination as models.CursorPaginationMeta; a cursor is only valid for the sort_by and sort_order it was issued with. Structured details can be filtered with details.<field>=value (e.g. details.rank=1), matched as case-insensitive text, number or boolean.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
 // @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
-// @Param student_id query string false "Filter by student ID"
-// @Param sort_by query string false "Sort by field (default: created_at)" default(created_at)
+// @Param category query string false "Filter by category (case-insensitive)"
+// @Param level query string false "Filter by level (case-insensitive)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Param student_id query string false "Filter by student (user) ID"
+// @Param program_study query string false "Filter by student program study"
+// @Param academic_year query string false "Filter by student academic year"
+// @Param advisor_id query string false "Filter by advisor (lecturer user) ID"
+// @Param sort_by query string false "Sort by field: created_at, updated_at, date, title, category, level, status, program_study, academic_year, advisor, submitted_at, verified_at" default(created_at)
 // @Param sort_order query string false "Sort order (asc, desc)" default(desc)
-// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.Achievement,pagination=object,filters=object}} "All achievements retrieved successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid filter parameters"
+// @Success 200 {object} object{status=string,message=string,data=object{achievements=[]models.AchievementView,pagination=object,filters=models.AchievementFilter}} "All achievements retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter parameters or cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
-// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires admin access)"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve achievements"
 // @Router /achievements/all [get]
 func (s *AchievementService) GetAllAchievements(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	roleID, _ := c.Locals("role_id").(string)
+
 	// Parse query parameters
 	page := c.QueryInt("page", 1)
 	limit := c.QueryInt("limit", 10)
-	statusFilter := c.Query("status", "")
-	studentIDFilter := c.Query("student_id", "")
-	sortBy := c.Query("sort_by", "created_at")
-	sortOrder := c.Query("sort_order", "desc")
 
 	// Validation
 	if page < 1 {
@@ -944,68 +1275,58 @@ func (s *AchievementService) GetAllAchievements(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
-	// Validate status filter
-	if statusFilter != "" {
-		validStatuses := map[string]bool{
-			"draft":     true,
-			"submitted": true,
-			"verified":  true,
-			"rejected":  true,
-		}
-		if !validStatuses[statusFilter] {
-			return c.Status(400).JSON(fiber.Map{
-				"status":  "error",
-				"message": "Invalid status filter. Valid values: draft, submitted, verified, rejected",
-			})
-		}
+	filter, err := ParseAchievementFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
 	}
 
-	// Step 1: Get achievement references dari PostgreSQL dengan filter
-	references, total, err := s.referenceRepo.FindAllWithFilters(
-		limit,
-		offset,
-		statusFilter,
-		studentIDFilter,
-		sortBy,
-		sortOrder,
-	)
+	// Step 1: Tentukan cakupan data sesuai role
+	scope, err := s.achievementScope(userID, roleID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
-			"message": "Gagal mengambil data achievement references",
+			"message": "Gagal mengambil data mahasiswa bimbingan",
+		})
+	}
+
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, filter.SortBy, filter.SortOrder)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid untuk sorting ini",
 		})
 	}
 
-	// Check if no data
-	if len(references) == 0 {
+	// Step 2: Filter, sort dan paginate di read model
+	ctx := context.Background()
+	if useCursor {
+		achievements, pagination, err := s.findAchievementsWithCursor(ctx, filter, scope, cursor, limit)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data achievements",
+			})
+		}
+
 		return c.Status(200).JSON(fiber.Map{
 			"status":  "success",
 			"message": "Data achievements berhasil diambil",
 			"data": fiber.Map{
-				"achievements": []models.Achievement{},
-				"pagination": fiber.Map{
-					"page":        page,
-					"limit":       limit,
-					"total_items": total,
-					"total_pages": 0,
-				},
+				"achievements": achievements,
+				"pagination":   pagination,
+				"filters":      filter,
 			},
 		})
 	}
 
-	// Step 2: Extract achievement IDs untuk fetch dari MongoDB
-	achievementIDs := make([]string, len(references))
-	for i, ref := range references {
-		achievementIDs[i] = ref.MongoAchievementID
-	}
-
-	// Step 3: Fetch details dari MongoDB
-	ctx := context.Background()
-	achievements, err := s.achievementRepo.FindByAchievementIDs(ctx, achievementIDs)
+	achievements, total, err := s.findAchievementsWithFilter(ctx, filter, scope, limit, offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
-			"message": "Gagal mengambil detail achievements dari MongoDB",
+			"message": "Gagal mengambil data achievements",
 		})
 	}
 
@@ -1015,7 +1336,7 @@ func (s *AchievementService) GetAllAchievements(c *fiber.Ctx) error {
 		totalPages++
 	}
 
-	// Step 4: Return dengan pagination
+	// Step 3: Return dengan pagination
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Data achievements berhasil diambil",
@@ -1027,29 +1348,31 @@ func (s *AchievementService) GetAllAchievements(c *fiber.Ctx) error {
 				"total_items": total,
 				"total_pages": totalPages,
 			},
-			"filters": fiber.Map{
-				"status":     statusFilter,
-				"student_id": studentIDFilter,
-				"sort_by":    sortBy,
-				"sort_order": sortOrder,
-			},
+			"filters": filter,
 		},
 	})
 }
 
-// GetMyStatistics godoc
-// @Summary Get my achievement statistics
-// @Description Get comprehensive achievement statistics for the authenticated student including summary, category breakdown, level distribution, and period analysis.
-// @Tags Statistics & Reports
+// SearchAchievements godoc
+// @Summary Search achievements
+// @Description Full-text search on achievement title and description, ranked by relevance (title matches weigh more), served from the achievement read model so results include student and advisor fields. Matching words are wrapped in <mark> tags in the highlights. Admin searches all achievements, lecturers search their advisees' achievements and students search their own. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by relevance) which returns pagination as models.CursorPaginationMeta.
+// @Tags Achievements
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} object{status=string,message=string,data=object} "Statistics retrieved successfully"
+// @Param q query string true "Search keywords (prefix a word with - to exclude it, wrap a phrase in quotes)"
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param page query int false "Page number (default: 1)" default(1)
+// @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
+// @Success 200 {object} object{status=string,message=string,data=object{results=[]models.AchievementSearchResult,pagination=models.PaginationMeta,query=string}} "Search results retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Missing search query, invalid status filter or cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
-// @Failure 500 {object} map[string]interface{} "Failed to retrieve statistics from database"
-// @Router /reports/statistics [get]
-func (s *AchievementService) GetMyStatistics(c *fiber.Ctx) error {
+// @Failure 500 {object} map[string]interface{} "Search failed"
+// @Router /achievements/search [get]
+func (s *AchievementService) SearchAchievements(c *fiber.Ctx) error {
 	userID, ok := c.Locals("user_id").(string)
 	if !ok || userID == "" {
 		return c.Status(401).JSON(fiber.Map{
@@ -1057,19 +1380,178 @@ func (s *AchievementService) GetMyStatistics(c *fiber.Ctx) error {
 			"message": "Unauthorized",
 		})
 	}
+	roleID, _ := c.Locals("role_id").(string)
 
-	ctx := context.Background()
-
-	// Get statistics dari MongoDB
-	stats, err := s.achievementRepo.GetStatisticsByStudentIDs(ctx, []string{userID})
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(400).JSON(fiber.Map{
 			"status":  "error",
-			"message": "Gagal mengambil statistik",
+			"message": "Parameter q wajib diisi",
 		})
 	}
 
-	// Build response
+	statusFilter := c.Query("status", "")
+	if statusFilter != "" {
+		validStatuses := map[string]bool{
+			"draft":     true,
+			"submitted": true,
+			"verified":  true,
+			"rejected":  true,
+		}
+		if !validStatuses[statusFilter] {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Invalid status filter. Valid values: draft, submitted, verified, rejected",
+			})
+		}
+	}
+
+	page := c.QueryInt("page", 1)
+	limit := c.QueryInt("limit", 10)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	// Step 1: Tentukan cakupan data sesuai role
+	studentIDs, err := s.achievementScope(userID, roleID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data mahasiswa bimbingan",
+		})
+	}
+
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "score", "desc")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid",
+		})
+	}
+
+	// Step 2: Cari di read model dengan text index
+	ctx := context.Background()
+	if useCursor {
+		results, err := s.viewRepo.SearchWithCursor(ctx, query, studentIDs, statusFilter, cursor, limit)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal melakukan pencarian",
+			})
+		}
+
+		results, pagination := utils.CursorPage(results, limit, cursor, searchCursor, s.cursorSecret)
+		highlightSearchResults(results, query)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Hasil pencarian berhasil diambil",
+			"data": fiber.Map{
+				"results":    results,
+				"pagination": pagination,
+				"query":      query,
+			},
+		})
+	}
+
+	results, total, err := s.viewRepo.Search(ctx, query, studentIDs, statusFilter, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal melakukan pencarian",
+		})
+	}
+	if results == nil {
+		results = []models.AchievementSearchResult{}
+	}
+
+	// Step 3: Highlight kata kunci pada title dan description
+	highlightSearchResults(results, query)
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Hasil pencarian berhasil diambil",
+		"data": fiber.Map{
+			"results": results,
+			"pagination": models.PaginationMeta{
+				Page:       page,
+				Limit:      limit,
+				TotalItems: total,
+				TotalPages: totalPages,
+			},
+			"query": query,
+		},
+	})
+}
+
+// highlightSearchResults menandai kata kunci pada title dan description hasil pencarian
+func highlightSearchResults(results []models.AchievementSearchResult, query string) {
+	terms := utils.ParseSearchTerms(query)
+	for i := range results {
+		highlights := map[string]string{}
+		if title := utils.HighlightText(results[i].Title, terms, 0); title != "" {
+			highlights["title"] = title
+		}
+		if description := utils.HighlightText(results[i].Description, terms, utils.HighlightSnippetLength); description != "" {
+			highlights["description"] = description
+		}
+		results[i].Highlights = highlights
+	}
+}
+
+// GetMyStatistics godoc
+// @Summary Get my achievement statistics
+// @Description Get comprehensive achievement statistics for the authenticated student including summary, category breakdown, level distribution, and period analysis. Served from incrementally maintained per-student counters; a date range that is not month-aligned is computed from the read model. Optionally filtered by status and date range.
+// @Tags Statistics & Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Success 200 {object} object{status=string,message=string,data=object} "Statistics retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid status or date filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve statistics from database"
+// @Router /reports/statistics [get]
+func (s *AchievementService) GetMyStatistics(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return c.Status(401).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized",
+		})
+	}
+
+	statsFilter, err := ParseStatisticsFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+
+	// Get statistics dari counter
+	stats, err := s.statisticsFor(ctx, repository.CounterScopeStudent, userID, []string{userID}, statsFilter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil statistik",
+		})
+	}
+
+	// Build response
 	response := buildStatisticsResponse(stats, false)
 
 	return c.Status(200).JSON(fiber.Map{
@@ -1081,12 +1563,16 @@ func (s *AchievementService) GetMyStatistics(c *fiber.Ctx) error {
 
 // GetAdviseeStatistics godoc
 // @Summary Get advisee statistics
-// @Description Lecturer view of comprehensive achievement statistics for their advisees including top performers ranking.
+// @Description Lecturer view of comprehensive achievement statistics for their advisees including top performers ranking. Served from incrementally maintained per-advisor counters; a date range that is not month-aligned is computed from the read model. Optionally filtered by status and date range.
 // @Tags Statistics & Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
 // @Success 200 {object} object{status=string,message=string,data=object} "Advisee statistics retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid status or date filter"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires lecturer access)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve statistics from database"
@@ -1100,6 +1586,14 @@ func (s *AchievementService) GetAdviseeStatistics(c *fiber.Ctx) error {
 		})
 	}
 
+	statsFilter, err := ParseStatisticsFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
 	ctx := context.Background()
 
 	// Get student IDs dari advisees
@@ -1119,8 +1613,8 @@ func (s *AchievementService) GetAdviseeStatistics(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get statistics dari MongoDB
-	stats, err := s.achievementRepo.GetStatisticsByStudentIDs(ctx, studentIDs)
+	// Get statistics dari counter
+	stats, err := s.statisticsFor(ctx, repository.CounterScopeAdvisor, userID, studentIDs, statsFilter)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -1129,7 +1623,7 @@ func (s *AchievementService) GetAdviseeStatistics(c *fiber.Ctx) error {
 	}
 
 	// Get top students
-	topStudents, err := s.referenceRepo.GetTopStudents(studentIDs, 10)
+	topStudents, err := s.viewRepo.GetTopStudents(ctx, studentIDs, statsFilter, 10)
 	if err != nil {
 		topStudents = []models.TopStudent{}
 	}
@@ -1145,6 +1639,7 @@ func (s *AchievementService) GetAdviseeStatistics(c *fiber.Ctx) error {
 			"student_name":          student.StudentName,
 			"total_achievements":    student.TotalAchievements,
 			"verified_achievements": student.VerifiedAchievements,
+			"total_points":          student.TotalPoints,
 		})
 	}
 	response["top_students"] = topStudentsMap
@@ -1158,23 +1653,33 @@ func (s *AchievementService) GetAdviseeStatistics(c *fiber.Ctx) error {
 
 // GetAllStatistics godoc
 // @Summary Get all achievement statistics
-// @Description Admin view of comprehensive achievement statistics across all students including top performers ranking.
+// @Description Admin view of comprehensive achievement statistics across all students including top performers ranking. Served from incrementally maintained counters; a date range that is not month-aligned is computed from the read model. Optionally filtered by status and date range.
 // @Tags Statistics & Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
 // @Success 200 {object} object{status=string,message=string,data=object} "All statistics retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid status or date filter"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires admin access)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve statistics from database"
 // @Router /reports/statistics [get]
 func (s *AchievementService) GetAllStatistics(c *fiber.Ctx) error {
+	statsFilter, err := ParseStatisticsFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
 	ctx := context.Background()
 
-	// Get all student IDs (we'll use empty filter to get all)
-	// For simplicity, we'll aggregate all achievements
-	filter := bson.M{"is_deleted": false}
-	achievements, err := s.achievementRepo.FindAll(ctx, filter)
+	// Get statistics semua mahasiswa dari counter
+	stats, err := s.statisticsFor(ctx, repository.CounterScopeStudent, "", nil, statsFilter)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -1182,11 +1687,8 @@ func (s *AchievementService) GetAllStatistics(c *fiber.Ctx) error {
 		})
 	}
 
-	// Calculate statistics manually
-	stats := calculateStatisticsFromAchievements(achievements)
-
 	// Get top students (all students)
-	topStudents, err := s.referenceRepo.GetAllTopStudents(10)
+	topStudents, err := s.viewRepo.GetTopStudents(ctx, nil, statsFilter, 10)
 	if err != nil {
 		topStudents = []models.TopStudent{}
 	}
@@ -1202,6 +1704,7 @@ func (s *AchievementService) GetAllStatistics(c *fiber.Ctx) error {
 			"student_name":          student.StudentName,
 			"total_achievements":    student.TotalAchievements,
 			"verified_achievements": student.VerifiedAchievements,
+			"total_points":          student.TotalPoints,
 		})
 	}
 	response["top_students"] = topStudentsMap
@@ -1213,6 +1716,162 @@ func (s *AchievementService) GetAllStatistics(c *fiber.Ctx) error {
 	})
 }
 
+// GetTrends godoc
+// @Summary Get achievement trends
+// @Description Trend and cohort analytics per time bucket (month, academic semester labelled e.g. 2023/2024-Ganjil for August-January and 2023/2024-Genap for February-July, or year), optionally grouped by program study, academic year cohort, category, level or advisor (grouped by advisor_id, with the advisor name in group.advisor_name). Each point includes status counts, verification rate (verified / (verified + rejected)), average hours from submission to verification and year-over-year growth against the same period one year earlier. Admin sees all students, lecturers their advisees and students themselves.
+// @Tags Statistics & Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param bucket query string false "Time bucket (month, semester, year)" default(month)
+// @Param group_by query string false "Comma-separated grouping dimensions (program_study, academic_year, category, level, advisor)"
+// @Param status query string false "Filter by status (draft, submitted, verified, rejected)"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Success 200 {object} object{status=string,message=string,data=object{bucket=string,group_by=[]string,trends=[]models.TrendPoint}} "Trends retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid bucket, group_by, status or date filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Failed to compute trends"
+// @Router /reports/trends [get]
+func (s *AchievementService) GetTrends(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	roleID, _ := c.Locals("role_id").(string)
+
+	filter, err := ParseTrendFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	// Step 1: Tentukan cakupan data sesuai role
+	scope, err := s.achievementScope(userID, roleID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data mahasiswa bimbingan",
+		})
+	}
+
+	// Step 2: Hitung trend dari read model
+	trends, err := s.viewRepo.GetTrends(context.Background(), scope, filter)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghitung trend achievement",
+		})
+	}
+
+	groupBy := filter.GroupBy
+	if groupBy == nil {
+		groupBy = []string{}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Trend achievement berhasil diambil",
+		"data": fiber.Map{
+			"bucket":   filter.Bucket,
+			"group_by": groupBy,
+			"trends":   trends,
+		},
+	})
+}
+
+// GetLeaderboard godoc
+// @Summary Get achievement leaderboard
+// @Description Ranks students by verified achievements or total rubric points, optionally scoped by program study, academic year cohort, advisor and achievement date range. Only verified achievements count. Students with equal scores share a rank and the next rank is skipped (1, 2, 2, 4); ties are listed by name. Students tied with the entry at the limit are all included, so the list can be longer than limit. The leaderboard is the same for every caller, so it is cached for a few minutes and served with Cache-Control and ETag headers for display screens.
+// @Tags Statistics & Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param program_study query string false "Program study (case-insensitive)"
+// @Param academic_year query string false "Academic year cohort"
+// @Param advisor_id query string false "Advisor (lecturer) ID"
+// @Param date_from query string false "Achievement date from (YYYY-MM-DD, inclusive)"
+// @Param date_to query string false "Achievement date to (YYYY-MM-DD, inclusive)"
+// @Param rank_by query string false "Ranking basis (verified, points)" default(verified)
+// @Param limit query int false "Number of entries (1-100), extended by students tied with the last entry" default(10)
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} object{status=string,message=string,data=object{filter=models.LeaderboardFilter,leaderboard=[]models.LeaderboardEntry}} "Leaderboard retrieved successfully"
+// @Success 304 "Leaderboard not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid rank_by, limit or date filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Failed to compute leaderboard"
+// @Router /reports/leaderboard [get]
+func (s *AchievementService) GetLeaderboard(c *fiber.Ctx) error {
+	filter, err := ParseLeaderboardFilter(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+
+	// Step 1: Ambil dari cache, leaderboard sama untuk semua user
+	key := filter.CacheKey()
+	body, cached := []byte(nil), false
+	if s.cache != nil {
+		if value, ok := s.cache.Get(key); ok {
+			body, cached = value.([]byte)
+		}
+	}
+
+	// Step 2: Hitung leaderboard dari read model jika belum ada di cache
+	if !cached {
+		entries, err := s.viewRepo.GetLeaderboard(context.Background(), filter)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal menghitung leaderboard",
+			})
+		}
+
+		body, err = json.Marshal(fiber.Map{
+			"status":  "success",
+			"message": "Leaderboard berhasil diambil",
+			"data": fiber.Map{
+				"filter":      filter,
+				"leaderboard": entries,
+			},
+		})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal menghitung leaderboard",
+			})
+		}
+		if s.cache != nil {
+			s.cache.Set(key, body, s.leaderboardTTL)
+		}
+	}
+
+	// Step 3: Header cache untuk layar display, private karena endpoint butuh login
+	// sehingga tidak boleh disimpan shared cache. 304 jika ETag masih sama
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", int(s.leaderboardTTL.Seconds())))
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(200).Send(body)
+}
+
+// statisticsFor mengambil statistik dari counter yang diperbarui setiap transisi.
+// Jika rentang tanggal bukan bulan penuh, statistik dihitung dari read model.
+func (s *AchievementService) statisticsFor(ctx context.Context, scope, ownerID string, studentIDs []string, filter *models.StatisticsFilter) (map[string]interface{}, error) {
+	if _, _, ok := repository.CounterPeriodRange(filter); ok {
+		return s.counterRepo.GetStatistics(ctx, scope, ownerID, filter)
+	}
+	return s.viewRepo.GetStatistics(ctx, studentIDs, filter)
+}
+
 // Helper function to build statistics response
 func buildStatisticsResponse(stats map[string]interface{}, includeTopStudents bool) fiber.Map {
 	totalAchievements := stats["total_achievements"].(int)
@@ -1306,56 +1965,6 @@ func buildEmptyStatistics(includeTopStudents bool) fiber.Map {
 	return response
 }
 
-// Helper function to calculate statistics from achievements
-func calculateStatisticsFromAchievements(achievements []models.Achievement) map[string]interface{} {
-	stats := make(map[string]interface{})
-
-	totalAchievements := len(achievements)
-	totalVerified := 0
-	totalPending := 0
-	totalRejected := 0
-	totalDraft := 0
-
-	categoryCount := make(map[string]int)
-	levelCount := make(map[string]int)
-	periodCount := make(map[string]int)
-
-	for _, achievement := range achievements {
-		switch achievement.Status {
-		case "verified":
-			totalVerified++
-		case "submitted":
-			totalPending++
-		case "rejected":
-			totalRejected++
-		case "draft":
-			totalDraft++
-		}
-
-		if achievement.Category != "" {
-			categoryCount[achievement.Category]++
-		}
-
-		if achievement.Level != "" {
-			levelCount[achievement.Level]++
-		}
-
-		yearMonth := achievement.Date.Format("2006-01")
-		periodCount[yearMonth]++
-	}
-
-	stats["total_achievements"] = totalAchievements
-	stats["total_verified"] = totalVerified
-	stats["total_pending"] = totalPending
-	stats["total_rejected"] = totalRejected
-	stats["total_draft"] = totalDraft
-	stats["category_count"] = categoryCount
-	stats["level_count"] = levelCount
-	stats["period_count"] = periodCount
-
-	return stats
-}
-
 // GetAchievementHistory godoc
 // @Summary Get achievement history
 // @Description Get status change history of an achievement including timestamps and verification/rejection details.
@@ -1451,7 +2060,7 @@ func (s *AchievementService) GetAchievementHistory(c *fiber.Ctx) error {
 
 // UploadAttachment godoc
 // @Summary Upload additional attachments
-// @Description Upload additional files to a draft achievement. Validates file types and handles rollback on errors.
+// @Description Upload additional files to a draft achievement. Validates file content against its extension, enforces per-achievement and per-student storage quotas, and handles rollback on errors.
 // @Tags Achievements
 // @Accept multipart/form-data
 // @Produce json
@@ -1459,7 +2068,9 @@ func (s *AchievementService) GetAchievementHistory(c *fiber.Ctx) error {
 // @Param id path string true "Achievement ID"
 // @Param attachments formData file true "Additional attachment files (multiple files allowed)"
 // @Success 200 {object} object{status=string,message=string,data=object{achievement_id=string,new_documents=[]models.Document,total_documents=int}} "Attachments uploaded successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request, no files, or upload error"
+// @Failure 400 {object} map[string]interface{} "Invalid request, no files, or upload error (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED, FILE_CONTENT_MISMATCH)"
+// @Failure 413 {object} map[string]interface{} "Storage quota exceeded (code: ACHIEVEMENT_QUOTA_EXCEEDED, STUDENT_QUOTA_EXCEEDED)"
+// @Failure 422 {object} map[string]interface{} "Malware detected by antivirus scan (code: FILE_INFECTED)"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Access denied or achievement not in draft status"
 // @Failure 404 {object} map[string]interface{} "Achievement not found"
@@ -1513,29 +2124,9 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 		})
 	}
 
-	var newDocuments []models.Document
-	for _, file := range files {
-		// Save file
-		filepath, err := utils.SaveUploadedFile(file, s.uploadConfig)
-		if err != nil {
-			// Rollback uploaded files
-			for _, doc := range newDocuments {
-				utils.DeleteFile(doc.Filepath)
-			}
-			return c.Status(400).JSON(fiber.Map{
-				"status":  "error",
-				"message": fmt.Sprintf("Gagal upload file: %v", err),
-			})
-		}
-
-		// Add to documents
-		newDocuments = append(newDocuments, models.Document{
-			Filename:   file.Filename,
-			Filepath:   filepath,
-			Filesize:   file.Size,
-			Mimetype:   file.Header.Get("Content-Type"),
-			UploadedAt: time.Now(),
-		})
+	newDocuments, err := s.saveDocuments(ctx, files, achievementID, achievement.StudentID, achievement.Documents)
+	if err != nil {
+		return uploadErrorResponse(c, err)
 	}
 
 	// Append to existing documents
@@ -1545,14 +2136,13 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 	// Update in MongoDB
 	if err := s.achievementRepo.Update(ctx, achievementID, achievement); err != nil {
 		// Rollback uploaded files
-		for _, doc := range newDocuments {
-			utils.DeleteFile(doc.Filepath)
-		}
+		deleteDocumentFiles(newDocuments)
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
 			"message": "Gagal mengupdate achievement",
 		})
 	}
+	refreshReadModel(s.projector, achievementID)
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -1565,6 +2155,163 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 	})
 }
 
+// DeleteAttachment godoc
+// @Summary Delete attachment
+// @Description Remove a document from a draft achievement. The file is deleted from storage after the achievement is updated.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param docId path string true "Document ID"
+// @Success 200 {object} object{status=string,message=string,data=object{achievement_id=string,document_id=string,total_documents=int}} "Attachment deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Achievement not in draft status"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Access denied - not owner or insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Achievement or document not found"
+// @Failure 500 {object} map[string]interface{} "Delete operation failed"
+// @Router /achievements/{id}/documents/{docId} [delete]
+func (s *AchievementService) DeleteAttachment(c *fiber.Ctx) error {
+	achievementID := c.Params("id")
+	documentID := c.Params("docId")
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return c.Status(401).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Unauthorized",
+		})
+	}
+
+	ctx := context.Background()
+
+	// Get achievement
+	achievement, err := s.achievementRepo.FindByID(ctx, achievementID)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Achievement tidak ditemukan",
+		})
+	}
+
+	// Check ownership
+	if achievement.StudentID != userID {
+		return c.Status(403).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Anda tidak memiliki akses ke achievement ini",
+		})
+	}
+
+	// Check status (only draft can remove attachments)
+	if achievement.Status != "draft" {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Hanya achievement dengan status 'draft' yang bisa menghapus attachment",
+		})
+	}
+
+	// Cari dokumen yang akan dihapus
+	index := -1
+	for i, doc := range achievement.Documents {
+		if doc.ID == documentID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Dokumen tidak ditemukan",
+		})
+	}
+
+	removed := achievement.Documents[index]
+	documents := make([]models.Document, 0, len(achievement.Documents)-1)
+	documents = append(documents, achievement.Documents[:index]...)
+	documents = append(documents, achievement.Documents[index+1:]...)
+	achievement.Documents = documents
+
+	// Update in MongoDB terlebih dahulu, baru hapus file
+	if err := s.achievementRepo.Update(ctx, achievementID, achievement); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengupdate achievement",
+		})
+	}
+	refreshReadModel(s.projector, achievementID)
+
+	// File yang gagal dihapus akan dibersihkan oleh garbage collector
+	deleteDocumentFiles([]models.Document{removed})
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Attachment berhasil dihapus",
+		"data": fiber.Map{
+			"achievement_id":  achievementID,
+			"document_id":     documentID,
+			"total_documents": len(achievement.Documents),
+		},
+	})
+}
+
+// GetDocumentThumbnail godoc
+// @Summary Get document thumbnail
+// @Description Get the server-generated thumbnail of an image document (JPEG/PNG). Access control: owner, admin, or the student's advisor.
+// @Tags Achievements
+// @Produce image/jpeg,image/png
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param docId path string true "Document ID"
+// @Success 200 {file} file "Thumbnail image"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Access denied - not owner, admin, or the student's advisor"
+// @Failure 404 {object} map[string]interface{} "Achievement, document, or thumbnail not found"
+// @Router /achievements/{id}/documents/{docId}/thumbnail [get]
+func (s *AchievementService) GetDocumentThumbnail(c *fiber.Ctx) error {
+	achievementID := c.Params("id")
+	documentID := c.Params("docId")
+	userID, _ := c.Locals("user_id").(string)
+
+	ctx := context.Background()
+	achievement, err := s.achievementRepo.FindByID(ctx, achievementID)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Achievement tidak ditemukan",
+		})
+	}
+
+	// Check access (owner, admin atau dosen wali student)
+	roleID, _ := c.Locals("role_id").(string)
+	allowed := achievement.StudentID == userID || roleID == "1"
+	if !allowed && roleID == "2" {
+		allowed, err = s.isAdvisorOf(userID, achievement.StudentID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data mahasiswa bimbingan",
+			})
+		}
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Anda tidak memiliki akses ke achievement ini",
+		})
+	}
+
+	for _, doc := range achievement.Documents {
+		if doc.ID == documentID && doc.ThumbnailPath != "" {
+			c.Set(fiber.HeaderContentType, doc.Mimetype)
+			return c.SendFile(doc.ThumbnailPath)
+		}
+	}
+
+	return c.Status(404).JSON(fiber.Map{
+		"status":  "error",
+		"message": "Thumbnail tidak ditemukan",
+	})
+}
+
 // GetStudentAchievements godoc
 // @Summary Get student achievements
 // @Description Get all achievements for a specific student. Access control: admin, lecturer, or the student themselves.
@@ -1573,7 +2320,7 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Student ID"
-// @Success 200 {object} object{status=string,message=string,data=object{student_id=string,achievements=[]models.Achievement,total=int}} "Student achievements retrieved successfully"
+// @Success 200 {object} object{status=string,message=string,data=object{student_id=string,achievements=[]models.AchievementView,total=int}} "Student achievements retrieved successfully"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Access denied - insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve achievements"
@@ -1594,7 +2341,7 @@ func (s *AchievementService) GetStudentAchievements(c *fiber.Ctx) error {
 	}
 
 	ctx := context.Background()
-	achievements, err := s.achievementRepo.FindByStudentID(ctx, studentID)
+	achievements, err := s.viewRepo.FindByStudentID(ctx, studentID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -1615,13 +2362,13 @@ func (s *AchievementService) GetStudentAchievements(c *fiber.Ctx) error {
 
 // GetStudentReport godoc
 // @Summary Get student report
-// @Description Get detailed achievement report for a specific student including statistics and achievement list.
+// @Description Get detailed achievement report for a specific student including statistics, total rubric points of verified achievements and achievement list.
 // @Tags Statistics & Reports
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Student ID"
-// @Success 200 {object} object{status=string,message=string,data=object{student=models.Student,statistics=object,achievements=[]models.Achievement}} "Student report retrieved successfully"
+// @Success 200 {object} object{status=string,message=string,data=object{student=models.Student,statistics=object,total_points=int,achievements=[]models.AchievementView}} "Student report retrieved successfully"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
 // @Failure 403 {object} map[string]interface{} "Access denied - not owner, admin, or lecturer"
 // @Failure 404 {object} map[string]interface{} "Student not found"
@@ -1653,8 +2400,8 @@ func (s *AchievementService) GetStudentReport(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get achievements
-	achievements, err := s.achievementRepo.FindByStudentID(ctx, studentID)
+	// Get achievements dari read model
+	achievements, err := s.viewRepo.FindByStudentID(ctx, studentID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -1663,7 +2410,21 @@ func (s *AchievementService) GetStudentReport(c *fiber.Ctx) error {
 	}
 
 	// Calculate statistics
-	stats := calculateStatisticsFromAchievements(achievements)
+	stats, err := s.statisticsFor(ctx, repository.CounterScopeStudent, studentID, []string{studentID}, nil)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil statistik",
+		})
+	}
+
+	// Total poin rubric dari achievement verified
+	totalPoints := 0
+	for _, achievement := range achievements {
+		if achievement.Status == "verified" {
+			totalPoints += achievement.Points
+		}
+	}
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -1671,7 +2432,347 @@ func (s *AchievementService) GetStudentReport(c *fiber.Ctx) error {
 		"data": fiber.Map{
 			"student":      student,
 			"statistics":   buildStatisticsResponse(stats, false),
+			"total_points": totalPoints,
 			"achievements": achievements,
 		},
 	})
-}
\ No newline at end of file
+}
+
+// GetStudentTranscript godoc
+// @Summary Get student achievement transcript (PDF)
+// @Description Generate the official achievement transcript attached to the diploma supplement (SKPI). Only verified achievements are listed, together with student identity, program study, advisor, verification dates and a verification code. Each issued transcript is stored under its code so it can be verified later through GET /transcripts/verify, even after the student's verified achievements change.
+// @Tags Statistics & Reports
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path string true "Student ID"
+// @Success 200 {file} file "Transcript PDF"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Access denied - not owner, admin, or lecturer"
+// @Failure 404 {object} map[string]interface{} "Student not found"
+// @Failure 500 {object} map[string]interface{} "Failed to generate transcript"
+// @Router /reports/student/{id}/transcript.pdf [get]
+func (s *AchievementService) GetStudentTranscript(c *fiber.Ctx) error {
+	studentID := c.Params("id")
+
+	transcript, status, message := s.loadTranscript(c, studentID)
+	if transcript == nil {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	// Simpan salinan transkrip agar kode verifikasi tetap berlaku setelah isi berubah
+	issued, err := s.transcriptRepo.Issue(context.Background(), models.NewIssuedTranscript(transcript))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menyimpan transkrip",
+		})
+	}
+	transcript.GeneratedAt = issued.IssuedAt
+
+	var buf bytes.Buffer
+	if err := utils.RenderTranscriptPDF(&buf, transcript); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuat transkrip",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="transkrip-prestasi-%s.pdf"`, transcript.Student.StudentID))
+	return c.Status(200).Send(buf.Bytes())
+}
+
+// VerifyTranscript godoc
+// @Summary Verify transcript code
+// @Description Public endpoint (no login, rate limited per IP) to check a verification code printed on an issued transcript. A valid code returns the transcript as it was issued, regardless of later changes to the student's achievements.
+// @Tags Statistics & Reports
+// @Produce json
+// @Param code query string true "Verification code printed on the transcript"
+// @Success 200 {object} object{status=string,message=string,data=object{valid=bool,transcript=models.IssuedTranscript}} "Verification result"
+// @Failure 400 {object} map[string]interface{} "Missing code"
+// @Failure 429 {object} map[string]interface{} "Too many requests"
+// @Failure 500 {object} map[string]interface{} "Failed to load transcript data"
+// @Router /transcripts/verify [get]
+func (s *AchievementService) VerifyTranscript(c *fiber.Ctx) error {
+	code := strings.ToUpper(strings.TrimSpace(c.Query("code")))
+	if code == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "code wajib diisi",
+		})
+	}
+
+	transcript, err := s.transcriptRepo.FindByCode(context.Background(), code)
+	if err == mongo.ErrNoDocuments {
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Kode verifikasi tidak terdaftar",
+			"data": fiber.Map{
+				"valid": false,
+			},
+		})
+	}
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil data transkrip",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Verifikasi transkrip selesai",
+		"data": fiber.Map{
+			"valid":      true,
+			"transcript": transcript,
+		},
+	})
+}
+
+// loadTranscript mengecek akses lalu mengambil data transkrip mahasiswa.
+// Jika gagal, transcript nil beserta status HTTP dan pesan error.
+func (s *AchievementService) loadTranscript(c *fiber.Ctx, studentID string) (*models.Transcript, int, string) {
+	userID, _ := c.Locals("user_id").(string)
+	roleID, _ := c.Locals("role_id").(string)
+
+	// Only admin, lecturer, or the student themselves can view
+	if userID != studentID && roleID != "1" && roleID != "2" {
+		return nil, 403, "Anda tidak memiliki akses ke data ini"
+	}
+
+	student, err := s.studentRepo.FindDetailByUserID(studentID)
+	if err != nil {
+		return nil, 500, "Gagal mengambil data mahasiswa"
+	}
+	if student == nil {
+		return nil, 404, "Student tidak ditemukan"
+	}
+
+	achievements, err := s.viewRepo.FindVerifiedByStudentID(context.Background(), studentID)
+	if err != nil {
+		return nil, 500, "Gagal mengambil data achievements"
+	}
+
+	return &models.Transcript{
+		Student:          student,
+		Achievements:     achievements,
+		VerificationCode: utils.TranscriptVerificationCode(student, achievements, s.transcriptSecret),
+		GeneratedAt:      time.Now(),
+	}, 0, ""
+}
+
+// saveDocuments memvalidasi kuota lalu menyimpan file upload sebagai dokumen achievement.
+// Jika salah satu file gagal, file yang sudah tersimpan akan dihapus.
+func (s *AchievementService) saveDocuments(ctx context.Context, files []*multipart.FileHeader, achievementID, studentID string, existing []models.Document) ([]models.Document, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// Hitung pemakaian storage saat ini
+	var achievementUsage int64
+	for _, doc := range existing {
+		achievementUsage += doc.Filesize
+	}
+	studentUsage, err := s.achievementRepo.GetStorageUsageByStudentID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung kuota penyimpanan: %v", err)
+	}
+
+	if err := utils.CheckUploadQuota(files, achievementUsage, studentUsage, s.uploadConfig); err != nil {
+		return nil, err
+	}
+
+	var documents []models.Document
+	for _, file := range files {
+		saved, err := utils.SaveUploadedFile(file, s.uploadConfig)
+		if err != nil {
+			// Rollback uploaded files
+			deleteDocumentFiles(documents)
+			return nil, err
+		}
+
+		documents = append(documents, newDocument(saved, file.Filename, achievementID))
+	}
+
+	return documents, nil
+}
+
+// newDocument membuat metadata dokumen achievement dari file yang sudah disimpan
+func newDocument(saved *utils.SavedFile, filename, achievementID string) models.Document {
+	document := models.Document{
+		ID:         uuid.New().String(),
+		Filename:   filename,
+		Filepath:   saved.Filepath,
+		Filesize:   saved.Filesize,
+		Mimetype:   saved.Mimetype,
+		UploadedAt: time.Now(),
+		ScanStatus: saved.ScanStatus,
+		SHA256:     saved.SHA256,
+	}
+	if saved.ScanStatus != utils.ScanStatusPending {
+		scannedAt := time.Now()
+		document.ScannedAt = &scannedAt
+	}
+	if saved.ThumbnailPath != "" {
+		document.ThumbnailPath = saved.ThumbnailPath
+		document.ThumbnailURL = models.DocumentThumbnailURL(achievementID, document.ID)
+	}
+	return document
+}
+
+// findDuplicateDocuments mencari dokumen achievement lain (milik mahasiswa manapun)
+// yang memiliki hash SHA-256 sama dengan dokumen pada achievement ini
+func (s *AchievementService) findDuplicateDocuments(ctx context.Context, achievement *models.Achievement) ([]models.DuplicateFlag, error) {
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, doc := range achievement.Documents {
+		if doc.SHA256 != "" && !seen[doc.SHA256] {
+			seen[doc.SHA256] = true
+			hashes = append(hashes, doc.SHA256)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	others, err := s.achievementRepo.FindByDocumentHashes(ctx, hashes, achievement.AchievementID)
+	if err != nil {
+		return nil, err
+	}
+	return MatchDuplicateDocuments(achievement, others), nil
+}
+
+// MatchDuplicateDocuments menandai dokumen achievement yang hash SHA-256-nya sama dengan
+// dokumen pada achievement lain. Achievement itu sendiri dan yang sudah dihapus diabaikan.
+// Link mengarah ke endpoint review agar bisa dibuka dosen wali yang mereview.
+func MatchDuplicateDocuments(achievement *models.Achievement, others []models.Achievement) []models.DuplicateFlag {
+	docsByHash := make(map[string][]models.Document)
+	for _, doc := range achievement.Documents {
+		if doc.SHA256 != "" {
+			docsByHash[doc.SHA256] = append(docsByHash[doc.SHA256], doc)
+		}
+	}
+
+	var flags []models.DuplicateFlag
+	for _, other := range others {
+		if other.AchievementID == achievement.AchievementID || other.IsDeleted {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, otherDoc := range other.Documents {
+			if seen[otherDoc.SHA256] {
+				continue
+			}
+			seen[otherDoc.SHA256] = true
+			for _, doc := range docsByHash[otherDoc.SHA256] {
+				flags = append(flags, models.DuplicateFlag{
+					DocumentID:          doc.ID,
+					Filename:            doc.Filename,
+					SHA256:              doc.SHA256,
+					OtherAchievementID:  other.AchievementID,
+					OtherStudentID:      other.StudentID,
+					OtherTitle:          other.Title,
+					OtherStatus:         other.Status,
+					OtherAchievementURL: models.AchievementReviewURL(other.AchievementID),
+				})
+			}
+		}
+	}
+
+	return flags
+}
+
+// deleteDocumentFiles menghapus file dokumen beserta thumbnail-nya
+func deleteDocumentFiles(documents []models.Document) {
+	for _, doc := range documents {
+		if err := utils.DeleteFile(doc.Filepath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Gagal menghapus file %s: %v", doc.Filepath, err)
+		}
+		if err := utils.DeleteFile(doc.ThumbnailPath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Gagal menghapus thumbnail %s: %v", doc.ThumbnailPath, err)
+		}
+	}
+}
+
+// isAdvisorOf mengecek apakah student termasuk mahasiswa bimbingan dosen wali
+func (s *AchievementService) isAdvisorOf(lecturerID, studentID string) (bool, error) {
+	studentIDs, err := s.studentRepo.FindStudentIDsByAdvisorID(lecturerID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range studentIDs {
+		if id == studentID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// canLecturerReview mengecek akses dosen ke halaman review: achievement mahasiswa
+// bimbingan, atau achievement yang ditandai duplikat dari achievement mahasiswa bimbingan
+func (s *AchievementService) canLecturerReview(ctx context.Context, lecturerID string, achievement *models.Achievement) (bool, error) {
+	studentIDs, err := s.studentRepo.FindStudentIDsByAdvisorID(lecturerID)
+	if err != nil {
+		return false, err
+	}
+	advisees := make(map[string]bool, len(studentIDs))
+	for _, id := range studentIDs {
+		advisees[id] = true
+	}
+	if advisees[achievement.StudentID] {
+		return true, nil
+	}
+
+	var hashes []string
+	for _, doc := range achievement.Documents {
+		if doc.SHA256 != "" {
+			hashes = append(hashes, doc.SHA256)
+		}
+	}
+	if len(hashes) == 0 {
+		return false, nil
+	}
+	others, err := s.achievementRepo.FindByDocumentHashes(ctx, hashes, achievement.AchievementID)
+	if err != nil {
+		return false, err
+	}
+	for _, other := range others {
+		if advisees[other.StudentID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// uploadErrorResponse mengubah error upload menjadi response dengan kode error
+func uploadErrorResponse(c *fiber.Ctx, err error) error {
+	var uploadErr *utils.UploadError
+	if !errors.As(err, &uploadErr) {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": fmt.Sprintf("Gagal upload file: %v", err),
+		})
+	}
+
+	status := 400
+	switch uploadErr.Code {
+	case utils.UploadErrAchievementQuota, utils.UploadErrStudentQuota, utils.UploadErrChunkTooLarge:
+		status = 413
+	case utils.UploadErrInfected:
+		status = 422
+	case utils.UploadErrOffsetMismatch:
+		status = 409
+	case utils.UploadErrChecksumMismatch:
+		// 460 Checksum Mismatch (ekstensi checksum protokol tus)
+		status = 460
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"status":  "error",
+		"code":    uploadErr.Code,
+		"message": fmt.Sprintf("Gagal upload file: %v", uploadErr),
+	})
+}
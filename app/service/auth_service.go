@@ -4,18 +4,21 @@ import (
 	models "crud-app/app/model"
 	"crud-app/app/repository"
 	"crud-app/app/utils"
+	"crud-app/config"
 	"database/sql"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type AuthService struct {
-	userRepo *repository.UserRepository
+	userRepo  *repository.UserRepository
+	jwtConfig utils.JWTConfig
 }
 
-func NewAuthService(db *sql.DB) *AuthService {
+func NewAuthService(db *sql.DB, cfg *config.Config) *AuthService {
 	return &AuthService{
-		userRepo: repository.NewUserRepository(db),
+		userRepo:  repository.NewUserRepository(db),
+		jwtConfig: JWTConfig(cfg.Auth),
 	}
 }
 
@@ -77,7 +80,7 @@ func (s *AuthService) Login(c *fiber.Ctx) error {
 	}
 
 	// Generate JWT token
-	token, err := utils.GenerateToken(*user)
+	token, err := utils.GenerateToken(*user, s.jwtConfig)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
@@ -138,7 +141,7 @@ func (s *AuthService) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	// Validate refresh token
-	claims, err := utils.ValidateToken(req.RefreshToken)
+	claims, err := utils.ValidateToken(req.RefreshToken, s.jwtConfig)
 	if err != nil {
 		return c.Status(401).JSON(fiber.Map{
 			"status":  "error",
@@ -164,7 +167,7 @@ func (s *AuthService) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	// Generate new token
-	newToken, err := utils.GenerateToken(*user)
+	newToken, err := utils.GenerateToken(*user, s.jwtConfig)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"status":  "error",
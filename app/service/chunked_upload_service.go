@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ChunkedUploadService menangani upload bertahap (resumable) untuk file bukti berukuran besar.
+// Alurnya mengikuti protokol tus: buat session, kirim chunk dengan Upload-Offset,
+// cek offset terakhir untuk melanjutkan, lalu file dilampirkan ke achievement saat lengkap.
+type ChunkedUploadService struct {
+	achievementRepo *repository.AchievementRepository
+	sessionRepo     *repository.UploadSessionRepository
+	taxonomyRepo    *repository.TaxonomyRepository
+	projector       *AchievementProjector
+	uploadConfig    utils.FileUploadConfig
+	chunkConfig     utils.ChunkedUploadConfig
+}
+
+func NewChunkedUploadService(mongoDB *mongo.Database, postgresDB *sql.DB, cfg *config.Config) *ChunkedUploadService {
+	return &ChunkedUploadService{
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+		sessionRepo:     repository.NewUploadSessionRepository(mongoDB),
+		taxonomyRepo:    repository.NewTaxonomyRepository(postgresDB),
+		projector:       NewAchievementProjector(mongoDB, postgresDB),
+		uploadConfig:    FileUploadConfig(cfg.Upload),
+		chunkConfig:     ChunkedUploadConfig(cfg.Upload),
+	}
+}
+
+// CreateUploadSession godoc
+// @Summary Start resumable upload
+// @Description Create an upload session for a large evidence file (e.g. video) on a draft achievement. The maximum file size depends on the taxonomy code of the achievement category (UPLOAD_CATEGORY_LIMITS_MB). Send the file in chunks with PATCH and resume from the offset returned by GET after a connection drop.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param request body models.CreateUploadSessionRequest true "File name, total size in bytes and optional SHA-256 (hex) of the whole file"
+// @Success 201 {object} object{status=string,message=string,data=object{upload_id=string,upload_url=string,offset=int,filesize=int,max_chunk_size=int,expires_at=string}} "Upload session created"
+// @Failure 400 {object} map[string]interface{} "Invalid request, achievement not in draft status, or file rejected (code: FILE_TOO_LARGE, FILE_TYPE_NOT_ALLOWED)"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Access denied - not owner"
+// @Failure 404 {object} map[string]interface{} "Achievement not found"
+// @Failure 413 {object} map[string]interface{} "Storage quota exceeded, counting uploads still in progress (code: ACHIEVEMENT_QUOTA_EXCEEDED or STUDENT_QUOTA_EXCEEDED)"
+// @Failure 500 {object} map[string]interface{} "Failed to create upload session"
+// @Router /achievements/{id}/uploads [post]
+func (s *ChunkedUploadService) CreateUploadSession(c *fiber.Ctx) error {
+	achievementID := c.Params("id")
+	userID, _ := c.Locals("user_id").(string)
+
+	var req models.CreateUploadSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+	if req.Filename == "" || req.Filesize <= 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "filename dan filesize wajib diisi",
+		})
+	}
+
+	ctx := context.Background()
+
+	achievement, err := s.achievementRepo.FindByID(ctx, achievementID)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Achievement tidak ditemukan",
+		})
+	}
+	if achievement.StudentID != userID {
+		return c.Status(403).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Anda tidak memiliki akses ke achievement ini",
+		})
+	}
+	if achievement.Status != "draft" {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Hanya achievement dengan status 'draft' yang bisa menambah attachment",
+		})
+	}
+
+	// Validasi tipe file dan batas ukuran sesuai kode kategori achievement
+	taxonomy, err := s.taxonomyRepo.FindTaxonomy()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal memeriksa kategori achievement",
+		})
+	}
+	categoryCode := CategoryLimitKey(taxonomy, achievement.Category)
+	if err := s.chunkConfig.ValidateUploadSession(req.Filename, req.Filesize, categoryCode); err != nil {
+		return uploadErrorResponse(c, err)
+	}
+
+	// Kuota dihitung termasuk upload bertahap lain yang masih berjalan. Kuota per
+	// achievement minimal sebesar batas file kategori agar satu file besar tetap muat.
+	var achievementUsage int64
+	for _, doc := range achievement.Documents {
+		achievementUsage += doc.Filesize
+	}
+	achievementPending, err := s.sessionRepo.GetPendingSizeByAchievementID(ctx, achievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghitung kuota penyimpanan",
+		})
+	}
+	studentUsage, err := s.achievementRepo.GetStorageUsageByStudentID(ctx, userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghitung kuota penyimpanan",
+		})
+	}
+	studentPending, err := s.sessionRepo.GetPendingSizeByStudentID(ctx, userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghitung kuota penyimpanan",
+		})
+	}
+	quotaConfig := s.chunkConfig.QuotaConfig(s.uploadConfig, categoryCode)
+	if err := utils.CheckStorageQuota(req.Filesize, achievementUsage+achievementPending, studentUsage+studentPending, quotaConfig); err != nil {
+		return uploadErrorResponse(c, err)
+	}
+
+	uploadID := uuid.New().String()
+	session := &models.UploadSession{
+		UploadID:      uploadID,
+		AchievementID: achievementID,
+		StudentID:     userID,
+		Filename:      req.Filename,
+		Filesize:      req.Filesize,
+		Checksum:      req.Checksum,
+		PartialPath:   utils.PartialUploadPath(s.chunkConfig, uploadID),
+		ExpiresAt:     time.Now().Add(s.chunkConfig.SessionTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuat session upload",
+		})
+	}
+
+	uploadURL := fmt.Sprintf("/api/v1/achievements/%s/uploads/%s", achievementID, uploadID)
+	c.Set("Location", uploadURL)
+	c.Set("Upload-Offset", "0")
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Session upload berhasil dibuat",
+		"data": fiber.Map{
+			"upload_id":      uploadID,
+			"upload_url":     uploadURL,
+			"offset":         0,
+			"filesize":       session.Filesize,
+			"max_chunk_size": s.chunkConfig.MaxChunkSize,
+			"expires_at":     session.ExpiresAt,
+		},
+	})
+}
+
+// GetUploadSession godoc
+// @Summary Get resumable upload offset
+// @Description Get the number of bytes already received for an upload session, so the client can resume after a connection drop. The offset is also returned in the Upload-Offset header (HEAD is supported).
+// @Tags Achievements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Success 200 {object} object{status=string,message=string,data=models.UploadSession} "Upload session retrieved"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 404 {object} map[string]interface{} "Upload session not found or expired"
+// @Router /achievements/{id}/uploads/{uploadId} [get]
+func (s *ChunkedUploadService) GetUploadSession(c *fiber.Ctx) error {
+	session, err := s.findSession(c)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Session upload tidak ditemukan atau sudah kedaluwarsa",
+		})
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(session.Filesize, 10))
+	c.Set("Cache-Control", "no-store")
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Session upload berhasil diambil",
+		"data":    session,
+	})
+}
+
+// UploadChunk godoc
+// @Summary Upload file chunk
+// @Description Append a chunk to an upload session. The Upload-Offset header must equal the current offset. An optional Upload-Checksum header ("sha256 <base64>") verifies the chunk. When the last chunk arrives the file is validated, scanned and attached to the achievement.
+// @Tags Achievements
+// @Accept application/offset+octet-stream
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Param Upload-Offset header int true "Byte offset of this chunk"
+// @Param Upload-Checksum header string false "Chunk checksum, e.g. sha256 <base64 digest>"
+// @Success 200 {object} object{status=string,message=string,data=object{upload_id=string,offset=int,filesize=int,completed=bool,document=models.Document}} "Chunk stored (document is set once the upload is complete)"
+// @Failure 400 {object} map[string]interface{} "Invalid offset header or file rejected (code: FILE_CONTENT_MISMATCH)"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 404 {object} map[string]interface{} "Upload session not found or expired"
+// @Failure 409 {object} map[string]interface{} "Offset does not match the session (code: UPLOAD_OFFSET_MISMATCH)"
+// @Failure 413 {object} map[string]interface{} "Chunk too large or exceeds file size (code: CHUNK_TOO_LARGE)"
+// @Failure 422 {object} map[string]interface{} "Malware detected by antivirus scan (code: FILE_INFECTED)"
+// @Failure 460 {object} map[string]interface{} "Checksum mismatch (code: CHECKSUM_MISMATCH)"
+// @Failure 500 {object} map[string]interface{} "Failed to store chunk"
+// @Router /achievements/{id}/uploads/{uploadId} [patch]
+func (s *ChunkedUploadService) UploadChunk(c *fiber.Ctx) error {
+	session, err := s.findSession(c)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Session upload tidak ditemukan atau sudah kedaluwarsa",
+		})
+	}
+
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Header Upload-Offset tidak valid",
+		})
+	}
+
+	// Step 1: Validasi offset dan ukuran chunk
+	if offset != session.Offset {
+		c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+		return uploadErrorResponse(c, &utils.UploadError{
+			Code:    utils.UploadErrOffsetMismatch,
+			Message: fmt.Sprintf("offset %d tidak sesuai, lanjutkan dari offset %d", offset, session.Offset),
+		})
+	}
+
+	chunk := c.Body()
+	if int64(len(chunk)) > s.chunkConfig.MaxChunkSize || offset+int64(len(chunk)) > session.Filesize {
+		return uploadErrorResponse(c, &utils.UploadError{
+			Code:    utils.UploadErrChunkTooLarge,
+			Message: fmt.Sprintf("chunk melebihi batas %d byte atau melebihi ukuran file", s.chunkConfig.MaxChunkSize),
+		})
+	}
+	if err := utils.VerifyChunkChecksum(chunk, c.Get("Upload-Checksum")); err != nil {
+		return uploadErrorResponse(c, err)
+	}
+
+	// Step 2: Klaim offset baru dulu, baru tulis chunk. Hanya satu request yang bisa
+	// memajukan offset yang sama, sehingga chunk lain tidak ikut menulis ke file
+	ctx := context.Background()
+	newOffset := offset + int64(len(chunk))
+	expiresAt := time.Now().Add(s.chunkConfig.SessionTTL)
+	if err := s.sessionRepo.UpdateOffset(ctx, session.UploadID, offset, newOffset, expiresAt); err != nil {
+		// Chunk lain dengan offset yang sama sudah lebih dulu diklaim
+		return uploadErrorResponse(c, &utils.UploadError{
+			Code:    utils.UploadErrOffsetMismatch,
+			Message: "chunk dengan offset yang sama sedang diproses",
+		})
+	}
+	if _, err := utils.AppendChunk(session.PartialPath, offset, chunk); err != nil {
+		// Kembalikan klaim agar chunk bisa dikirim ulang dari offset yang sama
+		if rollbackErr := s.sessionRepo.UpdateOffset(ctx, session.UploadID, newOffset, offset, session.ExpiresAt); rollbackErr != nil {
+			log.Printf("Gagal mengembalikan offset upload %s: %v", session.UploadID, rollbackErr)
+		}
+		return uploadErrorResponse(c, err)
+	}
+	c.Set("Upload-Offset", strconv.FormatInt(newOffset, 10))
+
+	if newOffset < session.Filesize {
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Chunk berhasil diupload",
+			"data": fiber.Map{
+				"upload_id": session.UploadID,
+				"offset":    newOffset,
+				"filesize":  session.Filesize,
+				"completed": false,
+			},
+		})
+	}
+
+	// Step 3: Upload lengkap, validasi file dan lampirkan ke achievement
+	document, err := s.completeUpload(ctx, session)
+	if err != nil {
+		return uploadErrorResponse(c, err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Upload selesai dan file berhasil dilampirkan",
+		"data": fiber.Map{
+			"upload_id": session.UploadID,
+			"offset":    newOffset,
+			"filesize":  session.Filesize,
+			"completed": true,
+			"document":  document,
+		},
+	})
+}
+
+// CancelUploadSession godoc
+// @Summary Cancel resumable upload
+// @Description Abort an upload session and delete the partially uploaded data.
+// @Tags Achievements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Success 200 {object} object{status=string,message=string} "Upload session cancelled"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 404 {object} map[string]interface{} "Upload session not found or expired"
+// @Failure 500 {object} map[string]interface{} "Failed to cancel upload session"
+// @Router /achievements/{id}/uploads/{uploadId} [delete]
+func (s *ChunkedUploadService) CancelUploadSession(c *fiber.Ctx) error {
+	session, err := s.findSession(c)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Session upload tidak ditemukan atau sudah kedaluwarsa",
+		})
+	}
+
+	if err := s.sessionRepo.Delete(context.Background(), session.UploadID); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membatalkan session upload",
+		})
+	}
+	removePartialUpload(session.PartialPath)
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Session upload berhasil dibatalkan",
+	})
+}
+
+// findSession mengambil session dari parameter URL dan memastikan milik user yang login
+func (s *ChunkedUploadService) findSession(c *fiber.Ctx) (*models.UploadSession, error) {
+	userID, _ := c.Locals("user_id").(string)
+
+	session, err := s.sessionRepo.FindByID(context.Background(), c.Params("uploadId"))
+	if err != nil {
+		return nil, err
+	}
+	if session.AchievementID != c.Params("id") || session.StudentID != userID {
+		return nil, mongo.ErrNoDocuments
+	}
+	return session, nil
+}
+
+// completeUpload memvalidasi file yang sudah lengkap lalu menambahkannya ke achievement.
+// Session selalu dihapus, file yang ditolak tidak bisa dilanjutkan.
+func (s *ChunkedUploadService) completeUpload(ctx context.Context, session *models.UploadSession) (*models.Document, error) {
+	defer func() {
+		if err := s.sessionRepo.Delete(ctx, session.UploadID); err != nil {
+			log.Printf("Gagal menghapus session upload %s: %v", session.UploadID, err)
+		}
+		removePartialUpload(session.PartialPath)
+	}()
+
+	saved, err := utils.FinalizeChunkedUpload(session.PartialPath, session.Filename, session.Checksum, s.uploadConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	document := models.Document{
+		ID:         uuid.New().String(),
+		Filename:   session.Filename,
+		Filepath:   saved.Filepath,
+		Filesize:   saved.Filesize,
+		Mimetype:   saved.Mimetype,
+		UploadedAt: time.Now(),
+		ScanStatus: saved.ScanStatus,
+		SHA256:     saved.SHA256,
+	}
+	if saved.ScanStatus != utils.ScanStatusPending {
+		scannedAt := time.Now()
+		document.ScannedAt = &scannedAt
+	}
+	if saved.ThumbnailPath != "" {
+		document.ThumbnailPath = saved.ThumbnailPath
+		document.ThumbnailURL = models.DocumentThumbnailURL(session.AchievementID, document.ID)
+	}
+
+	// Achievement bisa saja sudah disubmit atau dihapus selama upload berjalan
+	if err := s.achievementRepo.AddDocument(ctx, session.AchievementID, document); err != nil {
+		deleteDocumentFiles([]models.Document{document})
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("achievement tidak ditemukan atau sudah tidak berstatus draft")
+		}
+		return nil, fmt.Errorf("gagal mengupdate achievement: %v", err)
+	}
+	refreshReadModel(s.projector, session.AchievementID)
+
+	return &document, nil
+}
+
+// removePartialUpload menghapus file sementara upload bertahap
+func removePartialUpload(path string) {
+	if err := utils.DeleteFile(path); err != nil && !os.IsNotExist(err) {
+		log.Printf("Gagal menghapus file upload sementara %s: %v", path, err)
+	}
+}
+
+// CategoryLimitKey mengembalikan kode kategori taxonomy untuk lookup batas ukuran file.
+// Achievement menyimpan nama baku kategori, sedangkan batas dikunci dengan kode. Kategori
+// lama yang tidak terdaftar di taxonomy dikembalikan apa adanya.
+func CategoryLimitKey(taxonomy *models.Taxonomy, category string) string {
+	if taxonomy != nil {
+		if entry, ok := taxonomy.ResolveCategory(category); ok {
+			return entry.Code
+		}
+	}
+	return category
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crud-app/app/utils"
+	"crud-app/config"
+)
+
+// JWTConfig konfigurasi token untuk utils.GenerateToken dan utils.ValidateToken
+func JWTConfig(c config.AuthConfig) utils.JWTConfig {
+	return utils.JWTConfig{Secret: []byte(c.JWTSecret), TokenTTL: c.TokenTTL}
+}
+
+// FileUploadConfig konfigurasi upload dokumen. Scanner clamd dipasang jika CLAMD_ADDRESS diisi.
+func FileUploadConfig(c config.UploadConfig) utils.FileUploadConfig {
+	uploadConfig := utils.FileUploadConfig{
+		UploadPath:            c.Path,
+		MaxFileSize:           c.MaxFileSize,
+		AllowedFileTypes:      c.AllowedFileTypes,
+		MaxAchievementStorage: c.MaxAchievementStorage,
+		MaxStudentStorage:     c.MaxStudentStorage,
+		QuarantinePath:        c.QuarantinePath,
+	}
+	if c.ClamdAddress != "" {
+		scanner := utils.NewClamdScanner(c.ClamdAddress)
+		scanner.MaxStreamSize = c.ClamdMaxStreamSize
+		uploadConfig.Scanner = scanner
+	}
+	return uploadConfig
+}
+
+// ChunkedUploadConfig konfigurasi upload bertahap. Tanpa UPLOAD_CATEGORY_LIMITS_MB batas
+// per kategori memakai default utils.
+func ChunkedUploadConfig(c config.UploadConfig) utils.ChunkedUploadConfig {
+	chunkConfig := utils.DefaultChunkedUploadConfig
+	chunkConfig.PartialPath = c.PartialPath
+	chunkConfig.MaxChunkSize = c.MaxChunkSize
+	chunkConfig.SessionTTL = c.SessionTTL
+	chunkConfig.DefaultMaxFileSize = c.ChunkedMaxFileSize
+	if limits, err := config.ParseCategorySizeLimits(c.CategoryLimitsMB); err == nil && len(limits) > 0 {
+		chunkConfig.CategoryMaxFileSize = limits
+	}
+	return chunkConfig
+}
+
+// ExportJobConfig konfigurasi export laporan
+func ExportJobConfig(c config.ExportConfig) utils.ExportConfig {
+	return utils.ExportConfig{
+		ExportPath:   c.Path,
+		SyncRowLimit: c.SyncRowLimit,
+		Retention:    c.Retention,
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/utils"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseCursorRequest menentukan mode pagination. Mode cursor dipakai jika parameter
+// cursor diisi atau pagination=cursor, selain itu endpoint tetap memakai page/limit.
+// Cursor hanya berlaku untuk urutan (sort_by dan sort_order) yang sama saat cursor dibuat.
+func parseCursorRequest(c *fiber.Ctx, secret []byte, sortBy, sortOrder string) (bool, *models.Cursor, error) {
+	token := c.Query("cursor")
+	if token == "" {
+		return c.Query("pagination") == "cursor", nil, nil
+	}
+
+	cursor, err := utils.DecodeCursor(token, secret)
+	if err != nil {
+		return true, nil, err
+	}
+	if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+		return true, nil, utils.ErrInvalidCursor
+	}
+	return true, cursor, nil
+}
+
+func userCursor(user models.User) models.Cursor {
+	return models.Cursor{
+		SortBy:    "created_at",
+		SortOrder: "desc",
+		Value:     timeCursorValue(&user.CreatedAt),
+		ID:        user.ID,
+	}
+}
+
+func studentCursor(student models.StudentDetail) models.Cursor {
+	return models.Cursor{
+		SortBy:    "created_at",
+		SortOrder: "desc",
+		Value:     timeCursorValue(&student.CreatedAt),
+		ID:        student.ID,
+	}
+}
+
+func lecturerCursor(lecturer models.LecturerDetail) models.Cursor {
+	return models.Cursor{
+		SortBy:    "full_name",
+		SortOrder: "asc",
+		Value:     &lecturer.FullName,
+		ID:        lecturer.ID,
+	}
+}
+
+// achievementViewCursor menyusun cursor read model untuk field sorting filter
+func achievementViewCursor(filter *models.AchievementFilter) func(models.AchievementView) models.Cursor {
+	return func(view models.AchievementView) models.Cursor {
+		var value *string
+		switch filter.SortBy {
+		case "created_at":
+			value = timeCursorValue(&view.CreatedAt)
+		case "updated_at":
+			value = timeCursorValue(&view.UpdatedAt)
+		case "date":
+			value = timeCursorValue(&view.Date)
+		case "submitted_at":
+			value = timeCursorValue(view.SubmittedAt)
+		case "verified_at":
+			value = timeCursorValue(view.VerifiedAt)
+		case "title":
+			value = &view.Title
+		case "category":
+			value = &view.Category
+		case "level":
+			value = &view.Level
+		case "status":
+			value = &view.Status
+		case "program_study":
+			value = &view.ProgramStudy
+		case "academic_year":
+			value = &view.AcademicYear
+		case "advisor":
+			value = &view.AdvisorName
+		}
+
+		return models.Cursor{
+			SortBy:    filter.SortBy,
+			SortOrder: filter.SortOrder,
+			Value:     value,
+			ID:        view.ID.Hex(),
+		}
+	}
+}
+
+func timeCursorValue(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	value := t.UTC().Format(time.RFC3339Nano)
+	return &value
+}
+
+// adviseeCursor cursor daftar mahasiswa bimbingan, diurutkan berdasarkan nama
+func adviseeCursor(student models.StudentDetail) models.Cursor {
+	return models.Cursor{
+		SortBy:    "full_name",
+		SortOrder: "asc",
+		Value:     &student.FullName,
+		ID:        student.ID,
+	}
+}
+
+// pendingCursor cursor daftar achievement menunggu verifikasi, paling lama menunggu dulu
+func pendingCursor(view models.AchievementView) models.Cursor {
+	return models.Cursor{
+		SortBy:    "submitted_at",
+		SortOrder: "asc",
+		Value:     timeCursorValue(view.SubmittedAt),
+		ID:        view.ID.Hex(),
+	}
+}
+
+// searchCursor cursor hasil pencarian, diurutkan berdasarkan relevansi
+func searchCursor(result models.AchievementSearchResult) models.Cursor {
+	score := strconv.FormatFloat(result.Score, 'g', -1, 64)
+	return models.Cursor{
+		SortBy:    "score",
+		SortOrder: "desc",
+		Value:     &score,
+		ID:        result.ID.Hex(),
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FileGCConfig konfigurasi garbage collector file upload
+type FileGCConfig struct {
+	Interval time.Duration
+	// Lama file achievement yang soft-deleted disimpan sebelum di-purge
+	Retention time.Duration
+	// File yang lebih muda dari grace period tidak dianggap orphan,
+	// karena upload yang sedang berjalan belum tersimpan di MongoDB
+	OrphanGracePeriod time.Duration
+}
+
+// FileGCResult ringkasan hasil satu kali run garbage collector
+type FileGCResult struct {
+	OrphansRemoved int
+	PurgedFiles    int
+	PurgedRecords  int
+	ExpiredUploads int
+	ExpiredExports int
+}
+
+// FileGCAchievementRepository method repository achievement yang dipakai garbage collector
+type FileGCAchievementRepository interface {
+	FindDeletedBefore(ctx context.Context, cutoff time.Time) ([]models.Achievement, error)
+	ClearDocuments(ctx context.Context, achievementID string) error
+	FindAllDocumentPaths(ctx context.Context) (map[string]bool, error)
+}
+
+// FileGCSessionRepository method repository session upload bertahap yang dipakai garbage collector
+type FileGCSessionRepository interface {
+	FindExpired(ctx context.Context, now time.Time) ([]models.UploadSession, error)
+	Delete(ctx context.Context, uploadID string) error
+}
+
+// FileGCExportJobRepository method repository job export yang dipakai garbage collector
+type FileGCExportJobRepository interface {
+	FindExpired(ctx context.Context, now time.Time) ([]models.ExportJob, error)
+	Delete(ctx context.Context, jobID string) error
+}
+
+type FileGarbageCollector struct {
+	achievementRepo FileGCAchievementRepository
+	sessionRepo     FileGCSessionRepository
+	exportJobRepo   FileGCExportJobRepository
+	uploadConfig    utils.FileUploadConfig
+	config          FileGCConfig
+}
+
+func NewFileGarbageCollector(mongoDB *mongo.Database, cfg *config.Config) *FileGarbageCollector {
+	return NewFileGarbageCollectorWithRepositories(
+		repository.NewAchievementRepository(mongoDB),
+		repository.NewUploadSessionRepository(mongoDB),
+		repository.NewExportJobRepository(mongoDB),
+		FileUploadConfig(cfg.Upload),
+		FileGCConfig{
+			Interval:          cfg.FileGC.Interval,
+			Retention:         cfg.FileGC.Retention,
+			OrphanGracePeriod: cfg.FileGC.OrphanGracePeriod,
+		},
+	)
+}
+
+// NewFileGarbageCollectorWithRepositories membuat garbage collector dengan repository
+// yang diberikan langsung, contoh: mock untuk test
+func NewFileGarbageCollectorWithRepositories(achievementRepo FileGCAchievementRepository, sessionRepo FileGCSessionRepository, exportJobRepo FileGCExportJobRepository, uploadConfig utils.FileUploadConfig, config FileGCConfig) *FileGarbageCollector {
+	return &FileGarbageCollector{
+		achievementRepo: achievementRepo,
+		sessionRepo:     sessionRepo,
+		exportJobRepo:   exportJobRepo,
+		uploadConfig:    uploadConfig,
+		config:          config,
+	}
+}
+
+// Start menjalankan garbage collector secara periodik di background
+func (g *FileGarbageCollector) Start() {
+	go func() {
+		ticker := time.NewTicker(g.config.Interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			result, err := g.RunOnce(context.Background())
+			if err != nil {
+				log.Printf("File GC gagal: %v", err)
+				continue
+			}
+			log.Printf("File GC selesai: %d orphan dihapus, %d file di-purge dari %d achievement, %d upload kedaluwarsa dihapus, %d export kedaluwarsa dihapus",
+				result.OrphansRemoved, result.PurgedFiles, result.PurgedRecords, result.ExpiredUploads, result.ExpiredExports)
+		}
+	}()
+}
+
+// RunOnce menjalankan satu siklus garbage collection
+func (g *FileGarbageCollector) RunOnce(ctx context.Context) (*FileGCResult, error) {
+	result := &FileGCResult{}
+
+	// Step 1: Purge file dari achievement yang sudah soft-deleted melewati retention
+	cutoff := time.Now().Add(-g.config.Retention)
+	deleted, err := g.achievementRepo.FindDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, achievement := range deleted {
+		for _, doc := range achievement.Documents {
+			if err := utils.DeleteFile(doc.Filepath); err != nil && !os.IsNotExist(err) {
+				log.Printf("Gagal menghapus file %s: %v", doc.Filepath, err)
+				continue
+			}
+			if err := utils.DeleteFile(doc.ThumbnailPath); err != nil && !os.IsNotExist(err) {
+				log.Printf("Gagal menghapus thumbnail %s: %v", doc.ThumbnailPath, err)
+			}
+			result.PurgedFiles++
+		}
+		if err := g.achievementRepo.ClearDocuments(ctx, achievement.AchievementID); err != nil {
+			return nil, err
+		}
+		result.PurgedRecords++
+	}
+
+	// Step 2: Hapus file di folder upload yang tidak direferensikan dokumen manapun
+	paths, err := g.achievementRepo.FindAllDocumentPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(paths))
+	for path := range paths {
+		referenced[filepath.Clean(path)] = true
+	}
+
+	graceCutoff := time.Now().Add(-g.config.OrphanGracePeriod)
+	err = filepath.WalkDir(g.uploadConfig.UploadPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(graceCutoff) {
+			return nil
+		}
+
+		if referenced[filepath.Clean(path)] {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			log.Printf("Gagal menghapus file orphan %s: %v", path, err)
+			return nil
+		}
+		result.OrphansRemoved++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Hapus session upload bertahap yang kedaluwarsa beserta file sementaranya
+	expired, err := g.sessionRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range expired {
+		removePartialUpload(session.PartialPath)
+		if err := g.sessionRepo.Delete(ctx, session.UploadID); err != nil {
+			return nil, err
+		}
+		result.ExpiredUploads++
+	}
+
+	// Step 4: Hapus job export yang kedaluwarsa beserta file hasilnya
+	expiredExports, err := g.exportJobRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range expiredExports {
+		if err := utils.DeleteFile(job.Filepath); err != nil && !os.IsNotExist(err) {
+			log.Printf("Gagal menghapus file export %s: %v", job.Filepath, err)
+			continue
+		}
+		if err := g.exportJobRepo.Delete(ctx, job.JobID); err != nil {
+			return nil, err
+		}
+		result.ExpiredExports++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"database/sql"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// QuarantineRescanner men-scan ulang dokumen yang masih di karantina,
+// misalnya karena clamd tidak tersedia saat file diupload
+type QuarantineRescanner struct {
+	achievementRepo *repository.AchievementRepository
+	projector       *AchievementProjector
+	uploadConfig    utils.FileUploadConfig
+	interval        time.Duration
+}
+
+func NewQuarantineRescanner(mongoDB *mongo.Database, postgresDB *sql.DB, cfg *config.Config) *QuarantineRescanner {
+	return &QuarantineRescanner{
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+		projector:       NewAchievementProjector(mongoDB, postgresDB),
+		uploadConfig:    FileUploadConfig(cfg.Upload),
+		interval:        cfg.Upload.RescanInterval,
+	}
+}
+
+// Start menjalankan rescan secara periodik di background
+func (q *QuarantineRescanner) Start() {
+	go func() {
+		ticker := time.NewTicker(q.interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := q.RunOnce(context.Background()); err != nil {
+				log.Printf("Rescan karantina gagal: %v", err)
+			}
+		}
+	}()
+}
+
+// RunOnce men-scan semua dokumen berstatus pending
+func (q *QuarantineRescanner) RunOnce(ctx context.Context) error {
+	achievements, err := q.achievementRepo.FindByDocumentScanStatus(ctx, utils.ScanStatusPending)
+	if err != nil {
+		return err
+	}
+
+	for _, achievement := range achievements {
+		for _, doc := range achievement.Documents {
+			if doc.ScanStatus != utils.ScanStatusPending {
+				continue
+			}
+
+			scan, err := utils.ScanQuarantinedFile(doc.Filepath, doc.Mimetype, q.uploadConfig)
+			if err != nil {
+				// Scanner masih belum tersedia, coba lagi di siklus berikutnya
+				log.Printf("Gagal scan dokumen %s: %v", doc.Filepath, err)
+				return nil
+			}
+
+			if scan.ScanStatus == utils.ScanStatusInfected {
+				log.Printf("Dokumen %s pada achievement %s terdeteksi malware: %s",
+					doc.Filename, achievement.AchievementID, scan.ScanSignature)
+			}
+
+			if err := q.achievementRepo.UpdateDocumentScan(ctx, achievement.AchievementID, doc.ID, scan); err != nil {
+				return err
+			}
+			refreshReadModel(q.projector, achievement.AchievementID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"database/sql"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ScoringRubricService mengelola rubric poin kredit achievement. Setiap perubahan
+// rubric memicu perhitungan ulang poin semua achievement yang sudah diverifikasi.
+type ScoringRubricService struct {
+	rubricRepo      *repository.ScoringRubricRepository
+	achievementRepo *repository.AchievementRepository
+	projector       *AchievementProjector
+}
+
+// recomputeMu mencegah dua perhitungan ulang poin berjalan bersamaan
+var recomputeMu sync.Mutex
+
+func NewScoringRubricService(db *sql.DB, mongoDB *mongo.Database) *ScoringRubricService {
+	return &ScoringRubricService{
+		rubricRepo:      repository.NewScoringRubricRepository(db),
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+		projector:       NewAchievementProjector(mongoDB, db),
+	}
+}
+
+// GetRubrics godoc
+// @Summary Get scoring rubrics
+// @Description List the credit point rubric per achievement category and level. Category "*" applies to every category of that level unless a more specific rubric exists.
+// @Tags Scoring Rubrics
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,message=string,data=[]models.ScoringRubric} "Rubrics retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve rubrics"
+// @Router /rubrics [get]
+func (s *ScoringRubricService) GetRubrics(c *fiber.Ctx) error {
+	rubrics, err := s.rubricRepo.FindAll()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil rubric penilaian",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Rubric penilaian berhasil diambil",
+		"data":    rubrics,
+	})
+}
+
+// CreateRubric godoc
+// @Summary Create scoring rubric
+// @Description Admin creates a credit point rubric for an achievement category and level. Points of all verified achievements are recomputed in the background.
+// @Tags Scoring Rubrics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ScoringRubricRequest true "Category (or * for any category), level and points"
+// @Success 201 {object} object{status=string,message=string,data=models.ScoringRubric} "Rubric created, recompute started"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 409 {object} map[string]interface{} "Rubric for this category and level already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to create rubric"
+// @Router /rubrics [post]
+func (s *ScoringRubricService) CreateRubric(c *fiber.Ctx) error {
+	var req models.ScoringRubricRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	rubric := &models.ScoringRubric{}
+	if status, message := s.applyRubricRequest(rubric, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.rubricRepo.Create(rubric); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuat rubric penilaian",
+		})
+	}
+	s.recomputeInBackground()
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Rubric penilaian berhasil dibuat, poin sedang dihitung ulang",
+		"data":    rubric,
+	})
+}
+
+// UpdateRubric godoc
+// @Summary Update scoring rubric
+// @Description Admin changes the category, level or points of a rubric. Points of all verified achievements are recomputed in the background.
+// @Tags Scoring Rubrics
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rubric ID"
+// @Param request body models.ScoringRubricRequest true "Category (or * for any category), level and points"
+// @Success 200 {object} object{status=string,message=string,data=models.ScoringRubric} "Rubric updated, recompute started"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 409 {object} map[string]interface{} "Rubric for this category and level already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to update rubric"
+// @Router /rubrics/{id} [put]
+func (s *ScoringRubricService) UpdateRubric(c *fiber.Ctx) error {
+	var req models.ScoringRubricRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	rubric, err := s.rubricRepo.FindByID(c.Params("id"))
+	if err != nil || rubric == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Rubric penilaian tidak ditemukan",
+		})
+	}
+
+	if status, message := s.applyRubricRequest(rubric, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.rubricRepo.Update(rubric); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengupdate rubric penilaian",
+		})
+	}
+	s.recomputeInBackground()
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Rubric penilaian berhasil diupdate, poin sedang dihitung ulang",
+		"data":    rubric,
+	})
+}
+
+// DeleteRubric godoc
+// @Summary Delete scoring rubric
+// @Description Admin deletes a rubric. Points of all verified achievements are recomputed in the background.
+// @Tags Scoring Rubrics
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rubric ID"
+// @Success 200 {object} object{status=string,message=string} "Rubric deleted, recompute started"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 500 {object} map[string]interface{} "Failed to delete rubric"
+// @Router /rubrics/{id} [delete]
+func (s *ScoringRubricService) DeleteRubric(c *fiber.Ctx) error {
+	rubric, err := s.rubricRepo.FindByID(c.Params("id"))
+	if err != nil || rubric == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Rubric penilaian tidak ditemukan",
+		})
+	}
+
+	if err := s.rubricRepo.Delete(rubric.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghapus rubric penilaian",
+		})
+	}
+	s.recomputeInBackground()
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Rubric penilaian berhasil dihapus, poin sedang dihitung ulang",
+	})
+}
+
+// RecomputePoints menghitung ulang poin semua achievement verified dengan rubric saat ini.
+// Hanya achievement yang poinnya berubah yang disimpan dan diproyeksikan ulang.
+func (s *ScoringRubricService) RecomputePoints(ctx context.Context) (int, error) {
+	recomputeMu.Lock()
+	defer recomputeMu.Unlock()
+
+	rubrics, err := s.rubricRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+	achievements, err := s.achievementRepo.FindAll(ctx, bson.M{"status": "verified", "is_deleted": false})
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, achievement := range achievements {
+		points := rubrics.Points(achievement.Category, achievement.Level)
+		if points == achievement.Points {
+			continue
+		}
+		if err := s.achievementRepo.UpdatePoints(ctx, achievement.AchievementID, points); err != nil {
+			return changed, err
+		}
+		refreshReadModel(s.projector, achievement.AchievementID)
+		changed++
+	}
+	return changed, nil
+}
+
+func (s *ScoringRubricService) recomputeInBackground() {
+	go func() {
+		changed, err := s.RecomputePoints(context.Background())
+		if err != nil {
+			log.Printf("Gagal menghitung ulang poin achievement: %v", err)
+			return
+		}
+		log.Printf("Poin achievement dihitung ulang: %d achievement berubah", changed)
+	}()
+}
+
+// applyRubricRequest memvalidasi request lalu menyalinnya ke rubric.
+// Mengembalikan status HTTP dan pesan jika request tidak valid.
+func (s *ScoringRubricService) applyRubricRequest(rubric *models.ScoringRubric, req *models.ScoringRubricRequest) (int, string) {
+	category := strings.TrimSpace(req.Category)
+	level := strings.TrimSpace(req.Level)
+	if category == "" || level == "" {
+		return 400, "category dan level wajib diisi"
+	}
+	if req.Points < 0 {
+		return 400, "points tidak boleh negatif"
+	}
+
+	exists, err := s.rubricRepo.ExistsByCategoryLevel(category, level, rubric.ID)
+	if err != nil {
+		return 500, "Gagal memeriksa rubric penilaian"
+	}
+	if exists {
+		return 409, "Rubric untuk kategori dan level ini sudah ada"
+	}
+
+	rubric.Category = category
+	rubric.Level = level
+	rubric.Points = req.Points
+	return 0, ""
+}
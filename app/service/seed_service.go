@@ -0,0 +1,189 @@
+package service
+
+import (
+	"crud-app/app/middleware"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"database/sql"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role bawaan aplikasi. ID dipakai langsung oleh kode, jangan diubah.
+const (
+	RoleAdmin    = "1"
+	RoleLecturer = "2"
+	RoleStudent  = "3"
+)
+
+// DefaultRoles role yang dibuat command seed
+var DefaultRoles = []models.SeedRole{
+	{ID: RoleAdmin, Name: "Admin", Description: "Administrator sistem, memiliki semua permission"},
+	{ID: RoleLecturer, Name: "Dosen Wali", Description: "Dosen wali yang memverifikasi achievement mahasiswa bimbingannya"},
+	{ID: RoleStudent, Name: "Mahasiswa", Description: "Mahasiswa yang melaporkan achievement"},
+}
+
+// DefaultRoleGrants permission bawaan per role. Admin selalu mendapat semua permission
+// di katalog sehingga tidak dicantumkan di sini.
+var DefaultRoleGrants = map[string][]string{
+	RoleLecturer: {"achievements.read", "achievements.verify", "students.read", "lecturers.read"},
+	RoleStudent:  {"achievements.read", "achievements.create", "achievements.update", "achievements.delete"},
+}
+
+// permissionDescriptions deskripsi permission yang tampil di database
+var permissionDescriptions = map[string]string{
+	"users.read":              "Melihat daftar dan detail user",
+	"users.create":            "Membuat user baru, termasuk import massal",
+	"users.update":            "Mengubah data user",
+	"users.delete":            "Menghapus user",
+	"users.assign_role":       "Mengubah role user",
+	"achievements.read":       "Melihat achievement dan laporan",
+	"achievements.create":     "Membuat, submit dan mengupload dokumen achievement",
+	"achievements.update":     "Mengubah achievement dan dokumennya",
+	"achievements.delete":     "Menghapus achievement",
+	"achievements.verify":     "Memverifikasi atau menolak achievement",
+	"students.read":           "Melihat daftar dan detail mahasiswa",
+	"students.assign_advisor": "Menetapkan dosen wali mahasiswa",
+	"lecturers.read":          "Melihat daftar dosen dan mahasiswa bimbingannya",
+}
+
+var permissionNamePattern = regexp.MustCompile(`^[a-z][a-z_]*\.[a-z][a-z_]*$`)
+
+// SeedAdminMinPasswordLength panjang minimal password admin awal
+const SeedAdminMinPasswordLength = 8
+
+// SeedService mengisi data awal: role, katalog permission, grant bawaan dan admin awal
+type SeedService struct {
+	seedRepo *repository.SeedRepository
+}
+
+func NewSeedService(db *sql.DB) *SeedService {
+	return &SeedService{
+		seedRepo: repository.NewSeedRepository(db),
+	}
+}
+
+// Seed membuat role, permission (dari middleware.RequiredPermissions, jadi route harus
+// sudah didaftarkan), grant bawaan dan admin awal. Aman dijalankan berulang kali.
+func (s *SeedService) Seed(admin *models.User) (*models.SeedReport, error) {
+	catalog, err := BuildPermissionCatalog(middleware.RequiredPermissions())
+	if err != nil {
+		return nil, err
+	}
+	if len(catalog) == 0 {
+		return nil, fmt.Errorf("katalog permission kosong, route belum didaftarkan")
+	}
+	grants, err := BuildRoleGrants(catalog)
+	if err != nil {
+		return nil, err
+	}
+	return s.seedRepo.Seed(DefaultRoles, catalog, grants, admin)
+}
+
+// BuildPermissionCatalog membuat katalog permission dari nama berformat resource.action
+func BuildPermissionCatalog(names []string) ([]models.Permissions, error) {
+	seen := make(map[string]bool, len(names))
+	catalog := make([]models.Permissions, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if !permissionNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("nama permission %q tidak valid, gunakan format resource.action", name)
+		}
+
+		resource, action, _ := strings.Cut(name, ".")
+		description, ok := permissionDescriptions[name]
+		if !ok {
+			description = fmt.Sprintf("Akses %s pada %s", strings.ReplaceAll(action, "_", " "), resource)
+		}
+		catalog = append(catalog, models.Permissions{
+			ID:          uuid.New(),
+			Name:        name,
+			Resource:    resource,
+			Action:      action,
+			Description: description,
+		})
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+	return catalog, nil
+}
+
+// BuildRoleGrants membuat grant bawaan: admin mendapat semua permission di katalog,
+// role lain sesuai DefaultRoleGrants. Error jika grant bawaan memakai permission yang
+// tidak lagi dipakai route.
+func BuildRoleGrants(catalog []models.Permissions) (map[string][]string, error) {
+	known := make(map[string]bool, len(catalog))
+	all := make([]string, 0, len(catalog))
+	for _, permission := range catalog {
+		known[permission.Name] = true
+		all = append(all, permission.Name)
+	}
+
+	grants := map[string][]string{RoleAdmin: all}
+	for roleID, names := range DefaultRoleGrants {
+		for _, name := range names {
+			if !known[name] {
+				return nil, fmt.Errorf("permission %q untuk role %s tidak dipakai route manapun", name, roleID)
+			}
+		}
+		grants[roleID] = names
+	}
+	return grants, nil
+}
+
+// SeedAdminFromConfig membuat admin awal dari SEED_ADMIN_USERNAME, SEED_ADMIN_EMAIL,
+// SEED_ADMIN_PASSWORD dan SEED_ADMIN_FULL_NAME (opsional). Mengembalikan nil jika
+// semua kosong sehingga seed hanya membuat role dan permission.
+func SeedAdminFromConfig(cfg config.SeedConfig) (*models.User, error) {
+	username := strings.TrimSpace(cfg.AdminUsername)
+	email := strings.TrimSpace(cfg.AdminEmail)
+	password := cfg.AdminPassword.Value()
+	fullName := strings.TrimSpace(cfg.AdminFullName)
+	if username == "" && email == "" && password == "" {
+		return nil, nil
+	}
+
+	var errs []string
+	if username == "" {
+		errs = append(errs, "SEED_ADMIN_USERNAME wajib diisi")
+	}
+	if _, err := mail.ParseAddress(email); err != nil || !strings.Contains(email, "@") {
+		errs = append(errs, "SEED_ADMIN_EMAIL tidak valid")
+	}
+	if len(password) < SeedAdminMinPasswordLength {
+		errs = append(errs, fmt.Sprintf("SEED_ADMIN_PASSWORD minimal %d karakter", SeedAdminMinPasswordLength))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	if fullName == "" {
+		fullName = "Administrator"
+	}
+
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &models.User{
+		ID:           uuid.New().String(),
+		Username:     username,
+		Email:        email,
+		PasswordHash: passwordHash,
+		FullName:     fullName,
+		RoleID:       RoleAdmin,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
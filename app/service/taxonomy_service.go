@@ -0,0 +1,767 @@
+package service
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"database/sql"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TaxonomyService mengelola master data kategori dan level achievement serta
+// migrasi nilai free-text lama di MongoDB ke taxonomy
+type TaxonomyService struct {
+	taxonomyRepo    *repository.TaxonomyRepository
+	rubricRepo      *repository.ScoringRubricRepository
+	achievementRepo *repository.AchievementRepository
+	rubricService   *ScoringRubricService
+	projector       *AchievementProjector
+}
+
+// taxonomyMigrateMu mencegah dua migrasi taxonomy berjalan bersamaan
+var taxonomyMigrateMu sync.Mutex
+
+func NewTaxonomyService(db *sql.DB, mongoDB *mongo.Database) *TaxonomyService {
+	return &TaxonomyService{
+		taxonomyRepo:    repository.NewTaxonomyRepository(db),
+		rubricRepo:      repository.NewScoringRubricRepository(db),
+		achievementRepo: repository.NewAchievementRepository(mongoDB),
+		rubricService:   NewScoringRubricService(db, mongoDB),
+		projector:       NewAchievementProjector(mongoDB, db),
+	}
+}
+
+// GetCategories godoc
+// @Summary Get achievement categories
+// @Description List the achievement category master data. Categories may have a parent category (parent_id) to form a hierarchy. Submissions must use a category name, code or alias from this list.
+// @Tags Taxonomy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,message=string,data=[]models.AchievementCategory} "Categories retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve categories"
+// @Router /taxonomy/categories [get]
+func (s *TaxonomyService) GetCategories(c *fiber.Ctx) error {
+	categories, err := s.taxonomyRepo.FindCategories()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil kategori achievement",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Kategori achievement berhasil diambil",
+		"data":    categories,
+	})
+}
+
+// CreateCategory godoc
+// @Summary Create achievement category
+// @Description Admin creates an achievement category. Code defaults to a slug of the name. Aliases are alternative spellings that are mapped to this category on submission and migration. details_schema is an optional JSON Schema (draft 2020-12, "type": "object") for the structured details of achievements in this category; sub-categories without a schema use the schema of their nearest parent.
+// @Tags Taxonomy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AchievementCategoryRequest true "Category code, name, description, parent, aliases and details schema"
+// @Success 201 {object} object{status=string,message=string,data=models.AchievementCategory} "Category created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request, invalid details_schema, unknown parent or parent cycle"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 409 {object} map[string]interface{} "Category code or name already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to create category"
+// @Router /taxonomy/categories [post]
+func (s *TaxonomyService) CreateCategory(c *fiber.Ctx) error {
+	var req models.AchievementCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	category := &models.AchievementCategory{}
+	if status, message := s.applyCategoryRequest(category, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.taxonomyRepo.CreateCategory(category); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuat kategori achievement",
+		})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Kategori achievement berhasil dibuat",
+		"data":    category,
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Update achievement category
+// @Description Admin changes an achievement category, including its details_schema. A new schema applies to submissions and updates from now on; existing achievements are not revalidated. When the name changes the old name is kept as an alias, scoring rubrics are renamed and existing achievements are migrated to the new name in the background.
+// @Tags Taxonomy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param request body models.AchievementCategoryRequest true "Category code, name, description, parent, aliases and details schema"
+// @Success 200 {object} object{status=string,message=string,data=models.AchievementCategory} "Category updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request, invalid details_schema, unknown parent or parent cycle"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Failure 409 {object} map[string]interface{} "Category code or name already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to update category"
+// @Router /taxonomy/categories/{id} [put]
+func (s *TaxonomyService) UpdateCategory(c *fiber.Ctx) error {
+	var req models.AchievementCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	category, err := s.taxonomyRepo.FindCategoryByID(c.Params("id"))
+	if err != nil || category == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Kategori achievement tidak ditemukan",
+		})
+	}
+
+	oldName := category.Name
+	if status, message := s.applyCategoryRequest(category, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+	renamed := category.Name != oldName
+	if renamed {
+		category.Aliases = appendTaxonomyAlias(category.Aliases, oldName, category.Name)
+	}
+
+	if err := s.taxonomyRepo.UpdateCategory(category); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengupdate kategori achievement",
+		})
+	}
+	if renamed {
+		s.renameInBackground("category", oldName, category.Name)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Kategori achievement berhasil diupdate",
+		"data":    category,
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Delete achievement category
+// @Description Admin deletes an achievement category. Categories that still have sub-categories or are used by achievements cannot be deleted.
+// @Tags Taxonomy
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Success 200 {object} object{status=string,message=string} "Category deleted successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Failure 409 {object} map[string]interface{} "Category has sub-categories or is used by achievements"
+// @Failure 500 {object} map[string]interface{} "Failed to delete category"
+// @Router /taxonomy/categories/{id} [delete]
+func (s *TaxonomyService) DeleteCategory(c *fiber.Ctx) error {
+	category, err := s.taxonomyRepo.FindCategoryByID(c.Params("id"))
+	if err != nil || category == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Kategori achievement tidak ditemukan",
+		})
+	}
+
+	children, err := s.taxonomyRepo.CountChildCategories(category.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghapus kategori achievement",
+		})
+	}
+	if children > 0 {
+		return c.Status(409).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Kategori masih memiliki sub-kategori",
+		})
+	}
+
+	if status, message := s.checkTaxonomyUnused("category", category.Name); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.taxonomyRepo.DeleteCategory(category.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghapus kategori achievement",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Kategori achievement berhasil dihapus",
+	})
+}
+
+// GetLevels godoc
+// @Summary Get achievement levels
+// @Description List the achievement level master data ordered by sort_order. Submissions must use a level name, code or alias from this list.
+// @Tags Taxonomy
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{status=string,message=string,data=[]models.AchievementLevel} "Levels retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires achievements.read)"
+// @Failure 500 {object} map[string]interface{} "Failed to retrieve levels"
+// @Router /taxonomy/levels [get]
+func (s *TaxonomyService) GetLevels(c *fiber.Ctx) error {
+	levels, err := s.taxonomyRepo.FindLevels()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengambil level achievement",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Level achievement berhasil diambil",
+		"data":    levels,
+	})
+}
+
+// CreateLevel godoc
+// @Summary Create achievement level
+// @Description Admin creates an achievement level. Code defaults to a slug of the name; sort_order controls the display order (lowest first).
+// @Tags Taxonomy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AchievementLevelRequest true "Level code, name, description, sort order and aliases"
+// @Success 201 {object} object{status=string,message=string,data=models.AchievementLevel} "Level created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 409 {object} map[string]interface{} "Level code or name already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to create level"
+// @Router /taxonomy/levels [post]
+func (s *TaxonomyService) CreateLevel(c *fiber.Ctx) error {
+	var req models.AchievementLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	level := &models.AchievementLevel{}
+	if status, message := s.applyLevelRequest(level, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.taxonomyRepo.CreateLevel(level); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuat level achievement",
+		})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Level achievement berhasil dibuat",
+		"data":    level,
+	})
+}
+
+// UpdateLevel godoc
+// @Summary Update achievement level
+// @Description Admin changes an achievement level. When the name changes the old name is kept as an alias, scoring rubrics are renamed and existing achievements are migrated to the new name in the background.
+// @Tags Taxonomy
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Level ID"
+// @Param request body models.AchievementLevelRequest true "Level code, name, description, sort order and aliases"
+// @Success 200 {object} object{status=string,message=string,data=models.AchievementLevel} "Level updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Level not found"
+// @Failure 409 {object} map[string]interface{} "Level code or name already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to update level"
+// @Router /taxonomy/levels/{id} [put]
+func (s *TaxonomyService) UpdateLevel(c *fiber.Ctx) error {
+	var req models.AchievementLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid request body",
+		})
+	}
+
+	level, err := s.taxonomyRepo.FindLevelByID(c.Params("id"))
+	if err != nil || level == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Level achievement tidak ditemukan",
+		})
+	}
+
+	oldName := level.Name
+	if status, message := s.applyLevelRequest(level, &req); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+	renamed := level.Name != oldName
+	if renamed {
+		level.Aliases = appendTaxonomyAlias(level.Aliases, oldName, level.Name)
+	}
+
+	if err := s.taxonomyRepo.UpdateLevel(level); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengupdate level achievement",
+		})
+	}
+	if renamed {
+		s.renameInBackground("level", oldName, level.Name)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Level achievement berhasil diupdate",
+		"data":    level,
+	})
+}
+
+// DeleteLevel godoc
+// @Summary Delete achievement level
+// @Description Admin deletes an achievement level. Levels used by achievements cannot be deleted.
+// @Tags Taxonomy
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Level ID"
+// @Success 200 {object} object{status=string,message=string} "Level deleted successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 404 {object} map[string]interface{} "Level not found"
+// @Failure 409 {object} map[string]interface{} "Level is used by achievements"
+// @Failure 500 {object} map[string]interface{} "Failed to delete level"
+// @Router /taxonomy/levels/{id} [delete]
+func (s *TaxonomyService) DeleteLevel(c *fiber.Ctx) error {
+	level, err := s.taxonomyRepo.FindLevelByID(c.Params("id"))
+	if err != nil || level == nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Level achievement tidak ditemukan",
+		})
+	}
+
+	if status, message := s.checkTaxonomyUnused("level", level.Name); status != 0 {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": message,
+		})
+	}
+
+	if err := s.taxonomyRepo.DeleteLevel(level.ID); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal menghapus level achievement",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Level achievement berhasil dihapus",
+	})
+}
+
+// MigrateTaxonomy godoc
+// @Summary Migrate free-text categories and levels
+// @Description Admin maps the free-text category and level values of existing achievements onto the taxonomy by name, code or alias (case and whitespace insensitive). With create_missing, values that cannot be mapped are added to the taxonomy using their most common spelling. With dry_run, nothing is changed and only the report is returned. Points are recomputed afterwards.
+// @Tags Taxonomy
+// @Produce json
+// @Security BearerAuth
+// @Param dry_run query bool false "Only report the mapping" default(false)
+// @Param create_missing query bool false "Create taxonomy entries for unmapped values" default(false)
+// @Success 200 {object} object{status=string,message=string,data=models.TaxonomyMigrationReport} "Migration report"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - invalid or missing JWT token"
+// @Failure 403 {object} map[string]interface{} "Admin only"
+// @Failure 500 {object} map[string]interface{} "Migration failed"
+// @Router /taxonomy/migrate [post]
+func (s *TaxonomyService) MigrateTaxonomy(c *fiber.Ctx) error {
+	report, err := s.MigrateAchievements(context.Background(), c.QueryBool("create_missing"), c.QueryBool("dry_run"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal migrasi kategori dan level achievement",
+		})
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Migrasi kategori dan level achievement selesai",
+		"data":    report,
+	})
+}
+
+// MigrateAchievements memetakan nilai category dan level achievement di MongoDB ke
+// nama baku taxonomy. Nilai yang tidak bisa dipetakan dibuat sebagai taxonomy baru
+// jika createMissing, selain itu dilaporkan sebagai unmapped. Dengan dryRun tidak
+// ada data yang diubah.
+func (s *TaxonomyService) MigrateAchievements(ctx context.Context, createMissing, dryRun bool) (*models.TaxonomyMigrationReport, error) {
+	taxonomyMigrateMu.Lock()
+	defer taxonomyMigrateMu.Unlock()
+
+	taxonomy, err := s.taxonomyRepo.FindTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.TaxonomyMigrationReport{
+		DryRun:   dryRun,
+		Mappings: []models.TaxonomyMapping{},
+		Unmapped: []models.TaxonomyMapping{},
+	}
+	for _, field := range []string{"category", "level"} {
+		if err := s.migrateField(ctx, taxonomy, field, createMissing, dryRun, report); err != nil {
+			return report, err
+		}
+	}
+
+	// Nama baku bisa mengubah rubric yang cocok, hitung ulang poin
+	if !dryRun && len(report.Mappings) > 0 {
+		if _, err := s.rubricService.RecomputePoints(ctx); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (s *TaxonomyService) migrateField(ctx context.Context, taxonomy *models.Taxonomy, field string, createMissing, dryRun bool, report *models.TaxonomyMigrationReport) error {
+	values, err := s.achievementRepo.DistinctValues(ctx, field)
+	if err != nil {
+		return err
+	}
+	sort.Strings(values)
+
+	counts := make(map[string]int64, len(values))
+	for _, value := range values {
+		if counts[value], err = s.achievementRepo.CountByValue(ctx, field, value); err != nil {
+			return err
+		}
+	}
+
+	// Kelompokkan nilai yang belum ada di taxonomy berdasarkan penulisan yang dinormalisasi
+	unresolved := make(map[string][]string)
+	var unresolvedKeys []string
+	for _, value := range values {
+		name, ok := resolveTaxonomyName(taxonomy, field, value)
+		if ok {
+			if err := s.applyMapping(ctx, field, value, name, counts[value], false, dryRun, report); err != nil {
+				return err
+			}
+			continue
+		}
+		key := models.NormalizeTaxonomyValue(value)
+		if key == "" {
+			continue
+		}
+		if _, seen := unresolved[key]; !seen {
+			unresolvedKeys = append(unresolvedKeys, key)
+		}
+		unresolved[key] = append(unresolved[key], value)
+	}
+
+	for _, key := range unresolvedKeys {
+		spellings := unresolved[key]
+		name, created := "", false
+		if createMissing {
+			name, created, err = s.createTaxonomyEntry(taxonomy, field, mostCommonSpelling(spellings, counts), dryRun)
+			if err != nil {
+				return err
+			}
+		}
+		for _, value := range spellings {
+			if name == "" {
+				report.Unmapped = append(report.Unmapped, models.TaxonomyMapping{
+					Field:        field,
+					From:         value,
+					Achievements: counts[value],
+				})
+				continue
+			}
+			if err := s.applyMapping(ctx, field, value, name, counts[value], created, dryRun, report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyMapping mengganti nilai lama dengan nama baku lalu memperbarui read model
+func (s *TaxonomyService) applyMapping(ctx context.Context, field, from, to string, count int64, created, dryRun bool, report *models.TaxonomyMigrationReport) error {
+	if from == to && !created {
+		return nil
+	}
+	report.Mappings = append(report.Mappings, models.TaxonomyMapping{
+		Field:        field,
+		From:         from,
+		To:           to,
+		Achievements: count,
+		Created:      created,
+	})
+	if dryRun || from == to {
+		return nil
+	}
+
+	achievementIDs, err := s.achievementRepo.ReplaceValue(ctx, field, from, to)
+	if err != nil {
+		return err
+	}
+	for _, achievementID := range achievementIDs {
+		refreshReadModel(s.projector, achievementID)
+	}
+	return nil
+}
+
+// createTaxonomyEntry membuat kategori atau level baru dari nilai free-text.
+// Jika kode hasil nama sudah dipakai, nilai dibiarkan tidak terpetakan.
+func (s *TaxonomyService) createTaxonomyEntry(taxonomy *models.Taxonomy, field, value string, dryRun bool) (string, bool, error) {
+	name := strings.Join(strings.Fields(value), " ")
+	code := models.TaxonomyCode(name)
+	if code == "" {
+		return "", false, nil
+	}
+
+	if field == "category" {
+		exists, err := s.taxonomyRepo.ExistsCategory(code, name, "")
+		if err != nil || exists {
+			return "", false, err
+		}
+		category := models.AchievementCategory{Code: code, Name: name}
+		if !dryRun {
+			if err := s.taxonomyRepo.CreateCategory(&category); err != nil {
+				return "", false, err
+			}
+		}
+		taxonomy.Categories = append(taxonomy.Categories, category)
+		return name, true, nil
+	}
+
+	exists, err := s.taxonomyRepo.ExistsLevel(code, name, "")
+	if err != nil || exists {
+		return "", false, err
+	}
+	level := models.AchievementLevel{Code: code, Name: name, SortOrder: len(taxonomy.Levels) + 1}
+	if !dryRun {
+		if err := s.taxonomyRepo.CreateLevel(&level); err != nil {
+			return "", false, err
+		}
+	}
+	taxonomy.Levels = append(taxonomy.Levels, level)
+	return name, true, nil
+}
+
+// renameInBackground menyesuaikan rubric dan achievement setelah nama kategori
+// atau level diubah. Nama lama sudah menjadi alias sehingga ikut terpetakan.
+func (s *TaxonomyService) renameInBackground(field, from, to string) {
+	go func() {
+		if err := s.rubricRepo.RenameValue(field, from, to); err != nil {
+			log.Printf("Gagal mengganti %s rubric %q menjadi %q: %v", field, from, to, err)
+		}
+		report, err := s.MigrateAchievements(context.Background(), false, false)
+		if err != nil {
+			log.Printf("Gagal migrasi taxonomy achievement: %v", err)
+			return
+		}
+		log.Printf("Taxonomy achievement dimigrasi: %d nilai dipetakan", len(report.Mappings))
+	}()
+}
+
+// checkTaxonomyUnused memastikan kategori atau level tidak dipakai achievement aktif
+func (s *TaxonomyService) checkTaxonomyUnused(field, name string) (int, string) {
+	count, err := s.achievementRepo.CountActiveByValueIgnoreCase(context.Background(), field, name)
+	if err != nil {
+		return 500, "Gagal memeriksa pemakaian taxonomy"
+	}
+	if count > 0 {
+		return 409, "Masih dipakai oleh achievement, ubah atau gabungkan terlebih dahulu"
+	}
+	return 0, ""
+}
+
+// applyCategoryRequest memvalidasi request lalu menyalinnya ke kategori.
+// Mengembalikan status HTTP dan pesan jika request tidak valid.
+func (s *TaxonomyService) applyCategoryRequest(category *models.AchievementCategory, req *models.AchievementCategoryRequest) (int, string) {
+	name := strings.Join(strings.Fields(req.Name), " ")
+	if name == "" {
+		return 400, "name wajib diisi"
+	}
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		code = models.TaxonomyCode(name)
+	}
+	if code == "" {
+		return 400, "code wajib diisi"
+	}
+
+	var parentID *string
+	if req.ParentID != nil && strings.TrimSpace(*req.ParentID) != "" {
+		id := strings.TrimSpace(*req.ParentID)
+		categories, err := s.taxonomyRepo.FindCategories()
+		if err != nil {
+			return 500, "Gagal memeriksa kategori induk"
+		}
+		found := false
+		for _, existing := range categories {
+			if existing.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 400, "Kategori induk tidak ditemukan"
+		}
+		if category.ID != "" && models.CategoryParentCreatesCycle(categories, category.ID, id) {
+			return 400, "Kategori induk tidak boleh kategori itu sendiri atau sub-kategorinya"
+		}
+		parentID = &id
+	}
+
+	// JSON Schema details harus valid dan bertipe object, null berarti tanpa schema
+	var detailsSchema []byte
+	if raw := strings.TrimSpace(string(req.DetailsSchema)); raw != "" && raw != "null" {
+		if _, err := utils.CompileDetailsSchema(req.DetailsSchema); err != nil {
+			return 400, err.Error()
+		}
+		detailsSchema = req.DetailsSchema
+	}
+
+	exists, err := s.taxonomyRepo.ExistsCategory(code, name, category.ID)
+	if err != nil {
+		return 500, "Gagal memeriksa kategori achievement"
+	}
+	if exists {
+		return 409, "Kode atau nama kategori sudah dipakai"
+	}
+
+	category.Code = code
+	category.Name = name
+	category.Description = strings.TrimSpace(req.Description)
+	category.ParentID = parentID
+	category.Aliases = cleanTaxonomyAliases(req.Aliases, name)
+	category.DetailsSchema = detailsSchema
+	return 0, ""
+}
+
+// applyLevelRequest memvalidasi request lalu menyalinnya ke level.
+// Mengembalikan status HTTP dan pesan jika request tidak valid.
+func (s *TaxonomyService) applyLevelRequest(level *models.AchievementLevel, req *models.AchievementLevelRequest) (int, string) {
+	name := strings.Join(strings.Fields(req.Name), " ")
+	if name == "" {
+		return 400, "name wajib diisi"
+	}
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		code = models.TaxonomyCode(name)
+	}
+	if code == "" {
+		return 400, "code wajib diisi"
+	}
+
+	exists, err := s.taxonomyRepo.ExistsLevel(code, name, level.ID)
+	if err != nil {
+		return 500, "Gagal memeriksa level achievement"
+	}
+	if exists {
+		return 409, "Kode atau nama level sudah dipakai"
+	}
+
+	level.Code = code
+	level.Name = name
+	level.Description = strings.TrimSpace(req.Description)
+	level.SortOrder = req.SortOrder
+	level.Aliases = cleanTaxonomyAliases(req.Aliases, name)
+	return 0, ""
+}
+
+// resolveTaxonomyName mencari nama baku kategori atau level dari nilai free-text
+func resolveTaxonomyName(taxonomy *models.Taxonomy, field, value string) (string, bool) {
+	if field == "category" {
+		if category, ok := taxonomy.ResolveCategory(value); ok {
+			return category.Name, true
+		}
+		return "", false
+	}
+	if level, ok := taxonomy.ResolveLevel(value); ok {
+		return level.Name, true
+	}
+	return "", false
+}
+
+// mostCommonSpelling memilih penulisan yang paling banyak dipakai achievement
+func mostCommonSpelling(spellings []string, counts map[string]int64) string {
+	best := spellings[0]
+	for _, spelling := range spellings[1:] {
+		if counts[spelling] > counts[best] {
+			best = spelling
+		}
+	}
+	return best
+}
+
+// cleanTaxonomyAliases merapikan alias: kosong, duplikat dan yang sama dengan nama dibuang
+func cleanTaxonomyAliases(aliases []string, name string) []string {
+	seen := map[string]bool{models.NormalizeTaxonomyValue(name): true}
+	cleaned := []string{}
+	for _, alias := range aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		key := models.NormalizeTaxonomyValue(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, alias)
+	}
+	return cleaned
+}
+
+// appendTaxonomyAlias menyimpan nama lama sebagai alias setelah kategori atau level diganti nama
+func appendTaxonomyAlias(aliases []string, oldName, newName string) []string {
+	return cleanTaxonomyAliases(append(aliases, oldName), newName)
+}
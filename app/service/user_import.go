@@ -0,0 +1,515 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/utils"
+	"fmt"
+	"io"
+	"net/mail"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// UserImportMaxRows batas jumlah baris data dalam satu file import user
+	UserImportMaxRows = 2000
+	// userImportMaxFileSize batas ukuran file import user (10MB)
+	userImportMaxFileSize = 10 << 20
+)
+
+// userImportColumns kolom file import. Kolom wajib: username, email, full_name, role.
+var userImportColumns = map[string]bool{
+	"username":      true,
+	"email":         true,
+	"full_name":     true,
+	"role":          true,
+	"is_active":     false,
+	"student_id":    false,
+	"program_study": false,
+	"academic_year": false,
+	"advisor_nip":   false,
+	"lecturer_id":   false,
+	"department":    false,
+}
+
+// ParseUserImportTable mengubah tabel hasil utils.ReadTable menjadi baris import.
+// Baris pertama adalah header (tidak case-sensitive), baris kosong dilewati dan
+// nomor baris dihitung dari 1 termasuk header agar sama dengan tampilan spreadsheet.
+func ParseUserImportTable(table [][]string) ([]models.UserImportRow, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("file import kosong")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range table[0] {
+		name := strings.ToLower(strings.TrimSpace(header))
+		if name == "" {
+			continue
+		}
+		if _, known := userImportColumns[name]; !known {
+			return nil, fmt.Errorf("kolom %q tidak dikenal", header)
+		}
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("kolom %q muncul lebih dari sekali", header)
+		}
+		columns[name] = i
+	}
+	for name, required := range userImportColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("kolom wajib %q tidak ditemukan", name)
+		}
+	}
+
+	rows := []models.UserImportRow{}
+	for i, record := range table[1:] {
+		value := func(name string) string {
+			index, ok := columns[name]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows) == UserImportMaxRows {
+			return nil, fmt.Errorf("file import maksimal %d baris data", UserImportMaxRows)
+		}
+
+		row := models.UserImportRow{
+			Row:          i + 2,
+			Username:     value("username"),
+			Email:        value("email"),
+			FullName:     value("full_name"),
+			Role:         value("role"),
+			IsActive:     true,
+			StudentID:    value("student_id"),
+			ProgramStudy: value("program_study"),
+			AcademicYear: value("academic_year"),
+			AdvisorNIP:   value("advisor_nip"),
+			LecturerID:   value("lecturer_id"),
+			Department:   value("department"),
+		}
+		if active := value("is_active"); active != "" {
+			switch strings.ToLower(active) {
+			case "1", "true", "ya", "yes", "aktif":
+				row.IsActive = true
+			case "0", "false", "tidak", "no", "nonaktif":
+				row.IsActive = false
+			default:
+				row.Errors = append(row.Errors, fmt.Sprintf("is_active %q tidak valid", active))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ValidateUserImportRows memvalidasi setiap baris terhadap data di database dan baris
+// lain dalam file. Error ditambahkan ke row.Errors; baris valid mendapat RoleID dan,
+// untuk dosen wali yang sudah terdaftar, AdvisorID. advisor_nip boleh merujuk dosen
+// yang diimport di file yang sama, asalkan baris dosen tersebut valid.
+func ValidateUserImportRows(rows []models.UserImportRow, lookup *models.UserImportLookup) {
+	seenUsernames := make(map[string]int)
+	seenEmails := make(map[string]int)
+	seenStudentIDs := make(map[string]int)
+	seenLecturerIDs := make(map[string]int)
+
+	duplicate := func(seen map[string]int, existing map[string]bool, field, value string, row *models.UserImportRow) {
+		key := strings.ToLower(value)
+		if existing[key] {
+			row.Errors = append(row.Errors, fmt.Sprintf("%s %q sudah digunakan", field, value))
+		} else if first, ok := seen[key]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("%s %q duplikat dengan baris %d", field, value, first))
+		} else {
+			seen[key] = row.Row
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+
+		if row.Username == "" {
+			row.Errors = append(row.Errors, "username wajib diisi")
+		} else {
+			duplicate(seenUsernames, lookup.ExistingUsernames, "username", row.Username, row)
+		}
+		if row.Email == "" {
+			row.Errors = append(row.Errors, "email wajib diisi")
+		} else if address, err := mail.ParseAddress(row.Email); err != nil || address.Address != row.Email {
+			row.Errors = append(row.Errors, fmt.Sprintf("email %q tidak valid", row.Email))
+		} else {
+			duplicate(seenEmails, lookup.ExistingEmails, "email", row.Email, row)
+		}
+		if row.FullName == "" {
+			row.Errors = append(row.Errors, "full_name wajib diisi")
+		}
+
+		row.RoleID = lookup.Roles[strings.ToLower(row.Role)]
+		switch {
+		case row.Role == "":
+			row.Errors = append(row.Errors, "role wajib diisi")
+		case row.RoleID == "":
+			row.Errors = append(row.Errors, fmt.Sprintf("role %q tidak dikenal", row.Role))
+		}
+
+		studentProfile := row.StudentID != "" || row.ProgramStudy != "" || row.AcademicYear != "" || row.AdvisorNIP != ""
+		lecturerProfile := row.LecturerID != "" || row.Department != ""
+		if studentProfile && row.RoleID != "" && row.RoleID != "3" {
+			row.Errors = append(row.Errors, "kolom profil mahasiswa hanya untuk role mahasiswa")
+		}
+		if lecturerProfile && row.RoleID != "" && row.RoleID != "2" {
+			row.Errors = append(row.Errors, "kolom profil dosen hanya untuk role dosen")
+		}
+		if row.StudentID == "" && (row.ProgramStudy != "" || row.AcademicYear != "" || row.AdvisorNIP != "") {
+			row.Errors = append(row.Errors, "student_id wajib diisi jika profil mahasiswa diisi")
+		}
+		if row.LecturerID == "" && row.Department != "" {
+			row.Errors = append(row.Errors, "lecturer_id wajib diisi jika department diisi")
+		}
+		if row.StudentID != "" {
+			duplicate(seenStudentIDs, lookup.ExistingStudentIDs, "student_id", row.StudentID, row)
+		}
+		if row.LecturerID != "" {
+			duplicate(seenLecturerIDs, lookup.ExistingLecturerIDs, "lecturer_id", row.LecturerID, row)
+		}
+	}
+
+	// Dosen wali dicek setelah semua baris dosen divalidasi
+	lecturerRows := make(map[string]*models.UserImportRow)
+	for i := range rows {
+		nip := strings.ToLower(rows[i].LecturerID)
+		if _, ok := lecturerRows[nip]; !ok && nip != "" && rows[i].RoleID == "2" {
+			lecturerRows[nip] = &rows[i]
+		}
+	}
+	for i := range rows {
+		row := &rows[i]
+		if row.AdvisorNIP == "" {
+			continue
+		}
+		nip := strings.ToLower(row.AdvisorNIP)
+		if advisorID, ok := lookup.Lecturers[nip]; ok {
+			row.AdvisorID = advisorID
+		} else if lecturer, ok := lecturerRows[nip]; !ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("dosen wali dengan NIP %q tidak ditemukan", row.AdvisorNIP))
+		} else if len(lecturer.Errors) > 0 {
+			row.Errors = append(row.Errors, fmt.Sprintf("dosen wali dengan NIP %q pada baris %d tidak valid", row.AdvisorNIP, lecturer.Row))
+		}
+	}
+}
+
+// ImportUsers godoc
+// @Summary Bulk import users
+// @Description Import users from a CSV or XLSX file (first sheet). Required columns: username, email, full_name, role (role ID or name). Optional columns: is_active (default true), student_id, program_study, academic_year, advisor_nip (NIP of an existing lecturer or a lecturer in the same file) for students, and lecturer_id, department for lecturers. With dry_run=true the file is only validated and per-row errors (duplicate username/email, unknown role, etc.) are reported. Otherwise all valid rows are created in a single transaction and invalid rows are reported and skipped. Generated passwords are never returned in the response; they are stored encrypted and can be downloaded once as CSV by the importing user from credentials_url until credentials_expires_at.
+// @Tags User Management
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file (max 10MB, max 2000 rows)"
+// @Param dry_run formData bool false "Only validate the file without creating users"
+// @Success 200 {object} object{status=string,message=string,data=models.UserImportReport} "Dry run validation report"
+// @Success 201 {object} object{status=string,message=string,data=models.UserImportReport} "Users imported"
+// @Failure 400 {object} map[string]interface{} "Missing or unreadable file, unsupported format or invalid header"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires users.create)"
+// @Failure 500 {object} map[string]interface{} "Import failed, no users were created"
+// @Router /users/import [post]
+func (s *UserService) ImportUsers(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+
+	// Step 1: Baca file
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "File import wajib diupload",
+		})
+	}
+	if fileHeader.Size > userImportMaxFileSize {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Ukuran file import maksimal 10MB",
+		})
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	if format != utils.ExportFormatCSV && format != utils.ExportFormatXLSX {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Format file harus csv atau xlsx",
+		})
+	}
+	dryRun := false
+	if value := c.FormValue("dry_run", c.Query("dry_run")); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"status":  "error",
+				"message": "dry_run harus berupa boolean",
+			})
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membaca file import",
+		})
+	}
+	data, err := io.ReadAll(io.LimitReader(file, userImportMaxFileSize))
+	file.Close()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membaca file import",
+		})
+	}
+
+	// Step 2: Parse dan validasi semua baris
+	table, err := utils.ReadTable(format, data)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+	rows, err := ParseUserImportTable(table)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	}
+	lookup, err := s.importRepo.Lookup(rows)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal memvalidasi data import",
+		})
+	}
+	ValidateUserImportRows(rows, lookup)
+
+	report := models.UserImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    []models.UserImportRow{},
+	}
+	valid := make([]models.UserImportRow, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			report.Errors = append(report.Errors, row)
+		} else {
+			valid = append(valid, row)
+		}
+	}
+	report.ValidRows = len(valid)
+	report.InvalidRows = len(report.Errors)
+
+	if dryRun || len(valid) == 0 {
+		message := "Validasi import selesai"
+		if !dryRun {
+			message = "Tidak ada baris valid untuk diimport"
+		}
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": message,
+			"data":    report,
+		})
+	}
+
+	// Step 3: Buat user dan profil dalam satu transaksi
+	entries, passwords, err := buildUserImportEntries(valid)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal generate password",
+		})
+	}
+	if err := s.importRepo.CreateAll(entries); err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal mengimport user, tidak ada user yang dibuat",
+		})
+	}
+	report.Imported = len(entries)
+
+	// Step 4: Simpan kredensial terenkripsi untuk diunduh sekali oleh admin
+	importID := uuid.New().String()
+	expiresAt := time.Now().Add(s.credentialsTTL)
+	ciphertext, err := encryptImportCredentials(entries, passwords, s.credentialsSecret)
+	if err == nil {
+		err = s.credentialsRepo.Create(context.Background(), &models.UserImportCredentials{
+			ImportID:   importID,
+			UserID:     userID,
+			Users:      len(entries),
+			Ciphertext: ciphertext,
+			ExpiresAt:  expiresAt,
+		})
+	}
+	message := "User berhasil diimport"
+	if err != nil {
+		// User sudah dibuat, password bisa direset lewat fitur reset password
+		message = "User berhasil diimport, tetapi kredensial gagal disimpan. Reset password user secara manual"
+	} else {
+		report.CredentialsURL = "/users/import/credentials/" + importID
+		report.CredentialsExpiresAt = &expiresAt
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": message,
+		"data":    report,
+	})
+}
+
+// buildUserImportEntries membuat user beserta profilnya dan password acak untuk
+// setiap baris valid. Hash bcrypt dikerjakan paralel karena satu file bisa berisi
+// ribuan user.
+func buildUserImportEntries(rows []models.UserImportRow) ([]repository.UserImportEntry, []string, error) {
+	now := time.Now()
+	entries := make([]repository.UserImportEntry, len(rows))
+	passwords := make([]string, len(rows))
+
+	// User ID dosen di file ini untuk advisor_nip yang merujuk baris lain
+	lecturerUserIDs := make(map[string]string)
+	for i, row := range rows {
+		entries[i].User = &models.User{
+			ID:        uuid.New().String(),
+			Username:  row.Username,
+			Email:     row.Email,
+			FullName:  row.FullName,
+			RoleID:    row.RoleID,
+			IsActive:  row.IsActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if row.LecturerID != "" {
+			lecturerUserIDs[strings.ToLower(row.LecturerID)] = entries[i].User.ID
+			entries[i].Lecturer = &models.Lecturer{
+				ID:         uuid.New().String(),
+				UserID:     entries[i].User.ID,
+				LecturerID: row.LecturerID,
+				Department: row.Department,
+				CreatedAt:  now,
+			}
+		}
+	}
+	for i, row := range rows {
+		if row.StudentID == "" {
+			continue
+		}
+		advisorID := row.AdvisorID
+		if advisorID == "" && row.AdvisorNIP != "" {
+			advisorID = lecturerUserIDs[strings.ToLower(row.AdvisorNIP)]
+		}
+		entries[i].Student = &models.Student{
+			ID:           uuid.New().String(),
+			UserID:       entries[i].User.ID,
+			StudentID:    row.StudentID,
+			ProgramStudy: row.ProgramStudy,
+			AcademicYear: row.AcademicYear,
+			AdvisorID:    advisorID,
+			CreatedAt:    now,
+		}
+	}
+
+	jobs := make(chan int)
+	errs := make(chan error, len(rows))
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				passwords[i] = generateRandomPassword(12)
+				hash, err := utils.HashPassword(passwords[i])
+				if err != nil {
+					errs <- err
+					continue
+				}
+				entries[i].User.PasswordHash = hash
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, nil, err
+	}
+	return entries, passwords, nil
+}
+
+// encryptImportCredentials menulis username dan password awal sebagai CSV lalu mengenkripsinya
+func encryptImportCredentials(entries []repository.UserImportEntry, passwords []string, secret []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	table, err := utils.NewTableWriter(utils.ExportFormatCSV, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.WriteRow("username", "email", "full_name", "password"); err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		if err := table.WriteRow(entry.User.Username, entry.User.Email, entry.User.FullName, passwords[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := table.Close(); err != nil {
+		return nil, err
+	}
+	return utils.EncryptCredentials(buf.Bytes(), secret)
+}
+
+// DownloadImportCredentials godoc
+// @Summary Download imported user credentials
+// @Description Download the generated usernames and initial passwords of a bulk import as CSV. Only the user who ran the import can download it, only once and only until credentials_expires_at; the encrypted credentials are deleted on download. Distribute the passwords securely and ask users to change them.
+// @Tags User Management
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Import ID from credentials_url"
+// @Success 200 {file} file "Credentials CSV (username, email, full_name, password)"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires users.create)"
+// @Failure 404 {object} map[string]interface{} "Credentials not found, already downloaded or expired"
+// @Failure 500 {object} map[string]interface{} "Failed to decrypt credentials"
+// @Router /users/import/credentials/{id} [get]
+func (s *UserService) DownloadImportCredentials(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	importID := c.Params("id")
+
+	credentials, err := s.credentialsRepo.Take(context.Background(), importID, userID)
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Kredensial tidak ditemukan, sudah diunduh atau kedaluwarsa",
+		})
+	}
+
+	plaintext, err := utils.DecryptCredentials(credentials.Ciphertext, s.credentialsSecret)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Gagal membuka kredensial",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderContentType, utils.ExportContentType(utils.ExportFormatCSV))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="kredensial-import-%s.csv"`, importID))
+	return c.Send(plaintext)
+}
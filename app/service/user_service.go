@@ -4,6 +4,7 @@ import (
 	models "crud-app/app/model"
 	"crud-app/app/repository"
 	"crud-app/app/utils"
+	"crud-app/config"
 	"crypto/rand"
 	"database/sql"
 	"math/big"
@@ -11,19 +12,37 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type UserService struct {
 	userRepo     *repository.UserRepository
 	studentRepo  *repository.StudentRepository
 	lecturerRepo *repository.LecturerRepository
+	projector    *AchievementProjector
+
+	importRepo      *repository.UserImportRepository
+	credentialsRepo *repository.UserImportCredentialsRepository
+
+	cursorSecret      []byte
+	credentialsSecret []byte
+	// Lama kredensial hasil import bisa diunduh sebelum dihapus
+	credentialsTTL time.Duration
 }
 
-func NewUserService(db *sql.DB) *UserService {
+func NewUserService(db *sql.DB, mongoDB *mongo.Database, cfg *config.Config) *UserService {
 	return &UserService{
 		userRepo:     repository.NewUserRepository(db),
 		studentRepo:  repository.NewStudentRepository(db),
 		lecturerRepo: repository.NewLecturerRepository(db),
+		projector:    NewAchievementProjector(mongoDB, db),
+
+		importRepo:      repository.NewUserImportRepository(db),
+		credentialsRepo: repository.NewUserImportCredentialsRepository(mongoDB),
+
+		cursorSecret:      []byte(cfg.Auth.CursorSecret),
+		credentialsSecret: []byte(cfg.Auth.CredentialsSecret),
+		credentialsTTL:    cfg.Auth.CredentialsTTL,
 	}
 }
 
@@ -202,15 +221,18 @@ func (s *UserService) CreateUser(c *fiber.Ctx) error {
 
 // GetUsers godoc
 // @Summary Get list of users
-// @Description Get paginated list of users with optional role filtering. Admin access required.
+// @Description Get paginated list of users with optional role filtering. Admin access required. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by newest first) which returns pagination as models.CursorPaginationMeta.
 // @Tags User Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
 // @Param role_id query string false "Filter by role ID (1=Admin, 2=Lecturer, 3=Student)"
 // @Success 200 {object} object{status=string,message=string,data=object{users=[]models.User,pagination=models.PaginationMeta}} "Users retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or tampered cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires users.read)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve users"
@@ -228,6 +250,33 @@ func (s *UserService) GetUsers(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "created_at", "desc")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid",
+		})
+	}
+	if useCursor {
+		users, err := s.userRepo.FindWithCursor(limit, roleFilter, cursor)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data users",
+			})
+		}
+
+		users, pagination := utils.CursorPage(users, limit, cursor, userCursor, s.cursorSecret)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Data users berhasil diambil",
+			"data": fiber.Map{
+				"users":      users,
+				"pagination": pagination,
+			},
+		})
+	}
+
 	users, total, err := s.userRepo.FindAll(limit, offset, roleFilter)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
@@ -364,6 +413,7 @@ func (s *UserService) UpdateUser(c *fiber.Ctx) error {
 			"message": "Gagal mengupdate user",
 		})
 	}
+	refreshUserReadModel(s.projector, userID)
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -541,6 +591,7 @@ func (s *UserService) SetStudentProfile(c *fiber.Ctx) error {
 			"message": "Gagal membuat student profile",
 		})
 	}
+	refreshStudentReadModel(s.projector, userID)
 
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
@@ -604,6 +655,7 @@ func (s *UserService) UpdateStudentProfile(c *fiber.Ctx) error {
 			"message": "Gagal mengupdate student profile",
 		})
 	}
+	refreshStudentReadModel(s.projector, existing.UserID)
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -669,6 +721,9 @@ func (s *UserService) AssignAdvisor(c *fiber.Ctx) error {
 			"message": "Gagal assign advisor",
 		})
 	}
+	if student, err := s.studentRepo.FindByID(studentID); err == nil {
+		refreshStudentReadModel(s.projector, student.UserID)
+	}
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -809,14 +864,17 @@ func generateRandomPassword(length int) string {
 
 // GetStudents godoc
 // @Summary Get list of students
-// @Description Get paginated list of students with their profile information.
+// @Description Get paginated list of students with their profile information. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by newest first) which returns pagination as models.CursorPaginationMeta.
 // @Tags Student Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
 // @Success 200 {object} object{status=string,message=string,data=object{students=[]models.Student,pagination=models.PaginationMeta}} "Students retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or tampered cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires students.read)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve students"
@@ -833,6 +891,33 @@ func (s *UserService) GetStudents(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "created_at", "desc")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid",
+		})
+	}
+	if useCursor {
+		students, err := s.studentRepo.FindWithCursor(limit, cursor)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data students",
+			})
+		}
+
+		students, pagination := utils.CursorPage(students, limit, cursor, studentCursor, s.cursorSecret)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Data students berhasil diambil",
+			"data": fiber.Map{
+				"students":   students,
+				"pagination": pagination,
+			},
+		})
+	}
+
 	students, total, err := s.studentRepo.FindAll(limit, offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
@@ -907,14 +992,17 @@ func (s *UserService) GetStudentByID(c *fiber.Ctx) error {
 
 // GetLecturers godoc
 // @Summary Get list of lecturers
-// @Description Get paginated list of lecturers with their profile information and department details.
+// @Description Get paginated list of lecturers with their profile information and department details. Supports page mode (page/limit) and cursor mode (cursor/limit, ordered by name) which returns pagination as models.CursorPaginationMeta.
 // @Tags Lecturer Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)" default(1)
 // @Param limit query int false "Items per page (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination (page is ignored)"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
 // @Success 200 {object} object{status=string,message=string,data=object{lecturers=[]models.Lecturer,pagination=models.PaginationMeta}} "Lecturers retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or tampered cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires lecturers.read)"
 // @Failure 500 {object} map[string]interface{} "Failed to retrieve lecturers"
@@ -931,6 +1019,33 @@ func (s *UserService) GetLecturers(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "full_name", "asc")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid",
+		})
+	}
+	if useCursor {
+		lecturers, err := s.lecturerRepo.FindWithCursor(limit, cursor)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data lecturers",
+			})
+		}
+
+		lecturers, pagination := utils.CursorPage(lecturers, limit, cursor, lecturerCursor, s.cursorSecret)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Data lecturers berhasil diambil",
+			"data": fiber.Map{
+				"lecturers":  lecturers,
+				"pagination": pagination,
+			},
+		})
+	}
+
 	lecturers, total, err := s.lecturerRepo.FindAll(limit, offset)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
@@ -961,13 +1076,17 @@ func (s *UserService) GetLecturers(c *fiber.Ctx) error {
 
 // GetAdvisees godoc
 // @Summary Get lecturer advisees
-// @Description Get list of students advised by a specific lecturer including their profile information.
+// @Description Get list of students advised by a specific lecturer including their profile information, ordered by name. Without a cursor the full list is returned; cursor mode (cursor/limit) returns one page with pagination as models.CursorPaginationMeta.
 // @Tags Lecturer Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Lecturer ID"
+// @Param limit query int false "Items per page in cursor mode (default: 10, max: 100)" default(10)
+// @Param cursor query string false "Opaque cursor from next_cursor/prev_cursor. Switches to cursor pagination"
+// @Param pagination query string false "Set to 'cursor' to start cursor pagination from the first page"
 // @Success 200 {object} object{status=string,message=string,data=object{advisees=[]models.Student,total=int}} "Advisees retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid or tampered cursor"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Insufficient permissions (requires lecturers.read)"
 // @Failure 404 {object} map[string]interface{} "Lecturer not found"
@@ -985,6 +1104,38 @@ func (s *UserService) GetAdvisees(c *fiber.Ctx) error {
 		})
 	}
 
+	useCursor, cursor, err := parseCursorRequest(c, s.cursorSecret, "full_name", "asc")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Cursor tidak valid",
+		})
+	}
+	if useCursor {
+		limit := c.QueryInt("limit", 10)
+		if limit < 1 || limit > 100 {
+			limit = 10
+		}
+
+		advisees, err := s.studentRepo.FindByAdvisorIDWithCursor(lecturerID, limit, cursor)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Gagal mengambil data advisees",
+			})
+		}
+
+		advisees, pagination := utils.CursorPage(advisees, limit, cursor, adviseeCursor, s.cursorSecret)
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "Data advisees berhasil diambil",
+			"data": fiber.Map{
+				"advisees":   advisees,
+				"pagination": pagination,
+			},
+		})
+	}
+
 	// Get advisees
 	advisees, err := s.studentRepo.FindByAdvisorID(lecturerID)
 	if err != nil {
@@ -15,12 +15,13 @@ type PermissionCache struct {
 	mu    sync.RWMutex
 }
 
-var Cache *PermissionCache
-
-func InitCache() {
-	Cache = &PermissionCache{}
+// NewPermissionCache membuat cache dan menjalankan pembersihan item expired
+// setiap cleanupInterval
+func NewPermissionCache(cleanupInterval time.Duration) *PermissionCache {
+	cache := &PermissionCache{}
 	// Start cleanup goroutine
-	go Cache.cleanupExpired()
+	go cache.cleanupExpired(cleanupInterval)
+	return cache
 }
 
 // Set menyimpan data ke cache dengan TTL
@@ -63,9 +64,9 @@ func (c *PermissionCache) Clear() {
 	})
 }
 
-// cleanupExpired membersihkan item yang sudah expired setiap 5 menit
-func (c *PermissionCache) cleanupExpired() {
-	ticker := time.NewTicker(5 * time.Minute)
+// cleanupExpired membersihkan item yang sudah expired setiap interval
+func (c *PermissionCache) cleanupExpired(interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for range ticker.C {
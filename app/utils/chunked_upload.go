@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ChunkedUploadConfig konfigurasi upload bertahap (resumable) untuk file besar
+type ChunkedUploadConfig struct {
+	// Folder penyimpanan file yang belum selesai diupload
+	PartialPath      string
+	MaxChunkSize     int64
+	AllowedFileTypes []string
+	// Session yang tidak selesai sampai batas waktu ini akan dihapus
+	SessionTTL time.Duration
+	// Batas ukuran file per kode kategori taxonomy (key lowercase),
+	// kategori yang tidak terdaftar memakai DefaultMaxFileSize
+	DefaultMaxFileSize  int64
+	CategoryMaxFileSize map[string]int64
+}
+
+var DefaultChunkedUploadConfig = ChunkedUploadConfig{
+	PartialPath:        "./uploads/partial",
+	MaxChunkSize:       2 * 1024 * 1024, // 2MB, di bawah body limit default Fiber (4MB)
+	AllowedFileTypes:   []string{".pdf", ".jpg", ".jpeg", ".png", ".doc", ".docx", ".mp4", ".mov"},
+	SessionTTL:         24 * time.Hour,
+	DefaultMaxFileSize: 50 * 1024 * 1024, // 50MB
+	CategoryMaxFileSize: map[string]int64{
+		"kompetisi":  200 * 1024 * 1024, // rekaman lomba / presentasi
+		"penelitian": 100 * 1024 * 1024,
+	},
+}
+
+// Kode error tambahan untuk upload bertahap
+const (
+	UploadErrOffsetMismatch   = "UPLOAD_OFFSET_MISMATCH"
+	UploadErrChecksumMismatch = "CHECKSUM_MISMATCH"
+	UploadErrChunkTooLarge    = "CHUNK_TOO_LARGE"
+)
+
+// MaxFileSizeFor mengembalikan batas ukuran file untuk kode kategori taxonomy
+func (c ChunkedUploadConfig) MaxFileSizeFor(categoryCode string) int64 {
+	if limit, ok := c.CategoryMaxFileSize[strings.ToLower(strings.TrimSpace(categoryCode))]; ok {
+		return limit
+	}
+	return c.DefaultMaxFileSize
+}
+
+// QuotaConfig mengembalikan konfigurasi kuota untuk upload bertahap. Kuota per achievement
+// dinaikkan minimal ke batas file kategori, sehingga satu file terbesar yang diizinkan
+// tetap bisa diupload tetapi session berulang tidak bisa mengisi achievement tanpa batas.
+func (c ChunkedUploadConfig) QuotaConfig(upload FileUploadConfig, categoryCode string) FileUploadConfig {
+	if limit := c.MaxFileSizeFor(categoryCode); upload.MaxAchievementStorage > 0 && upload.MaxAchievementStorage < limit {
+		upload.MaxAchievementStorage = limit
+	}
+	return upload
+}
+
+// ValidateUploadSession memvalidasi nama file dan ukuran sebelum session dibuat
+func (c ChunkedUploadConfig) ValidateUploadSession(filename string, filesize int64, categoryCode string) error {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !isAllowedFileType(ext, c.AllowedFileTypes) {
+		return &UploadError{
+			Code:    UploadErrTypeNotAllowed,
+			Message: fmt.Sprintf("tipe file tidak diizinkan. Hanya: %v", c.AllowedFileTypes),
+		}
+	}
+
+	limit := c.MaxFileSizeFor(categoryCode)
+	if filesize > limit {
+		return &UploadError{
+			Code:    UploadErrFileTooLarge,
+			Message: fmt.Sprintf("ukuran file terlalu besar untuk kategori %s. Maksimal %d MB", categoryCode, limit/(1024*1024)),
+		}
+	}
+
+	return nil
+}
+
+// VerifyChunkChecksum memvalidasi header Upload-Checksum (format tus: "<algoritma> <base64>").
+// Header kosong dianggap valid.
+func VerifyChunkChecksum(data []byte, header string) error {
+	if header == "" {
+		return nil
+	}
+
+	algorithm, encoded, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || strings.ToLower(algorithm) != "sha256" {
+		return &UploadError{
+			Code:    UploadErrChecksumMismatch,
+			Message: "algoritma checksum tidak didukung, gunakan sha256",
+		}
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return &UploadError{
+			Code:    UploadErrChecksumMismatch,
+			Message: "format checksum tidak valid",
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	if string(sum[:]) != string(expected) {
+		return &UploadError{
+			Code:    UploadErrChecksumMismatch,
+			Message: "checksum chunk tidak sesuai",
+		}
+	}
+	return nil
+}
+
+// PartialUploadPath mengembalikan lokasi file sementara untuk session upload
+func PartialUploadPath(config ChunkedUploadConfig, uploadID string) string {
+	return filepath.Join(config.PartialPath, uploadID+".part")
+}
+
+// AppendChunk menulis chunk ke file sementara pada offset tertentu.
+// Offset harus sama dengan ukuran data yang sudah diterima.
+func AppendChunk(path string, offset int64, data []byte) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, fmt.Errorf("gagal membuat folder upload sementara: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membuka file sementara: %v", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca file sementara: %v", err)
+	}
+	// Sisa chunk yang gagal sebelumnya (klaim offset sudah dikembalikan) boleh ditimpa
+	if info.Size() < offset {
+		return 0, &UploadError{
+			Code:    UploadErrOffsetMismatch,
+			Message: fmt.Sprintf("offset %d tidak sesuai, data yang diterima baru %d byte", offset, info.Size()),
+		}
+	}
+
+	if _, err := f.WriteAt(data, offset); err != nil {
+		return 0, fmt.Errorf("gagal menulis chunk: %v", err)
+	}
+	newOffset := offset + int64(len(data))
+	if err := f.Truncate(newOffset); err != nil {
+		return 0, fmt.Errorf("gagal menulis chunk: %v", err)
+	}
+
+	return newOffset, nil
+}
+
+// FinalizeChunkedUpload memvalidasi file yang sudah lengkap, mencocokkan checksum
+// (hex SHA-256, opsional) lalu memindahkannya ke karantina untuk discan
+func FinalizeChunkedUpload(partialPath, filename, expectedSHA256 string, config FileUploadConfig) (*SavedFile, error) {
+	src, err := os.Open(partialPath)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka file: %v", err)
+	}
+	info, err := src.Stat()
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("gagal membaca file: %v", err)
+	}
+
+	mimetype, err := checkFileContent(src, info.Size(), filename)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, src)
+	src.Close()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file: %v", err)
+	}
+	sum := hex.EncodeToString(hasher.Sum(nil))
+	if expectedSHA256 != "" && !strings.EqualFold(sum, expectedSHA256) {
+		return nil, &UploadError{
+			Code:    UploadErrChecksumMismatch,
+			Message: fmt.Sprintf("checksum file %s tidak sesuai", filename),
+		}
+	}
+
+	if err := os.MkdirAll(config.QuarantinePath, 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat folder karantina: %v", err)
+	}
+	quarantined := filepath.Join(config.QuarantinePath, generateUniqueFilename(filename))
+	if err := os.Rename(partialPath, quarantined); err != nil {
+		return nil, fmt.Errorf("gagal memindahkan file ke karantina: %v", err)
+	}
+
+	saved := &SavedFile{
+		Filepath: quarantined,
+		Filesize: info.Size(),
+		Mimetype: mimetype,
+		SHA256:   sum,
+	}
+	return releaseFromQuarantine(saved, filename, config)
+}
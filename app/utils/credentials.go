@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// EncryptCredentials mengenkripsi kredensial dengan AES-256-GCM agar password awal
+// tidak pernah tersimpan sebagai plaintext. Kunci AES-256 diturunkan dari secret.
+// Hasilnya nonce diikuti ciphertext.
+func EncryptCredentials(plaintext, secret []byte) ([]byte, error) {
+	gcm, err := credentialsCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// DecryptCredentials membuka hasil EncryptCredentials
+func DecryptCredentials(sealed, secret []byte) ([]byte, error) {
+	gcm, err := credentialsCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("kredensial terenkripsi tidak valid")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func credentialsCipher(secret []byte) (cipher.AEAD, error) {
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
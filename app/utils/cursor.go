@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	models "crud-app/app/model"
+)
+
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// EncodeCursor menyusun cursor opaque: payload JSON base64url diikuti tanda tangan
+// HMAC-SHA256 dengan secret
+func EncodeCursor(cursor models.Cursor, secret []byte) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signCursor(encoded, secret)
+}
+
+// DecodeCursor memverifikasi tanda tangan dan membaca isi cursor
+func DecodeCursor(token string, secret []byte) (*models.Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signCursor(encoded, secret))) {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor models.Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+func signCursor(encoded string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CursorPage menyusun satu halaman dari hasil query keyset yang mengambil limit+1 baris.
+// Untuk cursor Before, rows masih dalam urutan terbalik dan akan dibalik kembali.
+// keyOf mengembalikan cursor (sort key dan ID) untuk sebuah baris, secret kunci tanda tangan cursor.
+func CursorPage[T any](rows []T, limit int, cursor *models.Cursor, keyOf func(T) models.Cursor, secret []byte) ([]T, models.CursorPaginationMeta) {
+	meta := models.CursorPaginationMeta{Limit: limit}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	before := cursor != nil && cursor.Before
+	if before {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, meta
+	}
+
+	// Halaman berikutnya ada jika masih ada baris setelahnya, atau kita datang dari sana
+	if hasMore || before {
+		next := keyOf(rows[len(rows)-1])
+		next.Before = false
+		token := EncodeCursor(next, secret)
+		meta.NextCursor = &token
+	}
+	// Halaman sebelumnya ada jika masih ada baris sebelumnya, atau kita datang dari sana
+	if (before && hasMore) || (!before && cursor != nil) {
+		prev := keyOf(rows[0])
+		prev.Before = true
+		token := EncodeCursor(prev, secret)
+		meta.PrevCursor = &token
+	}
+
+	return rows, meta
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// detailsSchemaURL nama resource internal untuk schema details kategori
+const detailsSchemaURL = "details.schema.json"
+
+// DetailsValidationError daftar pelanggaran JSON Schema pada details achievement.
+// Setiap error berformat "<lokasi>: <pesan>", contoh "/rank: expected integer, but got string".
+type DetailsValidationError struct {
+	Errors []string
+}
+
+func (e *DetailsValidationError) Error() string {
+	return "details tidak sesuai schema kategori: " + strings.Join(e.Errors, "; ")
+}
+
+// CompileDetailsSchema mengompilasi JSON Schema details kategori (default draft 2020-12).
+// Schema wajib bertipe object dan $ref ke URL luar tidak diizinkan.
+func CompileDetailsSchema(raw []byte) (*jsonschema.Schema, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("details_schema harus berupa JSON object: %v", err)
+	}
+	if document["type"] != "object" {
+		return nil, errors.New(`details_schema harus memiliki "type": "object"`)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("$ref ke %s tidak diizinkan", url)
+	}
+	if err := compiler.AddResource(detailsSchemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("details_schema tidak valid: %v", err)
+	}
+	schema, err := compiler.Compile(detailsSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("details_schema tidak valid: %v", err)
+	}
+	return schema, nil
+}
+
+// ValidateDetails memvalidasi details terhadap schema. details nil divalidasi sebagai
+// object kosong agar field required tetap diperiksa. Mengembalikan *DetailsValidationError
+// jika tidak valid.
+func ValidateDetails(schema *jsonschema.Schema, details map[string]interface{}) error {
+	var instance interface{} = map[string]interface{}{}
+	if details != nil {
+		instance = details
+	}
+
+	err := schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	messages := []string{}
+	collectDetailsErrors(validationErr, &messages)
+	sort.Strings(messages)
+	return &DetailsValidationError{Errors: messages}
+}
+
+// collectDetailsErrors mengambil pesan dari error paling dalam (penyebab sebenarnya)
+func collectDetailsErrors(err *jsonschema.ValidationError, messages *[]string) {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		*messages = append(*messages, location+": "+err.Message)
+		return
+	}
+	for _, cause := range err.Causes {
+		collectDetailsErrors(cause, messages)
+	}
+}
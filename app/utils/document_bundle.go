@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// DocumentBundle arsip ZIP berisi dokumen pendukung untuk import achievement.
+// Dokumen dirujuk dengan path relatif di dalam arsip, contoh "2019/sertifikat-budi.pdf".
+type DocumentBundle struct {
+	files map[string]*zip.File
+}
+
+// OpenDocumentBundle membaca daftar file arsip ZIP. Folder dan file metadata
+// macOS (__MACOSX) diabaikan. r harus tetap terbuka selama dokumen dibaca.
+func OpenDocumentBundle(r io.ReaderAt, size int64) (*DocumentBundle, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("file ZIP dokumen tidak valid: %v", err)
+	}
+
+	bundle := &DocumentBundle{files: make(map[string]*zip.File, len(archive.File))}
+	for _, file := range archive.File {
+		name := NormalizeBundlePath(file.Name)
+		if file.FileInfo().IsDir() || name == "" || strings.HasPrefix(name, "__MACOSX/") {
+			continue
+		}
+		bundle.files[name] = file
+	}
+	return bundle, nil
+}
+
+// NormalizeBundlePath menyeragamkan path dokumen di arsip dan di file import
+// (pemisah "\\" Windows, awalan "./" atau "/")
+func NormalizeBundlePath(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, "\\", "/"))
+	if name == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// Has mengecek apakah dokumen ada di arsip
+func (b *DocumentBundle) Has(name string) bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.files[NormalizeBundlePath(name)]
+	return ok
+}
+
+// Read membaca isi dokumen, maksimal maxSize byte agar file zip bomb tidak
+// menghabiskan memori
+func (b *DocumentBundle) Read(name string, maxSize int64) ([]byte, error) {
+	if !b.Has(name) {
+		return nil, fmt.Errorf("dokumen %s tidak ada di file ZIP", name)
+	}
+	file := b.files[NormalizeBundlePath(name)]
+	if file.UncompressedSize64 > uint64(maxSize) {
+		return nil, &UploadError{
+			Code:    UploadErrFileTooLarge,
+			Message: fmt.Sprintf("ukuran file %s terlalu besar. Maksimal %d MB", name, maxSize/(1024*1024)),
+		}
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka %s: %v", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca %s: %v", name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, &UploadError{
+			Code:    UploadErrFileTooLarge,
+			Message: fmt.Sprintf("ukuran file %s terlalu besar. Maksimal %d MB", name, maxSize/(1024*1024)),
+		}
+	}
+	return data, nil
+}
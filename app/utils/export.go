@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format file export laporan
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportConfig konfigurasi export laporan
+type ExportConfig struct {
+	// Folder file hasil export background job
+	ExportPath string
+	// Export dengan jumlah baris lebih dari ini dijalankan sebagai background job
+	SyncRowLimit int64
+	// Lama file hasil export disimpan sebelum dihapus garbage collector
+	Retention time.Duration
+}
+
+var DefaultExportConfig = ExportConfig{
+	ExportPath:   "./exports",
+	SyncRowLimit: 5000,
+	Retention:    24 * time.Hour,
+}
+
+// ExportContentType MIME type file export
+func ExportContentType(format string) string {
+	if format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// TableWriter menulis baris tabel ke file export secara streaming.
+// Nilai int, int64 dan float64 ditulis sebagai angka, selain itu sebagai teks.
+type TableWriter interface {
+	WriteRow(values ...interface{}) error
+	Close() error
+}
+
+// NewTableWriter membuat TableWriter untuk format csv atau xlsx
+func NewTableWriter(format string, w io.Writer) (TableWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return &csvTableWriter{writer: csv.NewWriter(w)}, nil
+	case ExportFormatXLSX:
+		return newXLSXTableWriter(w)
+	}
+	return nil, fmt.Errorf("format export tidak didukung: %s", format)
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func (t *csvTableWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatExportValue(value)
+		// Cegah formula injection saat CSV dibuka di spreadsheet
+		if _, isText := value.(string); isText && record[i] != "" && strings.ContainsRune("=+-@\t\r", rune(record[i][0])) {
+			record[i] = "'" + record[i]
+		}
+	}
+	return t.writer.Write(record)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+// xlsxTableWriter menulis workbook SpreadsheetML satu sheet. Baris langsung ditulis
+// ke entry zip sheet1.xml sehingga export besar tidak perlu ditampung di memori.
+type xlsxTableWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+}
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func newXLSXTableWriter(w io.Writer) (*xlsxTableWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(entry)
+	if _, err := sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	return &xlsxTableWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (t *xlsxTableWriter) WriteRow(values ...interface{}) error {
+	t.row++
+	fmt.Fprintf(t.sheet, `<row r="%d">`, t.row)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(t.row)
+		switch value.(type) {
+		case int, int64, float64:
+			fmt.Fprintf(t.sheet, `<c r="%s"><v>%s</v></c>`, ref, formatExportValue(value))
+		default:
+			fmt.Fprintf(t.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(t.sheet, []byte(formatExportValue(value))); err != nil {
+				return err
+			}
+			t.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := t.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (t *xlsxTableWriter) Close() error {
+	if _, err := t.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := t.sheet.Flush(); err != nil {
+		return err
+	}
+	return t.archive.Close()
+}
+
+// xlsxColumnName nama kolom spreadsheet dari indeks 0: A, B, ..., Z, AA, AB, ...
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatExportValue(*v)
+	}
+	return fmt.Sprint(value)
+}
@@ -1,8 +1,15 @@
 package utils
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
 	"io"
+	"log"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -16,81 +23,390 @@ type FileUploadConfig struct {
 	UploadPath       string
 	MaxFileSize      int64
 	AllowedFileTypes []string
+	// Kuota total ukuran dokumen per achievement dan per student (0 = tanpa batas)
+	MaxAchievementStorage int64
+	MaxStudentStorage     int64
+	// File disimpan di folder karantina sampai lolos scan antivirus
+	QuarantinePath string
+	Scanner        Scanner
 }
 
 var DefaultUploadConfig = FileUploadConfig{
-	UploadPath:       "./uploads/achievements",
-	MaxFileSize:      5 * 1024 * 1024, // 5MB
-	AllowedFileTypes: []string{".pdf", ".jpg", ".jpeg", ".png", ".doc", ".docx"},
+	UploadPath:            "./uploads/achievements",
+	MaxFileSize:           5 * 1024 * 1024, // 5MB
+	AllowedFileTypes:      []string{".pdf", ".jpg", ".jpeg", ".png", ".doc", ".docx"},
+	MaxAchievementStorage: 20 * 1024 * 1024,  // 20MB
+	MaxStudentStorage:     200 * 1024 * 1024, // 200MB
+	QuarantinePath:        "./uploads/quarantine",
+}
+
+// Kode error upload yang dikembalikan ke client
+const (
+	UploadErrFileTooLarge     = "FILE_TOO_LARGE"
+	UploadErrTypeNotAllowed   = "FILE_TYPE_NOT_ALLOWED"
+	UploadErrContentMismatch  = "FILE_CONTENT_MISMATCH"
+	UploadErrAchievementQuota = "ACHIEVEMENT_QUOTA_EXCEEDED"
+	UploadErrStudentQuota     = "STUDENT_QUOTA_EXCEEDED"
+	UploadErrInfected         = "FILE_INFECTED"
+	UploadErrImageTooLarge    = "IMAGE_DIMENSIONS_TOO_LARGE"
+)
+
+// UploadError adalah error validasi upload yang membawa kode error
+type UploadError struct {
+	Code    string
+	Message string
+}
+
+func (e *UploadError) Error() string {
+	return e.Message
+}
+
+// SavedFile berisi informasi file yang berhasil disimpan di server
+type SavedFile struct {
+	Filepath string
+	Filesize int64
+	Mimetype string // MIME type hasil deteksi server, bukan dari header client
+	// Thumbnail untuk gambar (JPEG/PNG)
+	ThumbnailPath string
+	// Hash SHA-256 isi file asli (sebelum diproses), untuk deteksi duplikat
+	SHA256 string
+	// Hasil scan antivirus
+	ScanStatus    string
+	ScanSignature string
+}
+
+// Signature MIME type yang diizinkan untuk setiap ekstensi
+var extensionMimeTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".mp4":  "video/mp4",
+	".mov":  "video/quicktime",
 }
 
 // SaveUploadedFile menyimpan file yang diupload
-func SaveUploadedFile(file *multipart.FileHeader, config FileUploadConfig) (string, error) {
+func SaveUploadedFile(file *multipart.FileHeader, config FileUploadConfig) (*SavedFile, error) {
+	if err := checkFileHeader(file.Filename, file.Size, config); err != nil {
+		return nil, err
+	}
+
+	// Buka file source
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka file: %v", err)
+	}
+	defer src.Close()
+
+	return saveFile(src, file.Size, file.Filename, config)
+}
+
+// SaveFileBytes menyimpan isi file yang sudah ada di memori (misalnya dari arsip ZIP)
+// dengan validasi, karantina dan scan yang sama seperti SaveUploadedFile
+func SaveFileBytes(filename string, data []byte, config FileUploadConfig) (*SavedFile, error) {
+	if err := checkFileHeader(filename, int64(len(data)), config); err != nil {
+		return nil, err
+	}
+	return saveFile(bytes.NewReader(data), int64(len(data)), filename, config)
+}
+
+// checkFileHeader memvalidasi ukuran dan ekstensi file
+func checkFileHeader(filename string, size int64, config FileUploadConfig) error {
 	// Validasi ukuran file
-	if file.Size > config.MaxFileSize {
-		return "", fmt.Errorf("ukuran file terlalu besar. Maksimal %d MB", config.MaxFileSize/(1024*1024))
+	if size > config.MaxFileSize {
+		return &UploadError{
+			Code:    UploadErrFileTooLarge,
+			Message: fmt.Sprintf("ukuran file terlalu besar. Maksimal %d MB", config.MaxFileSize/(1024*1024)),
+		}
 	}
 
 	// Validasi tipe file
-	ext := strings.ToLower(filepath.Ext(file.Filename))
+	ext := strings.ToLower(filepath.Ext(filename))
 	if !isAllowedFileType(ext, config.AllowedFileTypes) {
-		return "", fmt.Errorf("tipe file tidak diizinkan. Hanya: %v", config.AllowedFileTypes)
+		return &UploadError{
+			Code:    UploadErrTypeNotAllowed,
+			Message: fmt.Sprintf("tipe file tidak diizinkan. Hanya: %v", config.AllowedFileTypes),
+		}
+	}
+	return nil
+}
+
+// saveFile menyimpan file ke karantina lalu men-scan dan mengeluarkannya
+func saveFile(src interface {
+	io.Reader
+	io.ReaderAt
+}, size int64, filename string, config FileUploadConfig) (*SavedFile, error) {
+	// Validasi isi file (magic bytes) harus sesuai dengan ekstensi
+	mimetype, err := checkFileContent(src, size, filename)
+	if err != nil {
+		return nil, err
 	}
 
-	// Buat folder jika belum ada
-	if err := os.MkdirAll(config.UploadPath, 0755); err != nil {
-		return "", fmt.Errorf("gagal membuat folder upload: %v", err)
+	// Buat folder karantina jika belum ada
+	if err := os.MkdirAll(config.QuarantinePath, 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat folder karantina: %v", err)
 	}
 
 	// Generate unique filename
-	uniqueFilename := generateUniqueFilename(file.Filename)
-	filepath := filepath.Join(config.UploadPath, uniqueFilename)
+	uniqueFilename := generateUniqueFilename(filename)
+	filepath := filepath.Join(config.QuarantinePath, uniqueFilename)
 
-	// Buka file source
-	src, err := file.Open()
+	// Buat file destination di karantina
+	dst, err := os.Create(filepath)
 	if err != nil {
-		return "", fmt.Errorf("gagal membuka file: %v", err)
+		return nil, fmt.Errorf("gagal membuat file: %v", err)
 	}
-	defer src.Close()
 
-	// Buat file destination
-	dst, err := os.Create(filepath)
+	// Copy file sekaligus hitung hash SHA-256 dari isi file asli
+	hasher := sha256.New()
+	written, err := io.Copy(io.MultiWriter(dst, hasher), src)
+	dst.Close()
+	if err != nil {
+		os.Remove(filepath)
+		return nil, fmt.Errorf("gagal menyimpan file: %v", err)
+	}
+
+	saved := &SavedFile{
+		Filepath: filepath,
+		Filesize: written,
+		Mimetype: mimetype,
+		SHA256:   hex.EncodeToString(hasher.Sum(nil)),
+	}
+
+	return releaseFromQuarantine(saved, filename, config)
+}
+
+// checkFileContent memastikan magic bytes file sesuai dengan ekstensinya
+// dan mengembalikan MIME type hasil deteksi
+func checkFileContent(src io.ReaderAt, size int64, filename string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	mimetype, err := DetectMimeType(src, size)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca file: %v", err)
+	}
+	if expected, ok := extensionMimeTypes[ext]; !ok || expected != mimetype {
+		return "", &UploadError{
+			Code:    UploadErrContentMismatch,
+			Message: fmt.Sprintf("isi file %s tidak sesuai dengan ekstensi %s", filename, ext),
+		}
+	}
+	// Gambar harus bisa di-decode agar bisa dibuatkan thumbnail, dengan dimensi
+	// yang aman untuk di-decode penuh
+	if IsProcessableImage(mimetype) {
+		if _, _, err := image.DecodeConfig(io.NewSectionReader(src, 0, size)); err != nil {
+			return "", &UploadError{
+				Code:    UploadErrContentMismatch,
+				Message: fmt.Sprintf("file gambar %s tidak valid", filename),
+			}
+		}
+		if err := CheckImageDimensions(io.NewSectionReader(src, 0, size)); err != nil {
+			return "", &UploadError{
+				Code:    UploadErrImageTooLarge,
+				Message: fmt.Sprintf("file gambar %s: %v", filename, err),
+			}
+		}
+	}
+	return mimetype, nil
+}
+
+// releaseFromQuarantine men-scan file di karantina lalu mengeluarkannya jika bersih
+func releaseFromQuarantine(saved *SavedFile, filename string, config FileUploadConfig) (*SavedFile, error) {
+	scan, err := ScanQuarantinedFile(saved.Filepath, saved.Mimetype, config)
 	if err != nil {
-		return "", fmt.Errorf("gagal membuat file: %v", err)
+		// Scanner tidak tersedia: file tetap di karantina dan akan discan ulang
+		saved.ScanStatus = ScanStatusPending
+		return saved, nil
+	}
+	if scan.ScanStatus == ScanStatusInfected {
+		return nil, &UploadError{
+			Code:    UploadErrInfected,
+			Message: fmt.Sprintf("file %s terdeteksi malware (%s)", filename, scan.ScanSignature),
+		}
+	}
+
+	saved.Filepath = scan.Filepath
+	saved.ThumbnailPath = scan.ThumbnailPath
+	saved.ScanStatus = scan.ScanStatus
+	if scan.Filesize > 0 {
+		saved.Filesize = scan.Filesize
+	}
+	return saved, nil
+}
+
+// QuarantineScan hasil pemrosesan file di karantina
+type QuarantineScan struct {
+	Filepath      string
+	Filesize      int64 // ukuran baru jika file diproses ulang (gambar), 0 jika tidak berubah
+	ThumbnailPath string
+	ScanStatus    string
+	ScanSignature string
+}
+
+// ScanQuarantinedFile men-scan file di karantina. File bersih dipindah ke folder upload
+// (gambar dibersihkan dari metadata dan dibuatkan thumbnail), file terinfeksi dihapus.
+// File yang melebihi batas ukuran scanner dikeluarkan dengan status too_large.
+// Jika scanner error, file dibiarkan di karantina.
+func ScanQuarantinedFile(path, mimetype string, config FileUploadConfig) (*QuarantineScan, error) {
+	status := ScanStatusSkipped
+	if config.Scanner != nil {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuka file: %v", err)
+		}
+		result, err := config.Scanner.Scan(f)
+		f.Close()
+		switch {
+		case errors.Is(err, ErrScanTooLarge):
+			log.Printf("File %s melebihi batas ukuran scanner, dikeluarkan tanpa scan", path)
+			status = ScanStatusTooLarge
+		case err != nil:
+			return nil, err
+		case !result.Clean:
+			os.Remove(path)
+			return &QuarantineScan{
+				Filepath:      path,
+				ScanStatus:    ScanStatusInfected,
+				ScanSignature: result.Signature,
+			}, nil
+		default:
+			status = ScanStatusClean
+		}
 	}
-	defer dst.Close()
 
-	// Copy file
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("gagal menyimpan file: %v", err)
+	if err := os.MkdirAll(config.UploadPath, 0755); err != nil {
+		return nil, fmt.Errorf("gagal membuat folder upload: %v", err)
+	}
+	target := filepath.Join(config.UploadPath, filepath.Base(path))
+	if err := os.Rename(path, target); err != nil {
+		return nil, fmt.Errorf("gagal memindahkan file dari karantina: %v", err)
 	}
 
-	return filepath, nil
+	scan := &QuarantineScan{
+		Filepath:   target,
+		ScanStatus: status,
+	}
+
+	if IsProcessableImage(mimetype) {
+		processed, err := ProcessImage(target, mimetype)
+		if err != nil {
+			// Gambar tetap disimpan tanpa thumbnail
+			log.Printf("Gagal memproses gambar %s: %v", target, err)
+		} else {
+			scan.Filesize = processed.Filesize
+			scan.ThumbnailPath = processed.ThumbnailPath
+		}
+	}
+
+	return scan, nil
 }
 
 // SaveMultipleFiles menyimpan multiple files
-func SaveMultipleFiles(form *multipart.Form, fieldName string, config FileUploadConfig) ([]string, error) {
+func SaveMultipleFiles(form *multipart.Form, fieldName string, config FileUploadConfig) ([]SavedFile, error) {
 	files := form.File[fieldName]
 	if len(files) == 0 {
-		return []string{}, nil
+		return []SavedFile{}, nil
 	}
 
-	var savedFiles []string
+	var savedFiles []SavedFile
 	for _, file := range files {
-		filepath, err := SaveUploadedFile(file, config)
+		saved, err := SaveUploadedFile(file, config)
 		if err != nil {
-			// Rollback: hapus file yang sudah tersimpan
+			// Rollback: hapus file yang sudah tersimpan beserta thumbnail-nya
 			for _, savedFile := range savedFiles {
-				os.Remove(savedFile)
+				DeleteFile(savedFile.Filepath)
+				DeleteFile(savedFile.ThumbnailPath)
 			}
 			return nil, err
 		}
-		savedFiles = append(savedFiles, filepath)
+		savedFiles = append(savedFiles, *saved)
 	}
 
 	return savedFiles, nil
 }
 
+// CheckUploadQuota memastikan total ukuran file baru tidak melebihi kuota
+// achievement maupun kuota student
+func CheckUploadQuota(files []*multipart.FileHeader, achievementUsage, studentUsage int64, config FileUploadConfig) error {
+	var incoming int64
+	for _, file := range files {
+		incoming += file.Size
+	}
+
+	return CheckStorageQuota(incoming, achievementUsage, studentUsage, config)
+}
+
+// CheckStorageQuota memastikan penambahan sebesar incoming byte tidak melebihi kuota
+func CheckStorageQuota(incoming, achievementUsage, studentUsage int64, config FileUploadConfig) error {
+	if config.MaxAchievementStorage > 0 && achievementUsage+incoming > config.MaxAchievementStorage {
+		return &UploadError{
+			Code: UploadErrAchievementQuota,
+			Message: fmt.Sprintf("kuota dokumen achievement terlampaui. Maksimal %d MB per achievement",
+				config.MaxAchievementStorage/(1024*1024)),
+		}
+	}
+
+	if config.MaxStudentStorage > 0 && studentUsage+incoming > config.MaxStudentStorage {
+		return &UploadError{
+			Code: UploadErrStudentQuota,
+			Message: fmt.Sprintf("kuota penyimpanan mahasiswa terlampaui. Maksimal %d MB per mahasiswa",
+				config.MaxStudentStorage/(1024*1024)),
+		}
+	}
+
+	return nil
+}
+
+// DetectMimeType mendeteksi MIME type berdasarkan magic bytes isi file
+func DetectMimeType(r io.ReaderAt, size int64) (string, error) {
+	header := make([]byte, 12)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("%PDF-")):
+		return "application/pdf", nil
+	case bytes.HasPrefix(header, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg", nil
+	case bytes.HasPrefix(header, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return "image/png", nil
+	case bytes.HasPrefix(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}):
+		return "application/msword", nil
+	case bytes.HasPrefix(header, []byte{'P', 'K', 0x03, 0x04}):
+		// DOCX adalah arsip ZIP yang berisi word/document.xml
+		if isWordDocument(r, size) {
+			return extensionMimeTypes[".docx"], nil
+		}
+		return "application/zip", nil
+	case len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")):
+		// Container ISO BMFF: brand "qt  " untuk QuickTime, selain itu MP4
+		if bytes.Equal(header[8:12], []byte("qt  ")) {
+			return "video/quicktime", nil
+		}
+		return "video/mp4", nil
+	}
+
+	return "application/octet-stream", nil
+}
+
+// isWordDocument mengecek apakah arsip ZIP merupakan dokumen Word (OOXML)
+func isWordDocument(r io.ReaderAt, size int64) bool {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return false
+	}
+	for _, f := range archive.File {
+		if f.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteFile menghapus file
 func DeleteFile(filepath string) error {
 	if filepath == "" {
@@ -127,4 +443,4 @@ func generateUniqueFilename(originalFilename string) string {
 // GetFileInfo mendapatkan informasi file
 func GetFileInfo(file *multipart.FileHeader) (filename string, size int64, mimetype string) {
 	return file.Filename, file.Size, file.Header.Get("Content-Type")
-}
\ No newline at end of file
+}
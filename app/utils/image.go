@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Ukuran maksimal sisi terpanjang thumbnail (pixel)
+const ThumbnailMaxSize = 320
+
+// MaxImagePixels batas jumlah pixel (lebar x tinggi) gambar yang boleh di-decode.
+// Decode penuh mengalokasikan 4 byte per pixel, jadi header dengan dimensi besar
+// dari file kecil bisa menghabiskan memori server.
+const MaxImagePixels = 40_000_000 // 40 MP
+
+// ProcessedImage hasil pemrosesan gambar upload
+type ProcessedImage struct {
+	Filesize      int64
+	ThumbnailPath string
+}
+
+// IsProcessableImage mengecek apakah MIME type merupakan gambar yang diproses
+func IsProcessableImage(mimetype string) bool {
+	return mimetype == "image/jpeg" || mimetype == "image/png"
+}
+
+// ProcessImage menghapus metadata (EXIF, GPS, dll) dari gambar dengan meng-encode ulang
+// file di tempat, lalu membuat thumbnail di folder yang sama
+func ProcessImage(path, mimetype string) (*ProcessedImage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca gambar: %v", err)
+	}
+
+	if err := CheckImageDimensions(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gagal decode gambar: %v", err)
+	}
+
+	// Orientasi EXIF diterapkan ke pixel sebelum metadata dibuang
+	if mimetype == "image/jpeg" {
+		img = applyOrientation(img, readJPEGOrientation(data))
+	}
+
+	// Encode ulang tanpa metadata
+	if err := writeImage(path, img, mimetype); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buat thumbnail di samping file asli
+	thumbnailPath := thumbnailPathFor(path)
+	if err := writeImage(thumbnailPath, resizeToFit(img, ThumbnailMaxSize), mimetype); err != nil {
+		return nil, err
+	}
+
+	return &ProcessedImage{
+		Filesize:      info.Size(),
+		ThumbnailPath: thumbnailPath,
+	}, nil
+}
+
+// CheckImageDimensions membaca header gambar dan menolak gambar yang jumlah pixelnya
+// melebihi MaxImagePixels, tanpa men-decode seluruh gambar
+func CheckImageDimensions(r io.Reader) error {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return fmt.Errorf("gagal membaca header gambar: %v", err)
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return fmt.Errorf("dimensi gambar %dx%d melebihi batas %d MP", config.Width, config.Height, MaxImagePixels/1_000_000)
+	}
+	return nil
+}
+
+// thumbnailPathFor menghasilkan path thumbnail, contoh: foto_x.jpg -> foto_x_thumb.jpg
+func thumbnailPathFor(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "_thumb" + ext
+}
+
+func writeImage(path string, img image.Image, mimetype string) error {
+	var buf bytes.Buffer
+	var err error
+	if mimetype == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		return fmt.Errorf("gagal encode gambar: %v", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("gagal menyimpan gambar: %v", err)
+	}
+	return nil
+}
+
+// resizeToFit mengecilkan gambar agar sisi terpanjang tidak melebihi maxSize
+func resizeToFit(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxSize / width
+		width = maxSize
+	} else {
+		width = width * maxSize / height
+		height = maxSize
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// readJPEGOrientation membaca tag Orientation (0x0112) dari segmen APP1 Exif.
+// Mengembalikan 1 (normal) jika tidak ditemukan.
+func readJPEGOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		// Start of Scan: tidak ada metadata lagi setelah ini
+		if marker == 0xDA || length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseTIFFOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+func parseTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation memutar/membalik gambar sesuai nilai orientasi EXIF (1-8)
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // flip vertical
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90 CW
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 90 CCW
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
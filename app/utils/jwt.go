@@ -2,7 +2,6 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	models "crud-app/app/model"
@@ -10,13 +9,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte(func() string {
-	if s := os.Getenv("JWT_SECRET"); s != "" {
-		return s
-	}
-	// default (untuk dev). Pastikan di production diganti.
-	return "your-secret-key-here"
-}())
+// JWTConfig kunci tanda tangan dan masa berlaku token
+type JWTConfig struct {
+	Secret   []byte
+	TokenTTL time.Duration
+}
 
 type JwtClaims struct {
 	UserID   string `json:"user_id"`
@@ -25,23 +22,23 @@ type JwtClaims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(user models.User) (string, error) {
+func GenerateToken(user models.User, config JWTConfig) (string, error) {
 	claims := JwtClaims{
 		UserID:   user.ID,
 		Username: user.Username,
 		RoleID:   user.RoleID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.TokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return token.SignedString(config.Secret)
 }
 
-func ValidateToken(tokenString string) (*JwtClaims, error) {
+func ValidateToken(tokenString string, config JWTConfig) (*JwtClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JwtClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
+		return config.Secret, nil
 	})
 	if err != nil {
 		return nil, err
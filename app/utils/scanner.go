@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Status hasil scan antivirus dokumen
+const (
+	ScanStatusPending  = "pending"   // masih di karantina, belum berhasil discan
+	ScanStatusClean    = "clean"     // lolos scan, file dipindah ke folder upload
+	ScanStatusInfected = "infected"  // terdeteksi malware, file dihapus
+	ScanStatusSkipped  = "skipped"   // scanner tidak dikonfigurasi
+	ScanStatusTooLarge = "too_large" // melebihi batas ukuran stream scanner, dikeluarkan tanpa scan
+)
+
+// ErrScanTooLarge dikembalikan scanner jika file melebihi batas ukuran yang bisa discan.
+// Scan ulang akan selalu gagal, jadi file tidak dibiarkan pending di karantina.
+var ErrScanTooLarge = errors.New("file melebihi batas ukuran stream scanner")
+
+// DefaultClamdMaxStreamSize sama dengan default StreamMaxLength clamd (25MB)
+const DefaultClamdMaxStreamSize = 25 * 1024 * 1024
+
+// ScanResult hasil scan sebuah file
+type ScanResult struct {
+	Clean     bool
+	Signature string // nama signature malware jika terdeteksi
+}
+
+// Scanner adalah antarmuka untuk antivirus scanner
+type Scanner interface {
+	Scan(r io.Reader) (*ScanResult, error)
+}
+
+// ClamdScanner melakukan scan melalui daemon clamd menggunakan protokol INSTREAM.
+// MaxStreamSize harus sama dengan StreamMaxLength di clamd.conf (0 = tanpa batas di sisi client).
+type ClamdScanner struct {
+	Address       string
+	Timeout       time.Duration
+	ChunkSize     int
+	MaxStreamSize int64
+}
+
+func NewClamdScanner(address string) *ClamdScanner {
+	return &ClamdScanner{
+		Address:       address,
+		Timeout:       30 * time.Second,
+		ChunkSize:     64 * 1024,
+		MaxStreamSize: DefaultClamdMaxStreamSize,
+	}
+}
+
+// Scan mengirim isi file ke clamd dan membaca hasilnya
+func (s *ClamdScanner) Scan(r io.Reader) (*ScanResult, error) {
+	conn, err := net.DialTimeout("tcp", s.Address, s.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("gagal terhubung ke clamd: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(s.Timeout))
+
+	// Perintah INSTREAM dengan terminator null (z-prefix)
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("gagal mengirim perintah ke clamd: %v", err)
+	}
+
+	// Kirim data per chunk: 4 byte panjang (big-endian) diikuti data
+	buf := make([]byte, s.ChunkSize)
+	size := make([]byte, 4)
+	var sent int64
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			// clamd menutup koneksi jika stream melebihi StreamMaxLength
+			sent += int64(n)
+			if s.MaxStreamSize > 0 && sent > s.MaxStreamSize {
+				return nil, ErrScanTooLarge
+			}
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return nil, fmt.Errorf("gagal mengirim data ke clamd: %v", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return nil, fmt.Errorf("gagal mengirim data ke clamd: %v", err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca file: %v", err)
+		}
+	}
+
+	// Chunk dengan panjang 0 menandakan akhir stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, fmt.Errorf("gagal mengirim data ke clamd: %v", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("gagal membaca respon clamd: %v", err)
+	}
+
+	return parseClamdReply(reply)
+}
+
+// parseClamdReply mem-parsing respon clamd, contoh: "stream: OK",
+// "stream: Eicar-Test-Signature FOUND" atau "INSTREAM size limit exceeded. ERROR"
+func parseClamdReply(reply string) (*ScanResult, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case result == "OK":
+		return &ScanResult{Clean: true}, nil
+	case strings.Contains(result, "size limit exceeded"):
+		return nil, ErrScanTooLarge
+	case strings.HasSuffix(result, "FOUND"):
+		return &ScanResult{
+			Clean:     false,
+			Signature: strings.TrimSpace(strings.TrimSuffix(result, "FOUND")),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("respon clamd tidak dikenal: %s", reply)
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Panjang maksimal potongan teks (snippet) hasil highlight
+const HighlightSnippetLength = 200
+
+// ParseSearchTerms memecah query pencarian menjadi kata kunci untuk highlight.
+// Kata yang diawali "-" (negasi pada MongoDB $text) diabaikan.
+func ParseSearchTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"'
+	})
+	for _, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		term := strings.ToLower(strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}))
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// HighlightText membungkus kata yang diawali salah satu term dengan <mark></mark>.
+// Teks di-escape terlebih dahulu sehingga aman ditampilkan sebagai HTML. Jika teks
+// lebih panjang dari maxLength, diambil potongan di sekitar kecocokan pertama.
+// Mengembalikan string kosong jika tidak ada kata yang cocok.
+func HighlightText(text string, terms []string, maxLength int) string {
+	runes := []rune(text)
+
+	type span struct{ start, end int }
+	var matches []span
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		word := strings.ToLower(string(runes[start:i]))
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				matches = append(matches, span{start, i})
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+
+	// Potong teks di sekitar kecocokan pertama
+	from, to := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		from = matches[0].start - maxLength/4
+		if from < 0 {
+			from = 0
+		}
+		to = from + maxLength
+		if to > len(runes) {
+			to = len(runes)
+			from = to - maxLength
+		}
+		// Jangan memotong di tengah kata
+		for from > 0 && from < matches[0].start && isWordRune(runes[from-1]) {
+			from++
+		}
+		for to < len(runes) && to > matches[0].end && isWordRune(runes[to]) {
+			to--
+		}
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("...")
+	}
+	pos := from
+	for _, m := range matches {
+		if m.start < from || m.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:m.start])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		b.WriteString("</mark>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("...")
+	}
+
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
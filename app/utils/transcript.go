@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	models "crud-app/app/model"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// TranscriptVerificationCode menghitung kode verifikasi transkrip dari identitas mahasiswa
+// yang dicetak (nama, NIM, program studi, angkatan, dosen wali) dan achievement
+// terverifikasi beserta tanggal verifikasinya. Kode yang sama hanya dihasilkan ulang
+// selama isi transkrip tidak berubah. secret kunci HMAC kode verifikasi.
+func TranscriptVerificationCode(student *models.StudentDetail, achievements []models.AchievementView, secret []byte) string {
+	entries := make([]string, 0, len(achievements))
+	for _, achievement := range achievements {
+		verifiedAt := int64(0)
+		if achievement.VerifiedAt != nil {
+			verifiedAt = achievement.VerifiedAt.Unix()
+		}
+		entries = append(entries, fmt.Sprintf("%s:%d", achievement.AchievementID, verifiedAt))
+	}
+	sort.Strings(entries)
+
+	identity := []string{student.UserID, student.FullName, student.StudentID, student.ProgramStudy, student.AcademicYear, student.AdvisorName}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join(identity, "\x00") + "|" + strings.Join(entries, "|")))
+	sum := strings.ToUpper(hex.EncodeToString(mac.Sum(nil))[:16])
+	return sum[0:4] + "-" + sum[4:8] + "-" + sum[8:12] + "-" + sum[12:16]
+}
+
+// Lebar kolom tabel achievement transkrip (mm), total 180 mm untuk A4 dengan margin 15 mm
+var transcriptColumns = []struct {
+	title string
+	width float64
+}{
+	{"No", 10},
+	{"Prestasi", 62},
+	{"Kategori", 30},
+	{"Tingkat", 24},
+	{"Tanggal", 27},
+	{"Diverifikasi", 27},
+}
+
+// RenderTranscriptPDF menulis transkrip prestasi dalam format PDF (A4)
+func RenderTranscriptPDF(w io.Writer, transcript *models.Transcript) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	generatedAt := transcript.GeneratedAt.Format("02-01-2006 15:04")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(90, 5, tr("Kode verifikasi: "+transcript.VerificationCode), "", 0, "L", false, 0, "")
+		pdf.CellFormat(90, 5, fmt.Sprintf("Dibuat %s - Halaman %d/{nb}", generatedAt, pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	// Judul
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "TRANSKRIP PRESTASI MAHASISWA", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "Lampiran Surat Keterangan Pendamping Ijazah (SKPI)", "", 1, "C", false, 0, "")
+	pdf.Ln(6)
+
+	// Identitas mahasiswa
+	student := transcript.Student
+	identity := [][2]string{
+		{"Nama", student.FullName},
+		{"NIM", student.StudentID},
+		{"Program Studi", student.ProgramStudy},
+		{"Angkatan", student.AcademicYear},
+		{"Dosen Wali", student.AdvisorName},
+	}
+	for _, row := range identity {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(35, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, tr(": "+row[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Tabel achievement terverifikasi
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for _, column := range transcriptColumns {
+		pdf.CellFormat(column.width, 7, column.title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	if len(transcript.Achievements) == 0 {
+		pdf.CellFormat(0, 7, "Belum ada prestasi terverifikasi", "1", 1, "C", false, 0, "")
+	}
+	for i, achievement := range transcript.Achievements {
+		verifiedAt := ""
+		if achievement.VerifiedAt != nil {
+			verifiedAt = achievement.VerifiedAt.Format("02-01-2006")
+		}
+		writeTranscriptRow(pdf, []string{
+			fmt.Sprintf("%d", i+1),
+			tr(achievement.Title),
+			tr(achievement.Category),
+			tr(achievement.Level),
+			achievement.Date.Format("02-01-2006"),
+			verifiedAt,
+		})
+	}
+
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(0, 5, fmt.Sprintf(
+		"Transkrip ini memuat %d prestasi yang telah diverifikasi oleh dosen wali. "+
+			"Keaslian dokumen dapat diperiksa dengan kode verifikasi %s.",
+		len(transcript.Achievements), transcript.VerificationCode,
+	), "", "L", false)
+
+	return pdf.Output(w)
+}
+
+// writeTranscriptRow menulis satu baris tabel dengan tinggi mengikuti sel terpanjang
+func writeTranscriptRow(pdf *gofpdf.Fpdf, values []string) {
+	const lineHeight = 5.0
+
+	lines := make([][][]byte, len(values))
+	height := lineHeight
+	for i, value := range values {
+		lines[i] = pdf.SplitLines([]byte(value), transcriptColumns[i].width-2)
+		if h := float64(len(lines[i])) * lineHeight; h > height {
+			height = h
+		}
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	left, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+height > pageHeight-bottom {
+		pdf.AddPage()
+	}
+
+	x, y := left, pdf.GetY()
+	for i, column := range transcriptColumns {
+		pdf.Rect(x, y, column.width, height, "D")
+		for j, line := range lines[i] {
+			pdf.SetXY(x, y+float64(j)*lineHeight)
+			pdf.CellFormat(column.width, lineHeight, string(line), "", 0, "L", false, 0, "")
+		}
+		x += column.width
+	}
+	pdf.SetXY(left, y+height)
+}
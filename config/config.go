@@ -0,0 +1,482 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config konfigurasi aplikasi. Setiap field dibaca dari key pada tag env dengan urutan
+// prioritas: nilai default < file .env (--env-file) < file konfigurasi (--config /
+// CONFIG_FILE) < environment variable < flag command line (nama key lowercase dengan "-",
+// contoh --db-max-open-conns).
+type Config struct {
+	App      AppConfig
+	Postgres PostgresConfig
+	Mongo    MongoConfig
+	Auth     AuthConfig
+	Upload   UploadConfig
+	Export   ExportConfig
+	FileGC   FileGCConfig
+	Cache    CacheConfig
+	Seed     SeedConfig
+}
+
+// AppConfig server HTTP. Timeout 0 berarti tanpa batas.
+type AppConfig struct {
+	Port         int           `env:"APP_PORT"`
+	ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT"`
+	BodyLimit    int           `env:"HTTP_BODY_LIMIT"`
+}
+
+// PostgresConfig koneksi dan pool PostgreSQL
+type PostgresConfig struct {
+	DSN             Secret        `env:"DB_DSN"`
+	MaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `env:"DB_CONN_MAX_IDLE_TIME"`
+	ConnectTimeout  time.Duration `env:"DB_CONNECT_TIMEOUT"`
+}
+
+// MongoConfig koneksi dan pool MongoDB
+type MongoConfig struct {
+	DSN            Secret        `env:"MONGO_DSN"`
+	Database       string        `env:"MONGO_DATABASE"`
+	MaxPoolSize    int           `env:"MONGO_MAX_POOL_SIZE"`
+	ConnectTimeout time.Duration `env:"MONGO_CONNECT_TIMEOUT"`
+}
+
+// AuthConfig JWT dan kunci tanda tangan/enkripsi. Kunci yang kosong memakai JWTSecret.
+type AuthConfig struct {
+	JWTSecret         Secret        `env:"JWT_SECRET"`
+	TokenTTL          time.Duration `env:"JWT_TOKEN_TTL"`
+	CursorSecret      Secret        `env:"CURSOR_SECRET"`
+	TranscriptSecret  Secret        `env:"TRANSCRIPT_SECRET"`
+	CredentialsSecret Secret        `env:"CREDENTIALS_SECRET"`
+	CredentialsTTL    time.Duration `env:"USER_IMPORT_CREDENTIALS_TTL"`
+	// Batas permintaan verifikasi transkrip publik per menit per IP
+	TranscriptVerifyRateLimit int `env:"TRANSCRIPT_VERIFY_RATE_LIMIT"`
+}
+
+// UploadConfig batas dan folder upload dokumen. Ukuran dalam byte, kecuali
+// CategoryLimitsMB per kode kategori taxonomy (contoh: kompetisi=200,penelitian=100).
+type UploadConfig struct {
+	Path                  string        `env:"UPLOAD_PATH"`
+	QuarantinePath        string        `env:"UPLOAD_QUARANTINE_PATH"`
+	PartialPath           string        `env:"UPLOAD_PARTIAL_PATH"`
+	MaxFileSize           int64         `env:"MAX_FILE_SIZE"`
+	AllowedFileTypes      []string      `env:"ALLOWED_FILE_TYPES"`
+	MaxAchievementStorage int64         `env:"UPLOAD_MAX_ACHIEVEMENT_STORAGE"`
+	MaxStudentStorage     int64         `env:"UPLOAD_MAX_STUDENT_STORAGE"`
+	MaxChunkSize          int64         `env:"UPLOAD_MAX_CHUNK_SIZE"`
+	ChunkedMaxFileSize    int64         `env:"UPLOAD_CHUNKED_MAX_FILE_SIZE"`
+	CategoryLimitsMB      string        `env:"UPLOAD_CATEGORY_LIMITS_MB"`
+	SessionTTL            time.Duration `env:"UPLOAD_SESSION_TTL"`
+	ClamdAddress          string        `env:"CLAMD_ADDRESS"`
+	ClamdMaxStreamSize    int64         `env:"CLAMD_MAX_STREAM_SIZE"`
+	RescanInterval        time.Duration `env:"UPLOAD_RESCAN_INTERVAL"`
+}
+
+// ExportConfig export laporan background job
+type ExportConfig struct {
+	Path         string        `env:"EXPORT_PATH"`
+	SyncRowLimit int64         `env:"EXPORT_SYNC_ROW_LIMIT"`
+	Retention    time.Duration `env:"EXPORT_RETENTION"`
+}
+
+// FileGCConfig garbage collector file upload
+type FileGCConfig struct {
+	Interval          time.Duration `env:"FILE_GC_INTERVAL"`
+	Retention         time.Duration `env:"FILE_GC_RETENTION"`
+	OrphanGracePeriod time.Duration `env:"FILE_GC_ORPHAN_GRACE_PERIOD"`
+}
+
+// CacheConfig TTL cache in-memory
+type CacheConfig struct {
+	PermissionTTL   time.Duration `env:"CACHE_PERMISSION_TTL"`
+	LeaderboardTTL  time.Duration `env:"CACHE_LEADERBOARD_TTL"`
+	CleanupInterval time.Duration `env:"CACHE_CLEANUP_INTERVAL"`
+}
+
+// SeedConfig admin awal untuk command seed (kosong = tidak membuat admin)
+type SeedConfig struct {
+	AdminUsername string `env:"SEED_ADMIN_USERNAME"`
+	AdminEmail    string `env:"SEED_ADMIN_EMAIL"`
+	AdminPassword Secret `env:"SEED_ADMIN_PASSWORD"`
+	AdminFullName string `env:"SEED_ADMIN_FULL_NAME"`
+}
+
+// Secret nilai rahasia yang tidak pernah ditampilkan saat dicetak atau di-log
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return strconv.Quote(s.String())
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.String())), nil
+}
+
+// Value mengembalikan nilai asli secret
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// Default konfigurasi bawaan, sama dengan nilai default utils dan service
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Port:      3000,
+			BodyLimit: 4 * 1024 * 1024, // default Fiber
+		},
+		Postgres: PostgresConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  10 * time.Second,
+		},
+		Mongo: MongoConfig{
+			DSN:            "mongodb://localhost:27017/",
+			Database:       "test",
+			MaxPoolSize:    100,
+			ConnectTimeout: 10 * time.Second,
+		},
+		Auth: AuthConfig{
+			TokenTTL:                  24 * time.Hour,
+			CredentialsTTL:            1 * time.Hour,
+			TranscriptVerifyRateLimit: 30,
+		},
+		Upload: UploadConfig{
+			Path:                  "./uploads/achievements",
+			QuarantinePath:        "./uploads/quarantine",
+			PartialPath:           "./uploads/partial",
+			MaxFileSize:           5 * 1024 * 1024, // 5MB
+			AllowedFileTypes:      []string{".pdf", ".jpg", ".jpeg", ".png", ".doc", ".docx"},
+			MaxAchievementStorage: 20 * 1024 * 1024,  // 20MB
+			MaxStudentStorage:     200 * 1024 * 1024, // 200MB
+			MaxChunkSize:          2 * 1024 * 1024,   // 2MB
+			ChunkedMaxFileSize:    50 * 1024 * 1024,  // 50MB
+			SessionTTL:            24 * time.Hour,
+			ClamdMaxStreamSize:    25 * 1024 * 1024, // default StreamMaxLength clamd
+			RescanInterval:        5 * time.Minute,
+		},
+		Export: ExportConfig{
+			Path:         "./exports",
+			SyncRowLimit: 5000,
+			Retention:    24 * time.Hour,
+		},
+		FileGC: FileGCConfig{
+			Interval:          1 * time.Hour,
+			Retention:         30 * 24 * time.Hour, // 30 hari
+			OrphanGracePeriod: 1 * time.Hour,
+		},
+		Cache: CacheConfig{
+			PermissionTTL:   15 * time.Minute,
+			LeaderboardTTL:  5 * time.Minute,
+			CleanupInterval: 5 * time.Minute,
+		},
+	}
+}
+
+// DefaultEnvFile file .env yang dibaca jika --env-file tidak diisi. Tidak wajib ada.
+const DefaultEnvFile = ".env"
+
+// Load membaca konfigurasi dari .env, file konfigurasi, environment dan flag lalu
+// memvalidasinya. Flag ditulis sebelum command, contoh: "--app-port 8080 migrate up".
+// Mengembalikan argumen sisa (command beserta argumennya).
+func Load(args []string) (*Config, []string, error) {
+	return Parse(args, os.LookupEnv)
+}
+
+// Parse sama dengan Load, environment diambil dari lookupEnv. File .env dibaca sebagai
+// lapisan file di bawah --config dan tidak disalin ke environment proses.
+func Parse(args []string, lookupEnv func(string) (string, bool)) (*Config, []string, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	flags := flag.NewFlagSet("crud-app", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	envFile := flags.String("env-file", DefaultEnvFile, "file .env dasar, di bawah --config")
+	configFile := flags.String("config", "", "file konfigurasi berformat KEY=VALUE (.env)")
+	flagValues := make(map[string]string)
+	for _, f := range fields {
+		key := f.key
+		flags.Func(f.flagName(), "override "+key, func(value string) error {
+			flagValues[key] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	// Step 1: File .env lalu file konfigurasi di atasnya. .env default boleh tidak ada.
+	fileValues := map[string]string{}
+	if *envFile != "" {
+		values, err := godotenv.Read(*envFile)
+		if err != nil && (*envFile != DefaultEnvFile || !os.IsNotExist(err)) {
+			return nil, nil, fmt.Errorf("gagal membaca file .env %s: %v", *envFile, err)
+		}
+		for key, value := range values {
+			fileValues[key] = value
+		}
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gagal membaca file konfigurasi %s: %v", path, err)
+		}
+		for key, value := range values {
+			fileValues[key] = value
+		}
+	}
+
+	// Step 2: Terapkan file, environment lalu flag
+	var errs []string
+	for _, f := range fields {
+		value, ok := flagValues[f.key]
+		if !ok {
+			value, ok = lookupEnv(f.key)
+		}
+		if !ok {
+			value, ok = fileValues[f.key]
+		}
+		if !ok {
+			continue
+		}
+		if err := f.set(value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", f.key, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("konfigurasi tidak valid: %s", strings.Join(errs, "; "))
+	}
+
+	// Step 3: Kunci turunan default ke JWT_SECRET
+	for _, secret := range []*Secret{&cfg.Auth.CursorSecret, &cfg.Auth.TranscriptSecret, &cfg.Auth.CredentialsSecret} {
+		if *secret == "" {
+			*secret = cfg.Auth.JWTSecret
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, flags.Args(), nil
+}
+
+// Validate memeriksa field wajib, rentang nilai dan konsistensi antar field
+func (c *Config) Validate() error {
+	var errs []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.App.Port >= 1 && c.App.Port <= 65535, "APP_PORT harus antara 1 dan 65535")
+	check(c.App.ReadTimeout >= 0 && c.App.WriteTimeout >= 0 && c.App.IdleTimeout >= 0, "HTTP_*_TIMEOUT tidak boleh negatif")
+	check(c.App.BodyLimit > 0, "HTTP_BODY_LIMIT harus lebih dari 0")
+
+	check(c.Postgres.DSN != "", "DB_DSN wajib diisi")
+	check(c.Postgres.MaxOpenConns >= 1, "DB_MAX_OPEN_CONNS minimal 1")
+	check(c.Postgres.MaxIdleConns >= 0 && c.Postgres.MaxIdleConns <= c.Postgres.MaxOpenConns, "DB_MAX_IDLE_CONNS harus antara 0 dan DB_MAX_OPEN_CONNS")
+	check(c.Postgres.ConnMaxLifetime >= 0 && c.Postgres.ConnMaxIdleTime >= 0, "DB_CONN_MAX_LIFETIME dan DB_CONN_MAX_IDLE_TIME tidak boleh negatif")
+	check(c.Postgres.ConnectTimeout > 0, "DB_CONNECT_TIMEOUT harus lebih dari 0")
+
+	check(c.Mongo.DSN != "", "MONGO_DSN wajib diisi")
+	check(c.Mongo.Database != "", "MONGO_DATABASE wajib diisi")
+	check(c.Mongo.MaxPoolSize >= 1, "MONGO_MAX_POOL_SIZE minimal 1")
+	check(c.Mongo.ConnectTimeout > 0, "MONGO_CONNECT_TIMEOUT harus lebih dari 0")
+
+	check(c.Auth.JWTSecret != "", "JWT_SECRET wajib diisi")
+	check(c.Auth.TokenTTL >= time.Minute && c.Auth.TokenTTL <= 30*24*time.Hour, "JWT_TOKEN_TTL harus antara 1m dan 720h")
+	check(c.Auth.TranscriptVerifyRateLimit >= 1, "TRANSCRIPT_VERIFY_RATE_LIMIT minimal 1")
+	check(c.Auth.CredentialsTTL > 0, "USER_IMPORT_CREDENTIALS_TTL harus lebih dari 0")
+
+	check(c.Upload.Path != "" && c.Upload.QuarantinePath != "" && c.Upload.PartialPath != "", "UPLOAD_PATH, UPLOAD_QUARANTINE_PATH dan UPLOAD_PARTIAL_PATH wajib diisi")
+	check(c.Upload.MaxFileSize > 0, "MAX_FILE_SIZE harus lebih dari 0")
+	check(len(c.Upload.AllowedFileTypes) > 0, "ALLOWED_FILE_TYPES wajib diisi")
+	for _, ext := range c.Upload.AllowedFileTypes {
+		check(strings.HasPrefix(ext, ".") && len(ext) > 1, "ALLOWED_FILE_TYPES: %q harus diawali titik, contoh .pdf", ext)
+	}
+	check(c.Upload.MaxAchievementStorage >= 0 && c.Upload.MaxStudentStorage >= 0, "UPLOAD_MAX_*_STORAGE tidak boleh negatif (0 = tanpa batas)")
+	check(c.Upload.MaxChunkSize > 0 && c.Upload.MaxChunkSize < int64(c.App.BodyLimit), "UPLOAD_MAX_CHUNK_SIZE harus lebih dari 0 dan di bawah HTTP_BODY_LIMIT")
+	check(c.Upload.ChunkedMaxFileSize > 0, "UPLOAD_CHUNKED_MAX_FILE_SIZE harus lebih dari 0")
+	if _, err := ParseCategorySizeLimits(c.Upload.CategoryLimitsMB); err != nil {
+		errs = append(errs, fmt.Sprintf("UPLOAD_CATEGORY_LIMITS_MB: %v", err))
+	}
+	check(c.Upload.SessionTTL > 0, "UPLOAD_SESSION_TTL harus lebih dari 0")
+	check(c.Upload.RescanInterval > 0, "UPLOAD_RESCAN_INTERVAL harus lebih dari 0")
+	check(c.Upload.ClamdMaxStreamSize >= 0, "CLAMD_MAX_STREAM_SIZE tidak boleh negatif (0 = tanpa batas)")
+
+	check(c.Export.Path != "", "EXPORT_PATH wajib diisi")
+	check(c.Export.SyncRowLimit >= 0, "EXPORT_SYNC_ROW_LIMIT tidak boleh negatif")
+	check(c.Export.Retention > 0, "EXPORT_RETENTION harus lebih dari 0")
+	// File GC menghapus file orphan di seluruh UPLOAD_PATH, folder lain tidak boleh di dalamnya
+	for _, dir := range []struct{ key, path string }{
+		{"UPLOAD_QUARANTINE_PATH", c.Upload.QuarantinePath},
+		{"UPLOAD_PARTIAL_PATH", c.Upload.PartialPath},
+		{"EXPORT_PATH", c.Export.Path},
+	} {
+		check(c.Upload.Path == "" || dir.path == "" || !isWithinDir(c.Upload.Path, dir.path), "%s tidak boleh berada di dalam UPLOAD_PATH", dir.key)
+	}
+
+	check(c.FileGC.Interval > 0 && c.FileGC.Retention > 0 && c.FileGC.OrphanGracePeriod > 0, "FILE_GC_* harus lebih dari 0")
+
+	check(c.Cache.PermissionTTL > 0 && c.Cache.LeaderboardTTL > 0, "CACHE_*_TTL harus lebih dari 0")
+	check(c.Cache.CleanupInterval > 0, "CACHE_CLEANUP_INTERVAL harus lebih dari 0")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("konfigurasi tidak valid: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ParseCategorySizeLimits mem-parsing batas ukuran per kode kategori taxonomy dalam MB,
+// contoh: "kompetisi=200,penelitian=100"
+func ParseCategorySizeLimits(value string) (map[string]int64, error) {
+	limits := make(map[string]int64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		category, size, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("format batas kategori tidak valid: %s", pair)
+		}
+		megabytes, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+		if err != nil || megabytes <= 0 {
+			return nil, fmt.Errorf("ukuran untuk kategori %s tidak valid: %s", category, size)
+		}
+		limits[strings.ToLower(strings.TrimSpace(category))] = megabytes * 1024 * 1024
+	}
+	return limits, nil
+}
+
+// isWithinDir mengecek apakah path sama dengan atau berada di dalam folder dir
+func isWithinDir(dir, path string) bool {
+	dirAbs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	pathAbs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dirAbs, pathAbs)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// String menampilkan semua konfigurasi sebagai KEY=VALUE, secret disamarkan
+func (c *Config) String() string {
+	fields := c.fields()
+	lines := make([]string, 0, len(fields))
+	for _, f := range fields {
+		lines = append(lines, f.key+"="+f.String())
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// field satu nilai konfigurasi yang bisa diisi dari file, environment atau flag
+type field struct {
+	key   string
+	value reflect.Value
+}
+
+// fields mengambil semua field bertag env dari struct konfigurasi
+func (c *Config) fields() []field {
+	var fields []field
+	sections := reflect.ValueOf(c).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			if key := section.Type().Field(j).Tag.Get("env"); key != "" {
+				fields = append(fields, field{key: key, value: section.Field(j)})
+			}
+		}
+	}
+	return fields
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(strings.ToLower(f.key), "_", "-")
+}
+
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch v := f.value.Addr().Interface().(type) {
+	case *string:
+		*v = raw
+	case *Secret:
+		*v = Secret(raw)
+	case *int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*v = n
+	case *int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*v = n
+	case *time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q bukan durasi, contoh 30s, 15m, 24h", raw)
+		}
+		*v = d
+	case *[]string:
+		var values []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+				values = append(values, item)
+			}
+		}
+		*v = values
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", f.value.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	switch v := f.value.Interface().(type) {
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,13 +0,0 @@
-package config
-
-import (
-    "log"
-    "github.com/joho/godotenv"
-)
-
-func LoadEnv() {
-    err := godotenv.Load()
-    if err != nil {
-        log.Println("No .env file found, using system environment")
-    }
-}
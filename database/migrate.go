@@ -0,0 +1,322 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration SQL (up dan down) ditanam di binary. Nama file:
+// <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql, contoh 0002_scoring_rubrics.up.sql.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID kunci advisory lock PostgreSQL agar migration tidak berjalan bersamaan
+const migrationLockID = 7283510447
+
+// Migration satu versi skema database
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus status satu migration terhadap database
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrations mengembalikan semua migration yang ditanam di binary, urut berdasarkan versi
+func Migrations() ([]Migration, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return ParseMigrations(sub)
+}
+
+// ParseMigrations membaca file migration dari fsys. Setiap versi wajib memiliki
+// file up dan down, dan versi tidak boleh duplikat.
+func ParseMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		filename := entry.Name()
+		if entry.IsDir() || path.Ext(filename) != ".sql" {
+			continue
+		}
+
+		base := strings.TrimSuffix(filename, ".sql")
+		direction := path.Ext(base)
+		if direction != ".up" && direction != ".down" {
+			return nil, fmt.Errorf("migration %s: nama file harus diakhiri .up.sql atau .down.sql", filename)
+		}
+		versionText, name, ok := strings.Cut(strings.TrimSuffix(base, direction), "_")
+		version, err := strconv.ParseInt(versionText, 10, 64)
+		if !ok || err != nil || version <= 0 || name == "" {
+			return nil, fmt.Errorf("migration %s: nama file harus berformat <versi>_<nama>%s.sql", filename, direction)
+		}
+
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration versi %d memiliki dua nama: %s dan %s", version, migration.Name, name)
+		}
+		target := &migration.Up
+		if direction == ".down" {
+			target = &migration.Down
+		}
+		if *target != "" {
+			return nil, fmt.Errorf("migration %s duplikat", filename)
+		}
+		*target = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s harus memiliki file up dan down", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// PendingMigrations mengembalikan migration yang belum diterapkan. Error jika database
+// memiliki versi yang tidak dikenal binary (binary lebih lama dari skema database).
+func PendingMigrations(migrations []Migration, applied map[int64]time.Time) ([]Migration, error) {
+	known := make(map[int64]bool, len(migrations))
+	var pending []Migration
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	var unknown []string
+	for version := range applied {
+		if !known[version] {
+			unknown = append(unknown, strconv.FormatInt(version, 10))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("database memiliki migration versi %s yang tidak dikenal aplikasi ini", strings.Join(unknown, ", "))
+	}
+	return pending, nil
+}
+
+// CheckSchema memastikan semua migration sudah diterapkan. Dipanggil saat startup
+// agar aplikasi tidak berjalan dengan skema database yang tidak sesuai.
+func CheckSchema(db *sql.DB) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	pending, err := PendingMigrations(migrations, applied)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		names := make([]string, 0, len(pending))
+		for _, migration := range pending {
+			names = append(names, fmt.Sprintf("%04d_%s", migration.Version, migration.Name))
+		}
+		return fmt.Errorf("skema database belum diperbarui, migration belum diterapkan: %s. Jalankan \"go run . migrate up\"", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// MigrateUp menerapkan semua migration yang belum diterapkan. Setiap migration
+// dijalankan dalam transaksi tersendiri bersama pencatatan versinya.
+func MigrateUp(db *sql.DB) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		pending, err := PendingMigrations(migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			err := runMigration(db, migration.Up,
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, NOW())`,
+				migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s gagal: %v", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// MigrateDown membatalkan steps migration terakhir yang sudah diterapkan
+func MigrateDown(db *sql.DB, steps int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		if _, err := PendingMigrations(migrations, applied); err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			err := runMigration(db, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			if err != nil {
+				return fmt.Errorf("rollback migration %04d_%s gagal: %v", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// MigrationStatuses mengembalikan semua migration beserta waktu diterapkannya (nil jika belum)
+func MigrationStatuses(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func ensureMigrationTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`)
+	return err
+}
+
+// appliedMigrations mengambil versi yang sudah diterapkan. Database baru yang belum
+// memiliki tabel schema_migrations dianggap belum menerapkan migration apapun.
+func appliedMigrations(db *sql.DB) (map[int64]time.Time, error) {
+	applied := make(map[int64]time.Time)
+	var exists bool
+	if err := db.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// runMigration menjalankan SQL migration dan query pencatatan versi dalam satu transaksi
+func runMigration(db *sql.DB, statements, record string, args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(statements); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withMigrationLock menjalankan fn dengan advisory lock pada satu koneksi agar dua
+// proses migrate tidak berjalan bersamaan
+func withMigrationLock(db *sql.DB, fn func() error) error {
+	if err := ensureMigrationTable(db); err != nil {
+		return err
+	}
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	return fn()
+}
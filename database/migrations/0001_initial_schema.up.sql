@@ -0,0 +1,79 @@
+-- Skema dasar PostgreSQL: role, permission, user, profil mahasiswa/dosen dan
+-- reference achievement (data lengkap achievement ada di MongoDB).
+-- Memakai IF NOT EXISTS agar database lama yang dibuat manual bisa diadopsi.
+
+CREATE TABLE IF NOT EXISTS roles (
+    id VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    resource VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id VARCHAR(20) NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    username VARCHAR(50) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    full_name VARCHAR(100) NOT NULL,
+    role_id VARCHAR(20) NOT NULL REFERENCES roles(id),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    deleted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- Username dan email hanya unik di antara user yang belum dihapus (soft delete)
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_key ON users (username) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_key ON users (email) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS users_role_id_idx ON users (role_id);
+
+CREATE TABLE IF NOT EXISTS lecturers (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id),
+    lecturer_id VARCHAR(20) NOT NULL UNIQUE,
+    department VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- advisor_id adalah user ID dosen wali
+CREATE TABLE IF NOT EXISTS students (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id),
+    student_id VARCHAR(20) NOT NULL UNIQUE,
+    program_study VARCHAR(100) NOT NULL DEFAULT '',
+    academic_year VARCHAR(10) NOT NULL DEFAULT '',
+    advisor_id UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS students_advisor_id_idx ON students (advisor_id);
+
+-- student_id adalah user ID mahasiswa
+CREATE TABLE IF NOT EXISTS achievement_references (
+    id UUID PRIMARY KEY,
+    student_id UUID NOT NULL REFERENCES users(id),
+    mongo_achievement_id VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'submitted', 'verified', 'rejected')),
+    submitted_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    verified_by UUID REFERENCES users(id),
+    rejection_note TEXT,
+    deleted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS achievement_references_student_id_idx ON achievement_references (student_id);
+CREATE INDEX IF NOT EXISTS achievement_references_status_idx ON achievement_references (status) WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- Rubric poin achievement. Kombinasi kategori dan level unik tanpa membedakan
+-- huruf besar/kecil.
+CREATE TABLE IF NOT EXISTS scoring_rubrics (
+    id UUID PRIMARY KEY,
+    category VARCHAR(100) NOT NULL,
+    level VARCHAR(50) NOT NULL,
+    points INTEGER NOT NULL CHECK (points >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS scoring_rubrics_category_level_key
+    ON scoring_rubrics (LOWER(category), LOWER(level));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS achievement_levels;
+DROP TABLE IF EXISTS achievement_categories;
@@ -0,0 +1,35 @@
+-- Master data kategori dan level achievement. Kode dan nama unik tanpa membedakan
+-- huruf besar/kecil. Kategori yang masih memiliki sub-kategori tidak bisa dihapus.
+-- details_schema berisi JSON Schema details achievement.
+CREATE TABLE IF NOT EXISTS achievement_categories (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    parent_id UUID REFERENCES achievement_categories(id) ON DELETE RESTRICT,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    details_schema JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- Tabel yang dibuat versi lama (sebelum migration) belum memiliki details_schema
+ALTER TABLE achievement_categories ADD COLUMN IF NOT EXISTS details_schema JSONB;
+CREATE UNIQUE INDEX IF NOT EXISTS achievement_categories_code_key
+    ON achievement_categories (LOWER(code));
+CREATE UNIQUE INDEX IF NOT EXISTS achievement_categories_name_key
+    ON achievement_categories (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS achievement_levels (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS achievement_levels_code_key
+    ON achievement_levels (LOWER(code));
+CREATE UNIQUE INDEX IF NOT EXISTS achievement_levels_name_key
+    ON achievement_levels (LOWER(name));
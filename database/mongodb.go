@@ -2,27 +2,25 @@ package database
 
 import (
 	"context"
+	"crud-app/config"
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var DBmongo *mongo.Client
-
-func MongoConnection() *mongo.Client {
-	dsn := os.Getenv("MONGO_DSN")
-	if dsn == "" {
-		dsn = "mongodb://localhost:27017/"
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// MongoConnection membuka koneksi MongoDB dengan pengaturan pool dari konfigurasi
+func MongoConnection(cfg config.MongoConfig) *mongo.Client {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dsn))
+	opts := options.Client().
+		ApplyURI(cfg.DSN.Value()).
+		SetMaxPoolSize(uint64(cfg.MaxPoolSize)).
+		SetConnectTimeout(cfg.ConnectTimeout)
+	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
 		log.Fatalf("Gagal membuat klien MongoDB: %v", err)
 	}
@@ -32,31 +30,19 @@ func MongoConnection() *mongo.Client {
 	}
 
 	log.Println("Koneksi ke MongoDB berhasil!")
-	DBmongo = client
 
 	return client
 }
 
-func GetDB() *mongo.Client {
-	return DBmongo
-}
-
-func GetMongoDatabase() *mongo.Database {
-	dbName := os.Getenv("MONGO_DATABASE")
-	if dbName == "" {
-		dbName = "alumni"
-	}
-	return DBmongo.Database(dbName)
-}
-
-func Ping() error {
-	if DBmongo == nil {
+// Ping memeriksa koneksi MongoDB masih hidup
+func Ping(client *mongo.Client) error {
+	if client == nil {
 		return fmt.Errorf("database connection is not initialized")
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	return DBmongo.Ping(ctx, nil)
+	return client.Ping(ctx, nil)
 }
 
 func CloseDB(client *mongo.Client) {
@@ -21,14 +21,24 @@
 package main
 
 import (
+	"context"
+	"crud-app/app/repository"
+	"crud-app/app/service"
 	"crud-app/app/utils"
+	"crud-app/config"
 	"crud-app/database"
 	"crud-app/route"
+	"database/sql"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/joho/godotenv"
 
 	_ "crud-app/app/docs" // Import generated docs
 
@@ -36,38 +46,282 @@ import (
 )
 
 func main() {
-	err := godotenv.Load()
+	// Konfigurasi dari .env, file --config, environment dan flag sebelum command,
+	// contoh "go run . --app-port 8080". Aplikasi berhenti jika konfigurasi tidak valid.
+	cfg, args, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Println("No .env file found, using system environment variables")
+		log.Fatal(err)
 	}
-
-	if os.Getenv("DB_DSN") == "" {
-		log.Fatal("Set environment variable DB_DSN")
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+	}
+	// Jalankan "go run . config" untuk menampilkan konfigurasi efektif (secret disamarkan)
+	if command == "config" {
+		fmt.Println(cfg)
+		return
 	}
 
-	database.ConnectDB()
-	defer database.DB.Close()
+	db := database.ConnectDB(cfg.Postgres)
+	defer db.Close()
+
+	// Jalankan "go run . migrate up|down [jumlah]|status" untuk mengelola skema PostgreSQL.
+	// Migration SQL ditanam di binary (database/migrations).
+	if command == "migrate" {
+		runMigrateCommand(db, args[1:])
+		return
+	}
+	// Aplikasi tidak dijalankan dengan skema database yang belum diperbarui
+	if err := database.CheckSchema(db); err != nil {
+		log.Fatalf("Skema database tidak sesuai: %v", err)
+	}
 
-	mongoClient := database.MongoConnection()
+	mongoClient := database.MongoConnection(cfg.Mongo)
 	defer database.CloseDB(mongoClient)
-	mongoDB := database.GetMongoDatabase()
+	mongoDB := mongoClient.Database(cfg.Mongo.Database)
+
+	// Index read model achievement, termasuk text index untuk pencarian full-text
+	if err := repository.NewAchievementViewRepository(mongoDB).EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Gagal membuat index achievement_views: %v", err)
+	}
+	if err := repository.NewAchievementCounterRepository(mongoDB).EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Gagal membuat index achievement_counters: %v", err)
+	}
+	// Migration dokumen (schema_version), index dan validator $jsonSchema collection achievements
+	if err := service.NewAchievementSchemaService(mongoDB).Bootstrap(context.Background()); err != nil {
+		log.Printf("Gagal menyiapkan collection achievements: %v", err)
+	}
+	// TTL index kredensial hasil import user yang belum diunduh
+	if err := repository.NewUserImportCredentialsRepository(mongoDB).EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Gagal membuat index user_import_credentials: %v", err)
+	}
 
-	utils.InitCache()
-	log.Println("Permission cache initialized")
+	// Unique index kode verifikasi transkrip yang diterbitkan
+	if err := repository.NewTranscriptRepository(mongoDB).EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Gagal membuat index issued_transcripts: %v", err)
+	}
 
-	app := fiber.New()
+	// Master data kategori dan level achievement
+	taxonomyRepo := repository.NewTaxonomyRepository(db)
 
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+	// Jalankan "go run . seed" untuk membuat role, permission, grant bawaan dan admin awal
+	// (SEED_ADMIN_USERNAME, SEED_ADMIN_EMAIL, SEED_ADMIN_PASSWORD). Katalog permission
+	// diambil dari route sehingga route didaftarkan dulu ke app sementara.
+	if command == "seed" {
+		admin, err := service.SeedAdminFromConfig(cfg.Seed)
+		if err != nil {
+			log.Fatalf("Konfigurasi admin awal tidak valid: %v", err)
+		}
+		route.Routes(fiber.New(), cfg, db, mongoDB)
+
+		report, err := service.NewSeedService(db).Seed(admin)
+		if err != nil {
+			log.Fatalf("Seed gagal: %v", err)
+		}
+		log.Printf("Seed selesai: %d role, %d permission, %d grant baru", report.RolesCreated, report.PermissionsCreated, report.GrantsCreated)
+		switch {
+		case admin == nil:
+			log.Println("SEED_ADMIN_* kosong, admin awal tidak dibuat")
+		case report.AdminCreated:
+			log.Printf("Admin awal %q dibuat", admin.Username)
+		default:
+			log.Printf("Username atau email admin %q sudah dipakai, admin awal tidak dibuat", admin.Username)
+		}
+		return
+	}
+
+	// Read model untuk list, pencarian dan laporan achievement.
+	// Jalankan "go run . rebuild-read-model" untuk membangun ulang dari data sumber.
+	// Jalankan "go run . rebuild-statistics" untuk menghitung ulang counter statistik saja.
+	projector := service.NewAchievementProjector(mongoDB, db)
+	if command == "rebuild-read-model" {
+		projected, err := projector.Rebuild(context.Background())
+		if err != nil {
+			log.Fatalf("Gagal membangun ulang read model: %v", err)
+		}
+		log.Printf("Read model achievement dibangun ulang: %d achievement", projected)
+		return
+	}
+	if command == "rebuild-statistics" {
+		counters, err := projector.RebuildStatistics(context.Background())
+		if err != nil {
+			log.Fatalf("Gagal menghitung ulang counter statistik: %v", err)
+		}
+		log.Printf("Counter statistik achievement dihitung ulang: %d counter", counters)
+		return
+	}
+	// Jalankan "go run . recompute-points" untuk menghitung ulang poin dengan rubric saat ini.
+	if command == "recompute-points" {
+		changed, err := service.NewScoringRubricService(db, mongoDB).RecomputePoints(context.Background())
+		if err != nil {
+			log.Fatalf("Gagal menghitung ulang poin achievement: %v", err)
+		}
+		log.Printf("Poin achievement dihitung ulang: %d achievement berubah", changed)
+		return
+	}
+	// Jalankan "go run . migrate-taxonomy [--dry-run] [--create-missing]" untuk memetakan
+	// kategori dan level free-text achievement lama ke taxonomy.
+	if command == "migrate-taxonomy" {
+		dryRun, createMissing := false, false
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--dry-run":
+				dryRun = true
+			case "--create-missing":
+				createMissing = true
+			default:
+				log.Fatalf("Argumen migrate-taxonomy tidak dikenal: %s", arg)
+			}
+		}
+		report, err := service.NewTaxonomyService(db, mongoDB).MigrateAchievements(context.Background(), createMissing, dryRun)
+		if err != nil {
+			log.Fatalf("Gagal migrasi taxonomy achievement: %v", err)
+		}
+		for _, mapping := range report.Mappings {
+			log.Printf("%s %q -> %q (%d achievement, baru: %t)", mapping.Field, mapping.From, mapping.To, mapping.Achievements, mapping.Created)
+		}
+		for _, mapping := range report.Unmapped {
+			log.Printf("%s %q tidak terpetakan (%d achievement)", mapping.Field, mapping.From, mapping.Achievements)
+		}
+		log.Printf("Migrasi taxonomy selesai (dry run: %t): %d dipetakan, %d tidak terpetakan", dryRun, len(report.Mappings), len(report.Unmapped))
+		return
+	}
+	if taxonomy, err := taxonomyRepo.FindTaxonomy(); err == nil && (len(taxonomy.Categories) == 0 || len(taxonomy.Levels) == 0) {
+		log.Println("Taxonomy kategori/level achievement masih kosong, submit achievement akan ditolak. Jalankan \"go run . migrate-taxonomy --create-missing\" atau tambahkan lewat /taxonomy")
+	}
+	if err := projector.RebuildIfEmpty(context.Background()); err != nil {
+		log.Printf("Gagal membangun read model achievement: %v", err)
+	}
+
+	// Antivirus scanning untuk dokumen upload (clamd INSTREAM)
+	if cfg.Upload.ClamdAddress != "" {
+		log.Printf("Antivirus scanning enabled via clamd at %s", cfg.Upload.ClamdAddress)
+	}
 
-	route.Routes(app, database.DB, mongoDB)
+	// Jalankan "go run . import-achievements --file data.xlsx [--documents dokumen.zip] [--dry-run]"
+	// untuk mengimport achievement historis. Aman dijalankan ulang (baris dengan external_key
+	// yang sudah diimport dilewati). Dokumen divalidasi dan discan seperti upload biasa.
+	if command == "import-achievements" {
+		flags := flag.NewFlagSet("import-achievements", flag.ExitOnError)
+		file := flags.String("file", "", "file CSV atau XLSX achievement")
+		documents := flags.String("documents", "", "file ZIP dokumen pendukung (opsional)")
+		dryRun := flags.Bool("dry-run", false, "hanya validasi tanpa membuat achievement")
+		flags.Parse(args[1:])
+		if *file == "" {
+			log.Fatal("Argumen --file wajib diisi")
+		}
 
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "3000"
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatalf("Gagal membaca file import: %v", err)
+		}
+		table, err := utils.ReadTable(strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), "."), data)
+		if err != nil {
+			log.Fatalf("Gagal membaca file import: %v", err)
+		}
+		rows, err := service.ParseAchievementImportTable(table)
+		if err != nil {
+			log.Fatalf("File import tidak valid: %v", err)
+		}
+		var bundle *utils.DocumentBundle
+		if *documents != "" {
+			zipFile, err := os.Open(*documents)
+			if err != nil {
+				log.Fatalf("Gagal membuka file ZIP dokumen: %v", err)
+			}
+			defer zipFile.Close()
+			info, err := zipFile.Stat()
+			if err != nil {
+				log.Fatalf("Gagal membuka file ZIP dokumen: %v", err)
+			}
+			if bundle, err = utils.OpenDocumentBundle(zipFile, info.Size()); err != nil {
+				log.Fatalf("%v", err)
+			}
+		}
+
+		report, err := service.NewAchievementService(mongoDB, db, cfg, nil).ImportHistoricalAchievements(context.Background(), rows, bundle, *dryRun)
+		if err != nil {
+			log.Fatalf("Gagal mengimport achievement: %v", err)
+		}
+		for _, row := range report.Errors {
+			log.Printf("Baris %d (%s): %s", row.Row, row.ExternalKey, strings.Join(row.Errors, "; "))
+		}
+		log.Printf("Import achievement selesai (dry run: %t): %d baris, %d dibuat, %d dilewati, %d tidak valid, %d gagal",
+			*dryRun, report.TotalRows, report.Created, report.Skipped, report.InvalidRows, report.Failed)
+		return
 	}
 
+	app := fiber.New(fiber.Config{
+		ReadTimeout:  cfg.App.ReadTimeout,
+		WriteTimeout: cfg.App.WriteTimeout,
+		IdleTimeout:  cfg.App.IdleTimeout,
+		BodyLimit:    cfg.App.BodyLimit,
+	})
+
+	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+
+	route.Routes(app, cfg, db, mongoDB)
+
+	service.NewFileGarbageCollector(mongoDB, cfg).Start()
+	log.Println("File garbage collector started")
+
+	service.NewQuarantineRescanner(mongoDB, db, cfg).Start()
+
+	port := strconv.Itoa(cfg.App.Port)
+
 	log.Printf("Server running on http://localhost:%s", port)
 	log.Printf("Swagger docs: http://localhost:%s/swagger/", port)
 
 	log.Fatal(app.Listen(":" + port))
 }
+
+// runMigrateCommand menjalankan subcommand migrate: up menerapkan semua migration,
+// down membatalkan migration terakhir (default 1) dan status menampilkan daftar migration
+func runMigrateCommand(db *sql.DB, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Gunakan: migrate up | migrate down [jumlah] | migrate status")
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(db)
+		for _, migration := range applied {
+			log.Printf("Migration %04d_%s diterapkan", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migrate up gagal: %v", err)
+		}
+		log.Printf("Migrate up selesai: %d migration diterapkan", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatalf("Jumlah migration tidak valid: %s", args[1])
+			}
+			steps = n
+		}
+		reverted, err := database.MigrateDown(db, steps)
+		for _, migration := range reverted {
+			log.Printf("Migration %04d_%s dibatalkan", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migrate down gagal: %v", err)
+		}
+		log.Printf("Migrate down selesai: %d migration dibatalkan", len(reverted))
+	case "status":
+		statuses, err := database.MigrationStatuses(db)
+		if err != nil {
+			log.Fatalf("Gagal membaca status migration: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "belum diterapkan"
+			if status.AppliedAt != nil {
+				appliedAt = "diterapkan " + status.AppliedAt.Format(time.RFC3339)
+			}
+			log.Printf("%04d_%s: %s", status.Version, status.Name, appliedAt)
+		}
+	default:
+		log.Fatalf("Subcommand migrate tidak dikenal: %s", args[0])
+	}
+}
@@ -3,20 +3,31 @@ package route
 import (
 	"crud-app/app/middleware"
 	"crud-app/app/service"
+	"crud-app/app/utils"
+	"crud-app/config"
 	"database/sql"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func Routes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
+func Routes(app *fiber.App, cfg *config.Config, db *sql.DB, mongoDB *mongo.Database) {
+	// Cache permission user dan leaderboard
+	cache := utils.NewPermissionCache(cfg.Cache.CleanupInterval)
+
 	// Initialize services
-	authService := service.NewAuthService(db)
-	achievementService := service.NewAchievementService(mongoDB, db)
-	userService := service.NewUserService(db)
+	authService := service.NewAuthService(db, cfg)
+	achievementService := service.NewAchievementService(mongoDB, db, cfg, cache)
+	userService := service.NewUserService(db, mongoDB, cfg)
+	chunkedUploadService := service.NewChunkedUploadService(mongoDB, db, cfg)
+	rubricService := service.NewScoringRubricService(db, mongoDB)
+	taxonomyService := service.NewTaxonomyService(db, mongoDB)
 
-	// Initialize RBAC middleware
-	rbac := middleware.NewRBACMiddleware(db)
+	// Initialize auth dan RBAC middleware
+	authRequired := middleware.AuthRequired(service.JWTConfig(cfg.Auth))
+	rbac := middleware.NewRBACMiddleware(db, cache, cfg.Cache.PermissionTTL)
 
 	// API routes
 	api := app.Group("/api/v1")
@@ -25,13 +36,15 @@ func Routes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
 	auth := api.Group("/auth")
 	auth.Post("/login", authService.Login)
 	auth.Post("/refresh", authService.RefreshToken)
-	auth.Post("/logout", middleware.AuthRequired(), authService.Logout)
-	auth.Get("/profile", middleware.AuthRequired(), authService.GetProfile)
+	auth.Post("/logout", authRequired, authService.Logout)
+	auth.Get("/profile", authRequired, authService.GetProfile)
 
 	// Users Routes
 	users := api.Group("/users")
-	users.Use(middleware.AuthRequired())
+	users.Use(authRequired)
 	users.Get("/", rbac.RequirePermission("users.read"), userService.GetUsers)
+	users.Post("/import", rbac.RequirePermission("users.create"), userService.ImportUsers)
+	users.Get("/import/credentials/:id", rbac.RequirePermission("users.create"), userService.DownloadImportCredentials)
 	users.Get("/:id", rbac.RequirePermission("users.read"), userService.GetUserByID)
 	users.Post("/", rbac.RequirePermission("users.create"), userService.CreateUser)
 	users.Put("/:id", rbac.RequirePermission("users.update"), userService.UpdateUser)
@@ -40,18 +53,24 @@ func Routes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
 
 	// Achievements Routes
 	achievements := api.Group("/achievements")
-	achievements.Use(middleware.AuthRequired())
+	achievements.Use(authRequired)
 
 	// List & Detail
 	achievements.Get("/", rbac.RequirePermission("achievements.read"), achievementService.GetMyAchievements)
+	achievements.Get("/export", rbac.RequirePermission("achievements.read"), achievementService.ExportAchievements)
+	achievements.Get("/search", rbac.RequirePermission("achievements.read"), achievementService.SearchAchievements)
+	achievements.Get("/all", rbac.RequirePermission("achievements.read"), achievementService.GetAllAchievements)
+	achievements.Get("/advisees", rbac.RequirePermission("achievements.verify"), achievementService.GetAdviseeAchievements)
 	achievements.Get("/:id", rbac.RequirePermission("achievements.read"), achievementService.GetAchievementByID)
 
 	// CRUD Operations (Mahasiswa)
 	achievements.Post("/", rbac.RequirePermission("achievements.create"), achievementService.SubmitAchievement)
+	achievements.Post("/import", middleware.AdminOnly(), achievementService.ImportAchievements)
 	achievements.Put("/:id", rbac.RequirePermission("achievements.update"), achievementService.UpdateAchievement)
 	achievements.Delete("/:id", rbac.RequirePermission("achievements.delete"), achievementService.DeleteAchievement)
 
 	// Workflow Operations
+	achievements.Get("/:id/review", rbac.RequirePermission("achievements.verify"), achievementService.ReviewAchievementDetail)
 	achievements.Post("/:id/submit", rbac.RequirePermission("achievements.create"), achievementService.SubmitForVerification)
 	achievements.Post("/:id/verify", rbac.RequirePermission("achievements.verify"), achievementService.ApproveAchievement)
 	achievements.Post("/:id/reject", rbac.RequirePermission("achievements.verify"), achievementService.RejectAchievement)
@@ -59,23 +78,75 @@ func Routes(app *fiber.App, db *sql.DB, mongoDB *mongo.Database) {
 	// History & Attachments
 	achievements.Get("/:id/history", rbac.RequirePermission("achievements.read"), achievementService.GetAchievementHistory)
 	achievements.Post("/:id/attachments", rbac.RequirePermission("achievements.create"), achievementService.UploadAttachment)
+	achievements.Delete("/:id/documents/:docId", rbac.RequirePermission("achievements.update"), achievementService.DeleteAttachment)
+	achievements.Get("/:id/documents/:docId/thumbnail", rbac.RequirePermission("achievements.read"), achievementService.GetDocumentThumbnail)
+
+	// Resumable Uploads (file bukti berukuran besar)
+	achievements.Post("/:id/uploads", rbac.RequirePermission("achievements.create"), chunkedUploadService.CreateUploadSession)
+	achievements.Get("/:id/uploads/:uploadId", rbac.RequirePermission("achievements.create"), chunkedUploadService.GetUploadSession)
+	achievements.Patch("/:id/uploads/:uploadId", rbac.RequirePermission("achievements.create"), chunkedUploadService.UploadChunk)
+	achievements.Delete("/:id/uploads/:uploadId", rbac.RequirePermission("achievements.create"), chunkedUploadService.CancelUploadSession)
 
 	// Students & Lecturers Routes
 	students := api.Group("/students")
-	students.Use(middleware.AuthRequired())
+	students.Use(authRequired)
 	students.Get("/", rbac.RequirePermission("students.read"), userService.GetStudents)
 	students.Get("/:id", rbac.RequirePermission("students.read"), userService.GetStudentByID)
 	students.Get("/:id/achievements", rbac.RequirePermission("achievements.read"), achievementService.GetStudentAchievements)
 	students.Put("/:id/advisor", rbac.RequirePermission("students.assign_advisor"), userService.AssignAdvisor)
 
 	lecturers := api.Group("/lecturers")
-	lecturers.Use(middleware.AuthRequired())
+	lecturers.Use(authRequired)
 	lecturers.Get("/", rbac.RequirePermission("lecturers.read"), userService.GetLecturers)
 	lecturers.Get("/:id/advisees", rbac.RequirePermission("lecturers.read"), userService.GetAdvisees)
 
 	// Reports & Analytics Routes
 	reports := api.Group("/reports")
-	reports.Use(middleware.AuthRequired())
+	reports.Use(authRequired)
 	reports.Get("/statistics", rbac.RequirePermission("achievements.read"), achievementService.GetAllStatistics)
+	reports.Get("/statistics/export", rbac.RequirePermission("achievements.read"), achievementService.ExportStatistics)
+	reports.Get("/trends", rbac.RequirePermission("achievements.read"), achievementService.GetTrends)
+	reports.Get("/leaderboard", rbac.RequirePermission("achievements.read"), achievementService.GetLeaderboard)
 	reports.Get("/student/:id", rbac.RequirePermission("achievements.read"), achievementService.GetStudentReport)
+	reports.Get("/student/:id/transcript.pdf", rbac.RequirePermission("achievements.read"), achievementService.GetStudentTranscript)
+
+	// Verifikasi transkrip cetak (publik tanpa login, dibatasi per IP)
+	transcripts := api.Group("/transcripts")
+	transcripts.Get("/verify", limiter.New(limiter.Config{
+		Max:        cfg.Auth.TranscriptVerifyRateLimit,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Terlalu banyak permintaan verifikasi, coba lagi nanti",
+			})
+		},
+	}), achievementService.VerifyTranscript)
+
+	// Scoring Rubrics (poin kredit per kategori dan level)
+	rubrics := api.Group("/rubrics")
+	rubrics.Use(authRequired)
+	rubrics.Get("/", rbac.RequirePermission("achievements.read"), rubricService.GetRubrics)
+	rubrics.Post("/", middleware.AdminOnly(), rubricService.CreateRubric)
+	rubrics.Put("/:id", middleware.AdminOnly(), rubricService.UpdateRubric)
+	rubrics.Delete("/:id", middleware.AdminOnly(), rubricService.DeleteRubric)
+
+	// Taxonomy (master data kategori dan level achievement)
+	taxonomy := api.Group("/taxonomy")
+	taxonomy.Use(authRequired)
+	taxonomy.Get("/categories", rbac.RequirePermission("achievements.read"), taxonomyService.GetCategories)
+	taxonomy.Post("/categories", middleware.AdminOnly(), taxonomyService.CreateCategory)
+	taxonomy.Put("/categories/:id", middleware.AdminOnly(), taxonomyService.UpdateCategory)
+	taxonomy.Delete("/categories/:id", middleware.AdminOnly(), taxonomyService.DeleteCategory)
+	taxonomy.Get("/levels", rbac.RequirePermission("achievements.read"), taxonomyService.GetLevels)
+	taxonomy.Post("/levels", middleware.AdminOnly(), taxonomyService.CreateLevel)
+	taxonomy.Put("/levels/:id", middleware.AdminOnly(), taxonomyService.UpdateLevel)
+	taxonomy.Delete("/levels/:id", middleware.AdminOnly(), taxonomyService.DeleteLevel)
+	taxonomy.Post("/migrate", middleware.AdminOnly(), taxonomyService.MigrateTaxonomy)
+
+	// Export Jobs (hasil export berukuran besar)
+	exports := api.Group("/exports")
+	exports.Use(authRequired)
+	exports.Get("/:id", rbac.RequirePermission("achievements.read"), achievementService.GetExportJob)
+	exports.Get("/:id/download", rbac.RequirePermission("achievements.read"), achievementService.DownloadExport)
 }
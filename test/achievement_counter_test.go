@@ -0,0 +1,63 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"testing"
+	"time"
+)
+
+func TestCounterPeriod(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+	}{
+		{"UTC date", time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC), "2024-03"},
+		{"Converted to UTC", time.Date(2024, 4, 1, 3, 0, 0, 0, jakarta), "2024-03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repository.CounterPeriod(tt.date); got != tt.want {
+				t.Errorf("CounterPeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCounterPeriodRange(t *testing.T) {
+	date := func(value string) *time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return &parsed
+	}
+
+	tests := []struct {
+		name     string
+		filter   *models.StatisticsFilter
+		wantFrom string
+		wantTo   string
+		wantOK   bool
+	}{
+		{"Nil filter", nil, "", "", true},
+		{"Status only", &models.StatisticsFilter{Status: "verified"}, "", "", true},
+		{"Whole months", &models.StatisticsFilter{DateFrom: date("2024-01-01"), DateTo: date("2024-03-31")}, "2024-01", "2024-03", true},
+		{"Leap February", &models.StatisticsFilter{DateTo: date("2024-02-29")}, "", "2024-02", true},
+		{"From mid month", &models.StatisticsFilter{DateFrom: date("2024-01-15")}, "", "", false},
+		{"To mid month", &models.StatisticsFilter{DateFrom: date("2024-01-01"), DateTo: date("2024-03-30")}, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := repository.CounterPeriodRange(tt.filter)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("range = %v..%v, want %v..%v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
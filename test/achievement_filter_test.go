@@ -0,0 +1,79 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseFilterQuery menjalankan ParseAchievementFilter terhadap query string lewat Fiber
+func parseFilterQuery(t *testing.T, query string) (*models.AchievementFilter, error) {
+	t.Helper()
+
+	var filter *models.AchievementFilter
+	var parseErr error
+	app := fiber.New()
+	app.Get("/achievements", func(c *fiber.Ctx) error {
+		filter, parseErr = service.ParseAchievementFilter(c)
+		return nil
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/achievements?"+query, nil)); err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	return filter, parseErr
+}
+
+func TestParseAchievementFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+		check   func(t *testing.T, f *models.AchievementFilter)
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			check: func(t *testing.T, f *models.AchievementFilter) {
+				if f.SortBy != "created_at" || f.SortOrder != "desc" {
+					t.Errorf("sort = %s %s, want created_at desc", f.SortBy, f.SortOrder)
+				}
+			},
+		},
+		{
+			name:  "Cross-store filters",
+			query: "status=verified&level=Internasional&date_from=2025-01-01&date_to=2025-12-31&program_study=Informatika&sort_by=date&sort_order=asc",
+			check: func(t *testing.T, f *models.AchievementFilter) {
+				if f.Status != "verified" || f.Level != "Internasional" || f.ProgramStudy != "Informatika" {
+					t.Errorf("filter = %+v", f)
+				}
+				wantFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				if f.DateFrom == nil || !f.DateFrom.Equal(wantFrom) {
+					t.Errorf("DateFrom = %v, want %v", f.DateFrom, wantFrom)
+				}
+			},
+		},
+		{name: "Sort by student field", query: "sort_by=academic_year", check: func(t *testing.T, f *models.AchievementFilter) {}},
+		{name: "Invalid status", query: "status=archived", wantErr: true},
+		{name: "Invalid date", query: "date_from=01-01-2025", wantErr: true},
+		{name: "Date range reversed", query: "date_from=2025-12-31&date_to=2025-01-01", wantErr: true},
+		{name: "Invalid sort field", query: "sort_by=password", wantErr: true},
+		{name: "Invalid sort order", query: "sort_order=up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseFilterQuery(t, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAchievementFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, filter)
+			}
+		})
+	}
+}
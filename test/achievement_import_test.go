@@ -0,0 +1,220 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAchievementImportTable(t *testing.T) {
+	table := [][]string{
+		{"External_Key", "student_id", "title", "category", "level", "date", "details", "status", "documents"},
+		{"SIAKAD-1", "123", "Juara 1", "lomba akademik", "nasional", "2019-05-01", `{"rank":1}`, "", "2019/a.pdf; 2019/b.png"},
+		{"", "", "", "", "", "", "", "", ""},
+		{"SIAKAD-2", "124", "Paper", "penelitian", "nasional", "2020-01-01", "[1]", "Verified", ""},
+	}
+
+	rows, err := service.ParseAchievementImportTable(table)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+
+	first := rows[0]
+	if first.Row != 2 || first.ExternalKey != "SIAKAD-1" || first.Status != "submitted" {
+		t.Errorf("first row = %+v", first)
+	}
+	if first.Details["rank"] != float64(1) {
+		t.Errorf("details = %v, want rank 1", first.Details)
+	}
+	if !reflect.DeepEqual(first.Documents, []string{"2019/a.pdf", "2019/b.png"}) {
+		t.Errorf("documents = %q", first.Documents)
+	}
+
+	second := rows[1]
+	if second.Row != 4 || second.Status != "verified" {
+		t.Errorf("second row = %+v", second)
+	}
+	if !reflect.DeepEqual(second.Errors, []string{"details harus berupa JSON object"}) {
+		t.Errorf("errors = %q", second.Errors)
+	}
+
+	if _, err := service.ParseAchievementImportTable([][]string{{"student_id", "title", "category", "level", "date"}}); err == nil || !strings.Contains(err.Error(), "external_key") {
+		t.Errorf("missing external_key column: error = %v", err)
+	}
+}
+
+func TestValidateAchievementImportRows(t *testing.T) {
+	taxonomy := &models.Taxonomy{
+		Categories: []models.AchievementCategory{
+			{ID: "c1", Code: "kompetisi", Name: "Competition", Aliases: []string{"Lomba"},
+				DetailsSchema: json.RawMessage(`{"type":"object","properties":{"rank":{"type":"integer"}},"required":["rank"]}`)},
+			{ID: "c2", Code: "penelitian", Name: "Research"},
+		},
+		Levels: []models.AchievementLevel{
+			{ID: "l1", Code: "nasional", Name: "National"},
+		},
+	}
+	lookup := &models.AchievementImportLookup{
+		Students:     map[string]string{"123": "11111111-1111-1111-1111-111111111111"},
+		Verifiers:    map[string]string{"dosen1": "22222222-2222-2222-2222-222222222222"},
+		ExistingKeys: map[string]string{"OLD-1": "existing-achievement"},
+	}
+	bundle := newTestBundle(t, map[string]string{"2019/a.pdf": "%PDF-1.4"})
+	future := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+
+	rows := []models.AchievementImportRow{
+		{Row: 2, ExternalKey: "K1", StudentNumber: "123", Title: "Juara", Category: "lomba", Level: "NASIONAL", Date: "2019-05-01",
+			Details: map[string]interface{}{"rank": float64(1)}, Status: "verified", VerifiedBy: "Dosen1", VerifiedAt: "2019-06-01", Documents: []string{"./2019/a.pdf"}},
+		{Row: 3, ExternalKey: "OLD-1", Status: "submitted"},
+		{Row: 4, ExternalKey: "K1", StudentNumber: "123", Title: "Dup", Category: "Research", Level: "National", Date: "2019-05-01", Status: "submitted"},
+		{Row: 5, ExternalKey: "K5", StudentNumber: "999", Title: "X", Category: "Competition", Level: "Regional", Date: future, Status: "verified", Documents: []string{"missing.pdf"}},
+		{Row: 6, ExternalKey: "K6", StudentNumber: "123", Title: "X", Category: "Research", Level: "National", Date: "2020-01-01", Status: "draft", VerifiedBy: "dosen1"},
+		{Row: 7, ExternalKey: "K7", StudentNumber: "123", Title: "X", Category: "Research", Level: "National", Date: "2020-01-01", Status: "verified", VerifiedBy: "dosen1", VerifiedAt: "2019-12-31"},
+		{Row: 8, ExternalKey: "K8", StudentNumber: "123", Title: "X", Category: "Research", Level: "National", Date: "01/02/2020", Status: "approved"},
+	}
+
+	service.ValidateAchievementImportRows(rows, lookup, taxonomy, bundle)
+
+	wantErrors := map[int][]string{
+		2: nil,
+		3: nil,
+		4: {`external_key "K1" duplikat dengan baris 2`},
+		5: {
+			`mahasiswa dengan NIM "999" tidak ditemukan`,
+			`level "Regional" tidak terdaftar`,
+			"details /: missing properties: 'rank'",
+			"date tidak boleh di masa depan",
+			"verified_by wajib diisi untuk status verified",
+			`dokumen "missing.pdf" tidak ada di file ZIP`,
+		},
+		6: {"verified_by dan verified_at hanya untuk status verified"},
+		7: {"verified_at tidak boleh sebelum date"},
+		8: {`date "01/02/2020" tidak valid, gunakan format YYYY-MM-DD`, `status "approved" tidak valid, gunakan draft, submitted atau verified`},
+	}
+	for _, row := range rows {
+		if !reflect.DeepEqual(row.Errors, wantErrors[row.Row]) {
+			t.Errorf("row %d errors = %q, want %q", row.Row, row.Errors, wantErrors[row.Row])
+		}
+	}
+
+	valid := rows[0]
+	if valid.Category != "Competition" || valid.Level != "National" {
+		t.Errorf("taxonomy not canonicalized: %q / %q", valid.Category, valid.Level)
+	}
+	if valid.StudentUserID != lookup.Students["123"] || valid.VerifierID != lookup.Verifiers["dosen1"] {
+		t.Errorf("lookup not resolved: student %q verifier %q", valid.StudentUserID, valid.VerifierID)
+	}
+	if got := valid.VerificationDate.Format("2006-01-02"); got != "2019-06-01" {
+		t.Errorf("verification date = %s, want 2019-06-01", got)
+	}
+	if rows[1].ExistingAchievementID != "existing-achievement" {
+		t.Errorf("existing key not detected: %+v", rows[1])
+	}
+}
+
+func TestValidateAchievementImportRows_DocumentsWithoutBundle(t *testing.T) {
+	taxonomy := &models.Taxonomy{
+		Categories: []models.AchievementCategory{{ID: "c1", Name: "Research"}},
+		Levels:     []models.AchievementLevel{{ID: "l1", Name: "National"}},
+	}
+	lookup := &models.AchievementImportLookup{
+		Students:     map[string]string{"123": "11111111-1111-1111-1111-111111111111"},
+		Verifiers:    map[string]string{},
+		ExistingKeys: map[string]string{},
+	}
+	rows := []models.AchievementImportRow{
+		{Row: 2, ExternalKey: "K1", StudentNumber: "123", Title: "X", Category: "Research", Level: "National", Date: "2020-01-01", Status: "submitted", Documents: []string{"a.pdf", "b.pdf"}},
+	}
+
+	service.ValidateAchievementImportRows(rows, lookup, taxonomy, nil)
+
+	want := []string{"documents diisi tetapi file ZIP dokumen tidak diupload"}
+	if !reflect.DeepEqual(rows[0].Errors, want) {
+		t.Errorf("errors = %q, want %q", rows[0].Errors, want)
+	}
+}
+
+func TestDocumentBundle(t *testing.T) {
+	bundle := newTestBundle(t, map[string]string{
+		"2019/sertifikat.pdf":      "%PDF-1.4 isi",
+		"__MACOSX/2019/._x.pdf":    "metadata",
+		"besar.pdf":                strings.Repeat("x", 100),
+		"folder\\windows-path.pdf": "isi",
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"exact path", "2019/sertifikat.pdf", true},
+		{"leading dot slash", "./2019/sertifikat.pdf", true},
+		{"backslash separator", "2019\\sertifikat.pdf", true},
+		{"windows path in archive", "folder/windows-path.pdf", true},
+		{"macOS metadata ignored", "__MACOSX/2019/._x.pdf", false},
+		{"missing", "2019/lain.pdf", false},
+		{"traversal normalized", "../2019/sertifikat.pdf", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bundle.Has(tt.path); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	data, err := bundle.Read("2019/sertifikat.pdf", 1024)
+	if err != nil || string(data) != "%PDF-1.4 isi" {
+		t.Errorf("Read() = %q, %v", data, err)
+	}
+	if _, err := bundle.Read("besar.pdf", 10); err == nil {
+		t.Error("expected error for file larger than max size")
+	}
+	if _, err := bundle.Read("2019/lain.pdf", 1024); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	var missing *utils.DocumentBundle
+	if missing.Has("a.pdf") {
+		t.Error("nil bundle should not contain documents")
+	}
+}
+
+func TestOpenDocumentBundle_Invalid(t *testing.T) {
+	data := []byte("bukan zip")
+	if _, err := utils.OpenDocumentBundle(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Error("expected error for invalid ZIP")
+	}
+}
+
+func newTestBundle(t *testing.T, files map[string]string) *utils.DocumentBundle {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+
+	bundle, err := utils.OpenDocumentBundle(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("OpenDocumentBundle() error = %v", err)
+	}
+	return bundle
+}
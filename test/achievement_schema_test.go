@@ -0,0 +1,142 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/service"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAchievementDocumentMigrations_Ordered(t *testing.T) {
+	migrations := service.AchievementDocumentMigrations
+	if len(migrations) == 0 {
+		t.Fatal("no document migrations defined")
+	}
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			t.Errorf("migration %d version %d not greater than %d", i, migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+	if last := migrations[len(migrations)-1].Version; last != models.AchievementSchemaVersion {
+		t.Errorf("last migration version = %d, want AchievementSchemaVersion %d", last, models.AchievementSchemaVersion)
+	}
+}
+
+func TestMigrateAchievementDocument_DocumentIDs(t *testing.T) {
+	document := bson.M{
+		"achievement_id": "a1",
+		"schema_version": int32(1),
+		"documents": bson.A{
+			bson.M{"filename": "lama.pdf", "filepath": "/uploads/lama.pdf"},
+			bson.M{"id": "doc-1", "filename": "baru.pdf", "filepath": "/uploads/baru.pdf"},
+			bson.D{{Key: "filename", Value: "foto.jpg"}, {Key: "filepath", Value: "/uploads/foto.jpg"}},
+		},
+	}
+	if err := service.MigrateAchievementDocument(document); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	documents := document["documents"].(bson.A)
+	legacyID, _ := documents[0].(bson.M)["id"].(string)
+	if legacyID == "" {
+		t.Error("legacy document did not get an id")
+	}
+	if id := documents[1].(bson.M)["id"]; id != "doc-1" {
+		t.Errorf("existing id = %v, want doc-1", id)
+	}
+	if id, _ := documents[2].(bson.D).Map()["id"].(string); id == "" || id == legacyID {
+		t.Errorf("bson.D document id = %q, want new unique id", id)
+	}
+	if document["schema_version"] != int32(models.AchievementSchemaVersion) {
+		t.Errorf("schema_version = %v, want %d", document["schema_version"], models.AchievementSchemaVersion)
+	}
+}
+
+func TestMigrateAchievementDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document bson.M
+		want     bson.M
+		wantErr  bool
+	}{
+		{
+			name:     "legacy document gets defaults",
+			document: bson.M{"achievement_id": "a1", "status": "verified"},
+			want:     bson.M{"achievement_id": "a1", "status": "verified", "is_deleted": false, "points": int32(0), "description": "", "schema_version": int32(models.AchievementSchemaVersion)},
+		},
+		{
+			name:     "existing values kept",
+			document: bson.M{"achievement_id": "a2", "status": "submitted", "is_deleted": true, "points": int64(30), "description": "x"},
+			want:     bson.M{"achievement_id": "a2", "status": "submitted", "is_deleted": true, "points": int64(30), "description": "x", "schema_version": int32(models.AchievementSchemaVersion)},
+		},
+		{
+			name:     "current version untouched",
+			document: bson.M{"achievement_id": "a3", "schema_version": int32(models.AchievementSchemaVersion)},
+			want:     bson.M{"achievement_id": "a3", "schema_version": int32(models.AchievementSchemaVersion)},
+		},
+		{
+			name:     "newer version rejected",
+			document: bson.M{"achievement_id": "a4", "schema_version": int64(models.AchievementSchemaVersion + 1)},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.MigrateAchievementDocument(tt.document)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tt.document) != len(tt.want) {
+				t.Errorf("document = %v, want %v", tt.document, tt.want)
+			}
+			for key, value := range tt.want {
+				if tt.document[key] != value {
+					t.Errorf("%s = %#v, want %#v", key, tt.document[key], value)
+				}
+			}
+		})
+	}
+}
+
+// Dokumen yang ditulis aplikasi harus memenuhi field wajib validator
+func TestAchievementJSONSchema_RequiredFieldsMatchModel(t *testing.T) {
+	achievement := models.Achievement{
+		AchievementID: "a1",
+		StudentID:     "s1",
+		Status:        "draft",
+		SchemaVersion: models.AchievementSchemaVersion,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	raw, err := bson.Marshal(achievement)
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+	var document bson.M
+	if err := bson.Unmarshal(raw, &document); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+
+	schema := repository.AchievementJSONSchema()
+	properties := schema["properties"].(bson.M)
+	for _, field := range schema["required"].(bson.A) {
+		if _, ok := document[field.(string)]; !ok {
+			t.Errorf("required field %q not written by models.Achievement", field)
+		}
+	}
+	for field := range document {
+		if _, ok := properties[field]; !ok && field != "_id" {
+			t.Errorf("field %q missing from validator properties", field)
+		}
+	}
+}
@@ -542,4 +542,74 @@ func TestAchievementService_ExcludeDeletedAchievements(t *testing.T) {
 	if totalAchievements != 1 {
 		t.Errorf("Expected 1 achievement (excluding deleted), got %d", totalAchievements)
 	}
-}
\ No newline at end of file
+}
+func TestMatchDuplicateDocuments(t *testing.T) {
+	achievement := &models.Achievement{
+		AchievementID: "achievement-1",
+		StudentID:     "student-1",
+		Documents: []models.Document{
+			{ID: "doc-1", Filename: "sertifikat.pdf", SHA256: "hash-a"},
+			{ID: "doc-2", Filename: "foto.jpg", SHA256: "hash-b"},
+			{ID: "doc-3", Filename: "lama.pdf"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		others []models.Achievement
+		want   []string // document_id:other_achievement_id
+	}{
+		{
+			name: "Same hash on another achievement",
+			others: []models.Achievement{{
+				AchievementID: "achievement-2",
+				StudentID:     "student-2",
+				Documents:     []models.Document{{SHA256: "hash-a"}, {SHA256: "hash-a"}, {SHA256: "hash-c"}},
+			}},
+			want: []string{"doc-1:achievement-2"},
+		},
+		{
+			name: "Same achievement ignored",
+			others: []models.Achievement{{
+				AchievementID: "achievement-1",
+				Documents:     []models.Document{{SHA256: "hash-a"}, {SHA256: "hash-b"}},
+			}},
+		},
+		{
+			name: "Deleted achievement ignored",
+			others: []models.Achievement{{
+				AchievementID: "achievement-3",
+				IsDeleted:     true,
+				Documents:     []models.Document{{SHA256: "hash-b"}},
+			}},
+		},
+		{
+			name: "Document without hash never matches",
+			others: []models.Achievement{{
+				AchievementID: "achievement-4",
+				Documents:     []models.Document{{Filename: "lama.pdf"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := service.MatchDuplicateDocuments(achievement, tt.others)
+			var got []string
+			for _, flag := range flags {
+				got = append(got, flag.DocumentID+":"+flag.OtherAchievementID)
+				if flag.OtherAchievementURL != models.AchievementReviewURL(flag.OtherAchievementID) {
+					t.Errorf("OtherAchievementURL = %s, want review endpoint", flag.OtherAchievementURL)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("flags = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("flags = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAchievementView(t *testing.T) {
+	achievement := &models.Achievement{
+		AchievementID: "ach-1",
+		StudentID:     "user-1",
+		Title:         "Juara 1 Lomba Robot",
+		Status:        "verified",
+	}
+
+	submittedAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	verifiedAt := submittedAt.Add(48 * time.Hour)
+	verifiedBy := uuid.New()
+	reference := &models.AchievementReferences{
+		MongoAchievementID: "ach-1",
+		Status:             "verified",
+		SubmittedAt:        &submittedAt,
+		VerifiedAt:         &verifiedAt,
+		VerifiedBy:         &verifiedBy,
+	}
+
+	student := &models.StudentDetail{
+		UserID:       "user-1",
+		StudentID:    "2021001",
+		FullName:     "Budi Santoso",
+		ProgramStudy: "Teknik Informatika",
+		AcademicYear: "2021",
+		AdvisorID:    "lecturer-1",
+		AdvisorName:  "Dr. Siti",
+	}
+
+	t.Run("Joins reference and student fields", func(t *testing.T) {
+		view := models.NewAchievementView(achievement, reference, student)
+
+		if view.AchievementID != "ach-1" || view.Title != achievement.Title {
+			t.Errorf("achievement fields not copied: %+v", view.Achievement)
+		}
+		if view.SubmittedAt == nil || !view.SubmittedAt.Equal(submittedAt) {
+			t.Errorf("SubmittedAt = %v, want %v", view.SubmittedAt, submittedAt)
+		}
+		if view.VerifiedBy != verifiedBy.String() {
+			t.Errorf("VerifiedBy = %v, want %v", view.VerifiedBy, verifiedBy)
+		}
+		if view.StudentNumber != "2021001" || view.StudentName != "Budi Santoso" {
+			t.Errorf("student fields = %v/%v", view.StudentNumber, view.StudentName)
+		}
+		if view.ProgramStudy != "Teknik Informatika" || view.AcademicYear != "2021" {
+			t.Errorf("profile fields = %v/%v", view.ProgramStudy, view.AcademicYear)
+		}
+		if view.AdvisorID != "lecturer-1" || view.AdvisorName != "Dr. Siti" {
+			t.Errorf("advisor fields = %v/%v", view.AdvisorID, view.AdvisorName)
+		}
+	})
+
+	t.Run("Missing reference and student", func(t *testing.T) {
+		view := models.NewAchievementView(achievement, nil, nil)
+
+		if view.SubmittedAt != nil || view.VerifiedBy != "" {
+			t.Errorf("reference fields should be empty, got %v/%v", view.SubmittedAt, view.VerifiedBy)
+		}
+		if view.StudentName != "" || view.AdvisorID != "" {
+			t.Errorf("student fields should be empty, got %v/%v", view.StudentName, view.AdvisorID)
+		}
+	})
+}
+
+func TestViewsToAchievements(t *testing.T) {
+	views := []models.AchievementView{
+		{Achievement: models.Achievement{AchievementID: "ach-1"}, StudentName: "Budi"},
+		{Achievement: models.Achievement{AchievementID: "ach-2"}, StudentName: "Siti"},
+	}
+
+	achievements := models.ViewsToAchievements(views)
+	if len(achievements) != 2 {
+		t.Fatalf("len = %d, want 2", len(achievements))
+	}
+	if achievements[0].AchievementID != "ach-1" || achievements[1].AchievementID != "ach-2" {
+		t.Errorf("achievements = %+v", achievements)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"crud-app/test/mocks"
 	"encoding/json"
 	"net/http/httptest"
-	"os"
 	"strings"
 	"testing"
 	"time"
@@ -14,8 +13,6 @@ import (
 
 func TestAuthService_Login_Success(t *testing.T) {
 	// Setup
-	os.Setenv("JWT_SECRET", "test-secret")
-
 	mockRepo := mocks.NewMockUserRepository()
 
 	// Create a test user
@@ -68,7 +65,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 	}
 
 	// Test token generation
-	token, err := utils.GenerateToken(*user)
+	token, err := utils.GenerateToken(*user, testJWTConfig)
 	if err != nil {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
@@ -182,8 +179,6 @@ func TestAuthService_Login_ValidationErrors(t *testing.T) {
 }
 
 func TestAuthService_TokenGeneration(t *testing.T) {
-	os.Setenv("JWT_SECRET", "test-secret")
-
 	user := models.User{
 		ID:       "test-user-id",
 		Username: "testuser",
@@ -191,13 +186,13 @@ func TestAuthService_TokenGeneration(t *testing.T) {
 	}
 
 	// Test token generation
-	token, err := utils.GenerateToken(user)
+	token, err := utils.GenerateToken(user, testJWTConfig)
 	if err != nil {
 		t.Fatalf("GenerateToken failed: %v", err)
 	}
 
 	// Test token validation
-	claims, err := utils.ValidateToken(token)
+	claims, err := utils.ValidateToken(token, testJWTConfig)
 	if err != nil {
 		t.Fatalf("ValidateToken failed: %v", err)
 	}
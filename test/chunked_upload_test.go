@@ -0,0 +1,255 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChunkedUploadConfig_ValidateUploadSession(t *testing.T) {
+	config := utils.ChunkedUploadConfig{
+		AllowedFileTypes:    []string{".pdf", ".mp4"},
+		DefaultMaxFileSize:  10,
+		CategoryMaxFileSize: map[string]int64{"kompetisi": 100},
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		filesize int64
+		category string
+		wantCode string
+	}{
+		{"Within category limit", "lomba.mp4", 80, "Kompetisi", ""},
+		{"Exceeds category limit", "lomba.mp4", 120, "Kompetisi", utils.UploadErrFileTooLarge},
+		{"Default limit for unknown category", "sertifikat.pdf", 20, "Penelitian", utils.UploadErrFileTooLarge},
+		{"Disallowed extension", "script.exe", 5, "Kompetisi", utils.UploadErrTypeNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.ValidateUploadSession(tt.filename, tt.filesize, tt.category)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ValidateUploadSession() error = %v", err)
+				}
+				return
+			}
+
+			var uploadErr *utils.UploadError
+			if !errors.As(err, &uploadErr) || uploadErr.Code != tt.wantCode {
+				t.Errorf("ValidateUploadSession() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestChunkedUploadConfig_QuotaConfig(t *testing.T) {
+	config := utils.ChunkedUploadConfig{
+		DefaultMaxFileSize:  10,
+		CategoryMaxFileSize: map[string]int64{"kompetisi": 100},
+	}
+	upload := utils.FileUploadConfig{MaxAchievementStorage: 20, MaxStudentStorage: 500}
+
+	quota := config.QuotaConfig(upload, "Kompetisi")
+	if quota.MaxAchievementStorage != 100 || quota.MaxStudentStorage != 500 {
+		t.Errorf("QuotaConfig() = %d/%d, want 100/500", quota.MaxAchievementStorage, quota.MaxStudentStorage)
+	}
+	// Satu file terbesar muat, session kedua melebihi kuota achievement
+	if err := utils.CheckStorageQuota(100, 0, 0, quota); err != nil {
+		t.Errorf("first file rejected: %v", err)
+	}
+	var uploadErr *utils.UploadError
+	if err := utils.CheckStorageQuota(100, 100, 100, quota); !errors.As(err, &uploadErr) || uploadErr.Code != utils.UploadErrAchievementQuota {
+		t.Errorf("CheckStorageQuota() error = %v, want code %v", err, utils.UploadErrAchievementQuota)
+	}
+
+	if quota := config.QuotaConfig(upload, "Penelitian"); quota.MaxAchievementStorage != 20 {
+		t.Errorf("MaxAchievementStorage = %d, want 20 when above the file limit", quota.MaxAchievementStorage)
+	}
+	upload.MaxAchievementStorage = 0
+	if quota := config.QuotaConfig(upload, "Kompetisi"); quota.MaxAchievementStorage != 0 {
+		t.Errorf("MaxAchievementStorage = %d, want 0 (tanpa batas) kept", quota.MaxAchievementStorage)
+	}
+}
+
+func TestParseCategorySizeLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]int64
+		wantErr bool
+	}{
+		{"Multiple categories", "Kompetisi=200, penelitian=100", map[string]int64{"kompetisi": 200 << 20, "penelitian": 100 << 20}, false},
+		{"Empty", "", map[string]int64{}, false},
+		{"Missing size", "kompetisi", nil, true},
+		{"Invalid size", "kompetisi=abc", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.ParseCategorySizeLimits(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCategorySizeLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseCategorySizeLimits() = %v, want %v", got, tt.want)
+			}
+			for category, size := range tt.want {
+				if got[category] != size {
+					t.Errorf("ParseCategorySizeLimits()[%s] = %v, want %v", category, got[category], size)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyChunkChecksum(t *testing.T) {
+	data := []byte("chunk data")
+	sum := sha256.Sum256(data)
+	valid := "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		header  string
+		wantErr bool
+	}{
+		{"No checksum", "", false},
+		{"Valid checksum", valid, false},
+		{"Wrong checksum", "sha256 " + base64.StdEncoding.EncodeToString([]byte("wrong")), true},
+		{"Unsupported algorithm", "md5 " + base64.StdEncoding.EncodeToString(sum[:]), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.VerifyChunkChecksum(data, tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyChunkChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAppendChunk_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.part")
+
+	offset, err := utils.AppendChunk(path, 0, []byte("%PDF-"))
+	if err != nil || offset != 5 {
+		t.Fatalf("AppendChunk() = %v, %v, want 5", offset, err)
+	}
+
+	// Offset di depan data yang sudah diterima ditolak
+	_, err = utils.AppendChunk(path, 10, []byte("x"))
+	var uploadErr *utils.UploadError
+	if !errors.As(err, &uploadErr) || uploadErr.Code != utils.UploadErrOffsetMismatch {
+		t.Errorf("AppendChunk() error = %v, want code %v", err, utils.UploadErrOffsetMismatch)
+	}
+
+	offset, err = utils.AppendChunk(path, 5, []byte("1.4 content"))
+	if err != nil || offset != 16 {
+		t.Fatalf("AppendChunk() = %v, %v, want 16", offset, err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != "%PDF-1.4 content" {
+		t.Errorf("content = %q, want %q", content, "%PDF-1.4 content")
+	}
+}
+
+func TestFinalizeChunkedUpload(t *testing.T) {
+	content := []byte("%PDF-1.4 large evidence")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		filename string
+		checksum string
+		wantCode string
+	}{
+		{"Valid without checksum", "bukti.pdf", "", ""},
+		{"Valid with checksum", "bukti.pdf", checksum, ""},
+		{"Checksum mismatch", "bukti.pdf", hex.EncodeToString(make([]byte, 32)), utils.UploadErrChecksumMismatch},
+		{"Content mismatch", "bukti.mp4", "", utils.UploadErrContentMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := utils.DefaultUploadConfig
+			config.UploadPath = t.TempDir()
+			config.QuarantinePath = t.TempDir()
+
+			partial := filepath.Join(t.TempDir(), "upload.part")
+			if err := os.WriteFile(partial, content, 0644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			saved, err := utils.FinalizeChunkedUpload(partial, tt.filename, tt.checksum, config)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("FinalizeChunkedUpload() error = %v", err)
+				}
+				if saved.SHA256 != checksum {
+					t.Errorf("SHA256 = %v, want %v", saved.SHA256, checksum)
+				}
+				if saved.ScanStatus != utils.ScanStatusSkipped {
+					t.Errorf("ScanStatus = %v, want %v", saved.ScanStatus, utils.ScanStatusSkipped)
+				}
+				if _, err := os.Stat(saved.Filepath); err != nil {
+					t.Errorf("saved file missing: %v", err)
+				}
+				return
+			}
+
+			var uploadErr *utils.UploadError
+			if !errors.As(err, &uploadErr) || uploadErr.Code != tt.wantCode {
+				t.Errorf("FinalizeChunkedUpload() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCategoryLimitKey(t *testing.T) {
+	taxonomy := &models.Taxonomy{Categories: []models.AchievementCategory{
+		{Code: "kompetisi", Name: "Lomba dan Kompetisi", Aliases: []string{"lomba"}},
+		{Code: "penelitian", Name: "Penelitian Ilmiah"},
+	}}
+	config := utils.ChunkedUploadConfig{
+		DefaultMaxFileSize:  10,
+		CategoryMaxFileSize: map[string]int64{"kompetisi": 100, "penelitian": 50},
+	}
+
+	tests := []struct {
+		name      string
+		category  string
+		wantKey   string
+		wantLimit int64
+	}{
+		{"Canonical name resolves to code", "Lomba dan Kompetisi", "kompetisi", 100},
+		{"Name case-insensitive", "penelitian ilmiah", "penelitian", 50},
+		{"Unknown category kept", "Pengabdian", "Pengabdian", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := service.CategoryLimitKey(taxonomy, tt.category)
+			if key != tt.wantKey {
+				t.Errorf("CategoryLimitKey() = %q, want %q", key, tt.wantKey)
+			}
+			if limit := config.MaxFileSizeFor(key); limit != tt.wantLimit {
+				t.Errorf("MaxFileSizeFor(%q) = %d, want %d", key, limit, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package test
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var testCursorSecret = []byte("test-cursor-secret")
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	value := "2024-03-01T08:00:00Z"
+	cursor := models.Cursor{SortBy: "created_at", SortOrder: "desc", Value: &value, ID: "user-1", Before: true}
+	token := utils.EncodeCursor(cursor, testCursorSecret)
+	lastChar := "A"
+	if token[len(token)-1:] == "A" {
+		lastChar = "B"
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		decoded, err := utils.DecodeCursor(token, testCursorSecret)
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		if decoded.SortBy != "created_at" || decoded.SortOrder != "desc" || decoded.ID != "user-1" || !decoded.Before {
+			t.Errorf("DecodeCursor() = %+v", decoded)
+		}
+		if decoded.Value == nil || *decoded.Value != value {
+			t.Errorf("Value = %v, want %v", decoded.Value, value)
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		token string
+	}{
+		{"Empty", ""},
+		{"Missing signature", token[:len(token)-44]},
+		{"Tampered payload", "x" + token[1:]},
+		{"Tampered signature", token[:len(token)-1] + lastChar},
+		{"Garbage", "not-a-cursor"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := utils.DecodeCursor(tt.token, testCursorSecret); err != utils.ErrInvalidCursor {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, utils.ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	keyOf := func(n int) models.Cursor {
+		value := strconv.Itoa(n)
+		return models.Cursor{SortBy: "n", SortOrder: "asc", Value: &value, ID: value}
+	}
+	decode := func(t *testing.T, token *string) *models.Cursor {
+		t.Helper()
+		if token == nil {
+			t.Fatal("cursor is nil")
+		}
+		cursor, err := utils.DecodeCursor(*token, testCursorSecret)
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		return cursor
+	}
+
+	t.Run("First page with more rows", func(t *testing.T) {
+		rows, meta := utils.CursorPage([]int{1, 2, 3}, 2, nil, keyOf, testCursorSecret)
+		if len(rows) != 2 || rows[1] != 2 {
+			t.Fatalf("rows = %v, want [1 2]", rows)
+		}
+		if meta.PrevCursor != nil {
+			t.Errorf("PrevCursor should be nil on the first page")
+		}
+		next := decode(t, meta.NextCursor)
+		if next.ID != "2" || next.Before {
+			t.Errorf("NextCursor = %+v, want after 2", next)
+		}
+	})
+
+	t.Run("Last page going forward", func(t *testing.T) {
+		after := keyOf(2)
+		rows, meta := utils.CursorPage([]int{3}, 2, &after, keyOf, testCursorSecret)
+		if len(rows) != 1 || meta.NextCursor != nil {
+			t.Errorf("rows = %v, NextCursor = %v, want [3] and no next page", rows, meta.NextCursor)
+		}
+		prev := decode(t, meta.PrevCursor)
+		if prev.ID != "3" || !prev.Before {
+			t.Errorf("PrevCursor = %+v, want before 3", prev)
+		}
+	})
+
+	t.Run("Going backward reverses rows", func(t *testing.T) {
+		before := keyOf(5)
+		before.Before = true
+		// Query Before mengembalikan baris dalam urutan terbalik
+		rows, meta := utils.CursorPage([]int{4, 3, 2}, 2, &before, keyOf, testCursorSecret)
+		if len(rows) != 2 || rows[0] != 3 || rows[1] != 4 {
+			t.Fatalf("rows = %v, want [3 4]", rows)
+		}
+		if decode(t, meta.PrevCursor).ID != "3" {
+			t.Errorf("PrevCursor should point before 3")
+		}
+		if next := decode(t, meta.NextCursor); next.ID != "4" || next.Before {
+			t.Errorf("NextCursor = %+v, want after 4", next)
+		}
+	})
+
+	t.Run("Empty result", func(t *testing.T) {
+		rows, meta := utils.CursorPage([]int{}, 2, nil, keyOf, testCursorSecret)
+		if len(rows) != 0 || meta.NextCursor != nil || meta.PrevCursor != nil {
+			t.Errorf("rows = %v, meta = %+v, want empty", rows, meta)
+		}
+	})
+}
+
+// Cursor dari urutan lain atau yang dimanipulasi ditolak sebelum query database
+func TestListEndpoints_RejectForeignCursor(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("mongo.Connect() error = %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	cfg := config.Default()
+	cfg.Auth.CursorSecret = config.Secret(testCursorSecret)
+	achievementService := service.NewAchievementService(client.Database("cursor_test"), nil, cfg, nil)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "admin-1")
+		c.Locals("role_id", "1")
+		return c.Next()
+	})
+	app.Get("/pending", achievementService.GetPendingVerification)
+	app.Get("/search", achievementService.SearchAchievements)
+
+	value := "2024-03-01T08:00:00Z"
+	createdAtCursor := utils.EncodeCursor(models.Cursor{SortBy: "created_at", SortOrder: "desc", Value: &value, ID: "user-1"}, testCursorSecret)
+	pendingCursor := utils.EncodeCursor(models.Cursor{SortBy: "submitted_at", SortOrder: "asc", Value: &value, ID: "user-1"}, testCursorSecret)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"Pending with tampered cursor", "/pending?cursor=not-a-cursor"},
+		{"Pending with cursor from another list", "/pending?cursor=" + createdAtCursor},
+		{"Search with tampered cursor", "/search?q=lomba&cursor=not-a-cursor"},
+		{"Search with pending cursor", "/search?q=lomba&cursor=" + pendingCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.url, nil))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			if resp.StatusCode != 400 {
+				t.Errorf("status = %d, want 400", resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const competitionSchema = `{
+	"type": "object",
+	"properties": {
+		"rank": {"type": "integer", "minimum": 1},
+		"organizer": {"type": "string", "minLength": 1},
+		"participants": {"type": "integer"}
+	},
+	"required": ["rank", "organizer"],
+	"additionalProperties": false
+}`
+
+func TestCompileDetailsSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"Valid object schema", competitionSchema, false},
+		{"Not JSON", `{"type":`, true},
+		{"Not an object schema", `{"type": "array"}`, true},
+		{"Invalid keyword value", `{"type": "object", "required": "rank"}`, true},
+		{"Remote ref not allowed", `{"type": "object", "properties": {"x": {"$ref": "http://example.com/s.json"}}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.CompileDetailsSchema([]byte(tt.schema))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CompileDetailsSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDetails(t *testing.T) {
+	schema, err := utils.CompileDetailsSchema([]byte(competitionSchema))
+	if err != nil {
+		t.Fatalf("CompileDetailsSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		details    string
+		wantErrors int
+	}{
+		{"Valid", `{"rank": 1, "organizer": "Kemendikbud", "participants": 120}`, 0},
+		{"Missing details checks required", `null`, 1},
+		{"Wrong type", `{"rank": "first", "organizer": "Kemendikbud"}`, 1},
+		{"Several violations", `{"rank": 0, "organizer": "", "venue": "Jakarta"}`, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var details map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.details), &details); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			err := utils.ValidateDetails(schema, details)
+			if tt.wantErrors == 0 {
+				if err != nil {
+					t.Errorf("ValidateDetails() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *utils.DetailsValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateDetails() error = %v, want DetailsValidationError", err)
+			}
+			if len(validationErr.Errors) != tt.wantErrors {
+				t.Errorf("errors = %v, want %d errors", validationErr.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestTaxonomyDetailsSchema(t *testing.T) {
+	parent := func(id string) *string { return &id }
+	taxonomy := &models.Taxonomy{
+		Categories: []models.AchievementCategory{
+			{ID: "competition", Name: "Competition", DetailsSchema: json.RawMessage(competitionSchema)},
+			{ID: "academic", Name: "Academic Competition", ParentID: parent("competition")},
+			{ID: "publication", Name: "Publication"},
+		},
+	}
+
+	if got := taxonomy.DetailsSchema(&taxonomy.Categories[1]); string(got) != competitionSchema {
+		t.Errorf("DetailsSchema(sub-category) = %s, want parent schema", got)
+	}
+	if got := taxonomy.DetailsSchema(&taxonomy.Categories[2]); got != nil {
+		t.Errorf("DetailsSchema(no schema) = %s, want nil", got)
+	}
+}
+
+func TestParseAchievementFilter_Details(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"No details filter", "status=verified", nil, false},
+		{"Details fields", "details.rank=1&details.venue.city=Jakarta", map[string]string{"rank": "1", "venue.city": "Jakarta"}, false},
+		{"Operator injection", "details.$where=1", nil, true},
+		{"Empty field", "details.=1", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter *models.AchievementFilter
+			var parseErr error
+			app := fiber.New()
+			app.Get("/achievements", func(c *fiber.Ctx) error {
+				filter, parseErr = service.ParseAchievementFilter(c)
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest("GET", "/achievements?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if (parseErr != nil) != tt.wantErr {
+				t.Fatalf("ParseAchievementFilter() error = %v, wantErr %v", parseErr, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(filter.Details) != len(tt.want) {
+				t.Fatalf("Details = %v, want %v", filter.Details, tt.want)
+			}
+			for field, value := range tt.want {
+				if filter.Details[field] != value {
+					t.Errorf("Details[%s] = %q, want %q", field, filter.Details[field], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTableWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := utils.NewTableWriter(utils.ExportFormatCSV, &buf)
+	if err != nil {
+		t.Fatalf("NewTableWriter() error = %v", err)
+	}
+
+	verifiedAt := time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)
+	var notVerified *time.Time
+	table.WriteRow("title", "count", "verified_at", "pending_at")
+	table.WriteRow("=HYPERLINK(\"x\")", 3, &verifiedAt, notVerified)
+	if err := table.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("rows = %d, want 2", len(records))
+	}
+
+	row := records[1]
+	if row[0] != "'=HYPERLINK(\"x\")" {
+		t.Errorf("formula not escaped: %q", row[0])
+	}
+	if row[1] != "3" || row[2] != "2025-03-01T08:30:00Z" || row[3] != "" {
+		t.Errorf("row = %q", row)
+	}
+}
+
+func TestTableWriter_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := utils.NewTableWriter(utils.ExportFormatXLSX, &buf)
+	if err != nil {
+		t.Fatalf("NewTableWriter() error = %v", err)
+	}
+
+	columns := make([]interface{}, 28)
+	for i := range columns {
+		columns[i] = "col"
+	}
+	table.WriteRow(columns...)
+	table.WriteRow("Juara <1> & Terbaik", 42)
+	if err := table.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid xlsx zip: %v", err)
+	}
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	checks := []string{
+		`<c r="AB1" t="inlineStr">`,
+		`Juara &lt;1&gt; &amp; Terbaik`,
+		`<c r="B2"><v>42</v></c>`,
+		`</sheetData></worksheet>`,
+	}
+	for _, want := range checks {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q", want)
+		}
+	}
+}
+
+func TestNewTableWriter_UnsupportedFormat(t *testing.T) {
+	if _, err := utils.NewTableWriter("pdf", io.Discard); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestWriteStatisticsExport(t *testing.T) {
+	stats := map[string]interface{}{
+		"total_achievements": 5,
+		"total_verified":     3,
+		"total_pending":      1,
+		"total_rejected":     0,
+		"total_draft":        1,
+		"category_count":     map[string]int{"Penelitian": 2, "Kompetisi": 3},
+		"level_count":        map[string]int{"Nasional": 5},
+		"period_count":       map[string]int{"2025-03": 5},
+	}
+
+	var buf bytes.Buffer
+	if err := service.WriteStatisticsExport(&buf, utils.ExportFormatCSV, stats); err != nil {
+		t.Fatalf("WriteStatisticsExport() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	// header + 5 summary + 2 category + 1 level + 1 period
+	if len(records) != 10 {
+		t.Fatalf("rows = %d, want 10", len(records))
+	}
+	if records[6][0] != "category" || records[6][1] != "Kompetisi" || records[6][2] != "3" {
+		t.Errorf("categories should be sorted, got %q", records[6])
+	}
+}
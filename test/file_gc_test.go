@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"crud-app/test/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeAgedFile membuat file dengan waktu modifikasi age yang lalu
+func writeAgedFile(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("isi"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestFileGarbageCollector_RunOnce(t *testing.T) {
+	uploadDir := t.TempDir()
+	partialDir := t.TempDir()
+	exportDir := t.TempDir()
+
+	oldOrphan := filepath.Join(uploadDir, "orphan.pdf")
+	newOrphan := filepath.Join(uploadDir, "uploading.pdf")
+	referenced := filepath.Join(uploadDir, "sertifikat.pdf")
+	referencedThumb := filepath.Join(uploadDir, "foto_thumb.jpg")
+	deletedFile := filepath.Join(uploadDir, "dihapus.pdf")
+	recentlyDeletedFile := filepath.Join(uploadDir, "baru_dihapus.pdf")
+	partialFile := filepath.Join(partialDir, "upload-1")
+	exportFile := filepath.Join(exportDir, "laporan.xlsx")
+
+	writeAgedFile(t, oldOrphan, 48*time.Hour)
+	writeAgedFile(t, newOrphan, time.Minute)
+	writeAgedFile(t, referenced, 48*time.Hour)
+	writeAgedFile(t, referencedThumb, 48*time.Hour)
+	writeAgedFile(t, deletedFile, 60*24*time.Hour)
+	writeAgedFile(t, recentlyDeletedFile, 48*time.Hour)
+	writeAgedFile(t, partialFile, 48*time.Hour)
+	writeAgedFile(t, exportFile, 48*time.Hour)
+
+	achievementRepo := mocks.NewMockAchievementRepository()
+	deletedLongAgo := time.Now().Add(-31 * 24 * time.Hour)
+	deletedRecently := time.Now().Add(-time.Hour)
+	achievementRepo.AddAchievement(&models.Achievement{
+		AchievementID: "active",
+		Documents:     []models.Document{{Filepath: referenced}, {Filepath: filepath.Join(uploadDir, "foto.jpg"), ThumbnailPath: referencedThumb}},
+	})
+	achievementRepo.AddAchievement(&models.Achievement{
+		AchievementID: "purged",
+		IsDeleted:     true,
+		DeletedAt:     &deletedLongAgo,
+		Documents:     []models.Document{{Filepath: deletedFile}},
+	})
+	achievementRepo.AddAchievement(&models.Achievement{
+		AchievementID: "retained",
+		IsDeleted:     true,
+		DeletedAt:     &deletedRecently,
+		Documents:     []models.Document{{Filepath: recentlyDeletedFile}},
+	})
+
+	sessionRepo := mocks.NewMockUploadSessionRepository()
+	sessionRepo.Sessions["upload-1"] = models.UploadSession{UploadID: "upload-1", PartialPath: partialFile, ExpiresAt: time.Now().Add(-time.Hour)}
+	sessionRepo.Sessions["upload-2"] = models.UploadSession{UploadID: "upload-2", ExpiresAt: time.Now().Add(time.Hour)}
+	exportRepo := mocks.NewMockExportJobRepository()
+	exportRepo.Jobs["job-1"] = models.ExportJob{JobID: "job-1", Filepath: exportFile, ExpiresAt: time.Now().Add(-time.Hour)}
+
+	gc := service.NewFileGarbageCollectorWithRepositories(achievementRepo, sessionRepo, exportRepo,
+		utils.FileUploadConfig{UploadPath: uploadDir},
+		service.FileGCConfig{Retention: 30 * 24 * time.Hour, OrphanGracePeriod: time.Hour})
+
+	result, err := gc.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+
+	want := service.FileGCResult{OrphansRemoved: 1, PurgedFiles: 1, PurgedRecords: 1, ExpiredUploads: 1, ExpiredExports: 1}
+	if *result != want {
+		t.Errorf("RunOnce() = %+v, want %+v", *result, want)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		exists bool
+	}{
+		{"Old orphan removed", oldOrphan, false},
+		{"File inside grace period kept", newOrphan, true},
+		{"Referenced file kept", referenced, true},
+		{"Referenced thumbnail kept", referencedThumb, true},
+		{"Soft-deleted file past retention purged", deletedFile, false},
+		{"Soft-deleted file within retention kept", recentlyDeletedFile, true},
+		{"Expired partial upload removed", partialFile, false},
+		{"Expired export removed", exportFile, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fileExists(tt.path); got != tt.exists {
+				t.Errorf("exists(%s) = %v, want %v", filepath.Base(tt.path), got, tt.exists)
+			}
+		})
+	}
+
+	purged, _ := achievementRepo.FindDeletedBefore(context.Background(), time.Now())
+	for _, achievement := range purged {
+		if achievement.AchievementID == "purged" {
+			t.Error("purged achievement still has documents")
+		}
+	}
+	if _, ok := sessionRepo.Sessions["upload-2"]; !ok {
+		t.Error("active upload session was deleted")
+	}
+}
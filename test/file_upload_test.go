@@ -0,0 +1,207 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crud-app/app/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime/multipart"
+	"os"
+	"testing"
+)
+
+// buildFileHeader membuat multipart.FileHeader in-memory untuk testing
+func buildFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("documents", filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(10 << 20)
+	if err != nil {
+		t.Fatalf("ReadForm() error = %v", err)
+	}
+	return form.File["documents"][0]
+}
+
+func buildDocx(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	f, err := archive.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("zip Create() error = %v", err)
+	}
+	f.Write([]byte("<w:document/>"))
+	archive.Close()
+	return buf.Bytes()
+}
+
+func TestDetectMimeType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"PDF", []byte("%PDF-1.7\n..."), "application/pdf"},
+		{"JPEG", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}, "image/jpeg"},
+		{"PNG", []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n', 0x00}, "image/png"},
+		{"DOC", []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, "application/msword"},
+		{"DOCX", buildDocx(t), "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"MP4", []byte("\x00\x00\x00\x18ftypmp42"), "video/mp4"},
+		{"QuickTime", []byte("\x00\x00\x00\x14ftypqt  "), "video/quicktime"},
+		{"Executable", []byte("MZ\x90\x00\x03\x00\x00\x00"), "application/octet-stream"},
+		{"Empty", []byte{}, "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.DetectMimeType(bytes.NewReader(tt.content), int64(len(tt.content)))
+			if err != nil {
+				t.Fatalf("DetectMimeType() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectMimeType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveUploadedFile_Validation(t *testing.T) {
+	config := utils.DefaultUploadConfig
+	config.UploadPath = t.TempDir()
+	config.QuarantinePath = t.TempDir()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantCode string
+		wantMime string
+	}{
+		{"Valid PDF", "sertifikat.pdf", []byte("%PDF-1.4 content"), "", "application/pdf"},
+		{"Renamed executable", "sertifikat.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00"), utils.UploadErrContentMismatch, ""},
+		{"PNG renamed as JPG", "foto.jpg", []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}, utils.UploadErrContentMismatch, ""},
+		{"Disallowed extension", "script.exe", []byte("MZ"), utils.UploadErrTypeNotAllowed, ""},
+		{"Oversized image dimensions", "foto.png", pngWithDimensions(t, 30000, 30000), utils.UploadErrImageTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := buildFileHeader(t, tt.filename, tt.content)
+			// Header dari client tidak boleh dipercaya
+			header.Header.Set("Content-Type", "application/pdf")
+
+			saved, err := utils.SaveUploadedFile(header, config)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("SaveUploadedFile() error = %v", err)
+				}
+				if saved.Mimetype != tt.wantMime {
+					t.Errorf("Mimetype = %v, want %v", saved.Mimetype, tt.wantMime)
+				}
+				if saved.Filesize != int64(len(tt.content)) {
+					t.Errorf("Filesize = %v, want %v", saved.Filesize, len(tt.content))
+				}
+				sum := sha256.Sum256(tt.content)
+				if saved.SHA256 != hex.EncodeToString(sum[:]) {
+					t.Errorf("SHA256 = %v, want %v", saved.SHA256, hex.EncodeToString(sum[:]))
+				}
+				return
+			}
+
+			var uploadErr *utils.UploadError
+			if !errors.As(err, &uploadErr) {
+				t.Fatalf("SaveUploadedFile() error = %v, want UploadError", err)
+			}
+			if uploadErr.Code != tt.wantCode {
+				t.Errorf("Code = %v, want %v", uploadErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCheckUploadQuota(t *testing.T) {
+	config := utils.FileUploadConfig{
+		MaxAchievementStorage: 100,
+		MaxStudentStorage:     150,
+	}
+	files := []*multipart.FileHeader{{Size: 40}, {Size: 20}}
+
+	tests := []struct {
+		name             string
+		achievementUsage int64
+		studentUsage     int64
+		wantCode         string
+	}{
+		{"Within quota", 0, 0, ""},
+		{"Achievement quota exceeded", 50, 50, utils.UploadErrAchievementQuota},
+		{"Student quota exceeded", 10, 100, utils.UploadErrStudentQuota},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.CheckUploadQuota(files, tt.achievementUsage, tt.studentUsage, config)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("CheckUploadQuota() error = %v", err)
+				}
+				return
+			}
+
+			var uploadErr *utils.UploadError
+			if !errors.As(err, &uploadErr) || uploadErr.Code != tt.wantCode {
+				t.Errorf("CheckUploadQuota() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSaveMultipleFiles_RollbackRemovesThumbnails(t *testing.T) {
+	config := utils.DefaultUploadConfig
+	config.UploadPath = t.TempDir()
+	config.QuarantinePath = t.TempDir()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{"foto.jpg", jpegWithExif(t, 400, 200, 1)},
+		{"script.exe", []byte("MZ")},
+	} {
+		part, err := writer.CreateFormFile("documents", file.name)
+		if err != nil {
+			t.Fatalf("CreateFormFile() error = %v", err)
+		}
+		part.Write(file.content)
+	}
+	writer.Close()
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(10 << 20)
+	if err != nil {
+		t.Fatalf("ReadForm() error = %v", err)
+	}
+
+	if _, err := utils.SaveMultipleFiles(form, "documents", config); err == nil {
+		t.Fatal("expected error for disallowed file")
+	}
+
+	// File gambar pertama dan thumbnail-nya ikut dihapus
+	entries, err := os.ReadDir(config.UploadPath)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, entry := range entries {
+		t.Errorf("file %s left behind after rollback", entry.Name())
+	}
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"bytes"
+	"crud-app/app/utils"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// jpegWithExif membuat JPEG dengan segmen APP1 Exif berisi tag Orientation
+func jpegWithExif(t *testing.T, width, height, orientation int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	// TIFF little-endian dengan satu entry IFD0: Orientation
+	tiff := []byte("II")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 42)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPSLatitude-7.2575")...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := encoded.Bytes()
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	return append(result, data[2:]...)
+}
+
+func TestProcessImage_JPEG(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foto.jpg")
+	if err := os.WriteFile(path, jpegWithExif(t, 800, 400, 6), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	processed, err := utils.ProcessImage(path, "image/jpeg")
+	if err != nil {
+		t.Fatalf("ProcessImage() error = %v", err)
+	}
+
+	stored, _ := os.ReadFile(path)
+	if bytes.Contains(stored, []byte("Exif")) || bytes.Contains(stored, []byte("GPSLatitude")) {
+		t.Error("Stored image still contains EXIF/GPS metadata")
+	}
+
+	// Orientation 6 (rotate 90 CW) menukar lebar dan tinggi
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(stored))
+	if err != nil {
+		t.Fatalf("DecodeConfig() error = %v", err)
+	}
+	if cfg.Width != 400 || cfg.Height != 800 {
+		t.Errorf("Stored image size = %dx%d, want 400x800", cfg.Width, cfg.Height)
+	}
+
+	if processed.ThumbnailPath != filepath.Join(dir, "foto_thumb.jpg") {
+		t.Errorf("ThumbnailPath = %v", processed.ThumbnailPath)
+	}
+	thumb, err := os.Open(processed.ThumbnailPath)
+	if err != nil {
+		t.Fatalf("Thumbnail not created: %v", err)
+	}
+	defer thumb.Close()
+	thumbCfg, _, err := image.DecodeConfig(thumb)
+	if err != nil {
+		t.Fatalf("DecodeConfig() thumbnail error = %v", err)
+	}
+	if thumbCfg.Width != 160 || thumbCfg.Height != utils.ThumbnailMaxSize {
+		t.Errorf("Thumbnail size = %dx%d, want 160x%d", thumbCfg.Width, thumbCfg.Height, utils.ThumbnailMaxSize)
+	}
+}
+
+func TestProcessImage_PNG(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scan.png")
+
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	processed, err := utils.ProcessImage(path, "image/png")
+	if err != nil {
+		t.Fatalf("ProcessImage() error = %v", err)
+	}
+
+	// Gambar kecil tidak diperbesar
+	thumb, err := os.Open(processed.ThumbnailPath)
+	if err != nil {
+		t.Fatalf("Thumbnail not created: %v", err)
+	}
+	defer thumb.Close()
+	cfg, format, err := image.DecodeConfig(thumb)
+	if err != nil {
+		t.Fatalf("DecodeConfig() error = %v", err)
+	}
+	if format != "png" || cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("Thumbnail = %s %dx%d, want png 100x50", format, cfg.Width, cfg.Height)
+	}
+}
+
+// pngWithDimensions membuat PNG 1x1 lalu mengubah dimensi pada chunk IHDR,
+// meniru file kecil yang mengklaim dimensi sangat besar
+func pngWithDimensions(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	data := buf.Bytes()
+	// Signature (8) + length (4), lalu "IHDR" dan data IHDR (13 byte) + CRC
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestCheckImageDimensions(t *testing.T) {
+	if err := utils.CheckImageDimensions(bytes.NewReader(pngWithDimensions(t, 8000, 5000))); err != nil {
+		t.Errorf("40 MP image rejected: %v", err)
+	}
+	if err := utils.CheckImageDimensions(bytes.NewReader(pngWithDimensions(t, 30000, 30000))); err == nil {
+		t.Error("expected error for 30000x30000 image")
+	}
+
+	// Rescanner dan import memakai ProcessImage langsung, jadi batas juga dicek di sana
+	path := filepath.Join(t.TempDir(), "bom.png")
+	if err := os.WriteFile(path, pngWithDimensions(t, 30000, 30000), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := utils.ProcessImage(path, "image/png"); err == nil {
+		t.Error("ProcessImage() should reject oversized image before decoding")
+	}
+}
@@ -3,24 +3,22 @@ package test
 import (
 	models "crud-app/app/model"
 	"crud-app/app/utils"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func TestGenerateToken(t *testing.T) {
-	// Set test JWT secret
-	os.Setenv("JWT_SECRET", "test-secret-key")
+var testJWTConfig = utils.JWTConfig{Secret: []byte("test-secret-key"), TokenTTL: time.Hour}
 
+func TestGenerateToken(t *testing.T) {
 	user := models.User{
 		ID:       "test-user-id",
 		Username: "testuser",
 		RoleID:   "1",
 	}
 
-	token, err := utils.GenerateToken(user)
+	token, err := utils.GenerateToken(user, testJWTConfig)
 	if err != nil {
 		t.Fatalf("GenerateToken() error = %v", err)
 	}
@@ -36,9 +34,6 @@ func TestGenerateToken(t *testing.T) {
 }
 
 func TestValidateToken(t *testing.T) {
-	// Set test JWT secret
-	os.Setenv("JWT_SECRET", "test-secret-key")
-
 	user := models.User{
 		ID:       "test-user-id",
 		Username: "testuser",
@@ -46,7 +41,7 @@ func TestValidateToken(t *testing.T) {
 	}
 
 	// Generate valid token
-	validToken, err := utils.GenerateToken(user)
+	validToken, err := utils.GenerateToken(user, testJWTConfig)
 	if err != nil {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
@@ -80,7 +75,7 @@ func TestValidateToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			claims, err := utils.ValidateToken(tt.token)
+			claims, err := utils.ValidateToken(tt.token, testJWTConfig)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -105,9 +100,6 @@ func TestValidateToken(t *testing.T) {
 }
 
 func TestTokenExpiration(t *testing.T) {
-	// Set test JWT secret
-	os.Setenv("JWT_SECRET", "test-secret-key")
-
 	// Create expired token manually
 	claims := utils.JwtClaims{
 		UserID:   "test-user",
@@ -126,8 +118,19 @@ func TestTokenExpiration(t *testing.T) {
 	}
 
 	// Try to validate expired token
-	_, err = utils.ValidateToken(tokenString)
+	_, err = utils.ValidateToken(tokenString, testJWTConfig)
 	if err == nil {
 		t.Error("ValidateToken() should return error for expired token")
 	}
+}
+
+func TestValidateToken_WrongSecret(t *testing.T) {
+	user := models.User{ID: "test-user-id", Username: "testuser", RoleID: "1"}
+	token, err := utils.GenerateToken(user, testJWTConfig)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if _, err := utils.ValidateToken(token, utils.JWTConfig{Secret: []byte("other-secret"), TokenTTL: time.Hour}); err == nil {
+		t.Error("ValidateToken() should return error for token signed with another secret")
+	}
 }
\ No newline at end of file
@@ -0,0 +1,128 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRankLeaderboard(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []int
+		want   []int
+	}{
+		{"Empty", nil, nil},
+		{"Distinct scores", []int{9, 5, 2}, []int{1, 2, 3}},
+		{"Ties share rank and skip next", []int{7, 5, 5, 3}, []int{1, 2, 2, 4}},
+		{"All tied", []int{4, 4, 4}, []int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]models.LeaderboardEntry, len(tt.scores))
+			for i, score := range tt.scores {
+				entries[i].Score = score
+			}
+
+			models.RankLeaderboard(entries)
+
+			for i, entry := range entries {
+				if entry.Rank != tt.want[i] {
+					t.Errorf("entries[%d].Rank = %d, want %d", i, entry.Rank, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderboardAccepts(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []int
+		limit  int
+		want   []int
+	}{
+		{"Below limit", []int{9, 5}, 3, []int{9, 5}},
+		{"Cut at limit", []int{9, 7, 5, 3}, 2, []int{9, 7}},
+		{"Ties at boundary included", []int{9, 5, 5, 5, 3}, 2, []int{9, 5, 5, 5}},
+		{"Ties above boundary", []int{9, 9, 9, 5}, 2, []int{9, 9, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []models.LeaderboardEntry
+			for _, score := range tt.scores {
+				next := models.LeaderboardEntry{Score: score}
+				if !models.LeaderboardAccepts(entries, tt.limit, next) {
+					break
+				}
+				entries = append(entries, next)
+			}
+
+			if len(entries) != len(tt.want) {
+				t.Fatalf("got %d entries, want %v", len(entries), tt.want)
+			}
+			for i, entry := range entries {
+				if entry.Score != tt.want[i] {
+					t.Errorf("entries[%d].Score = %d, want %d", i, entry.Score, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseLeaderboardFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantRankBy string
+		wantLimit  int
+		wantErr    bool
+	}{
+		{"Defaults", "", models.LeaderboardRankByVerified, 10, false},
+		{"Points with scope", "rank_by=points&program_study=Informatika&academic_year=2022&limit=25", models.LeaderboardRankByPoints, 25, false},
+		{"Date range", "date_from=2025-01-01&date_to=2025-06-30", models.LeaderboardRankByVerified, 10, false},
+		{"Invalid rank_by", "rank_by=title", "", 0, true},
+		{"Limit too large", "limit=500", "", 0, true},
+		{"Limit zero", "limit=0", "", 0, true},
+		{"Reversed range", "date_from=2025-12-31&date_to=2025-01-01", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter *models.LeaderboardFilter
+			var parseErr error
+			app := fiber.New()
+			app.Get("/leaderboard", func(c *fiber.Ctx) error {
+				filter, parseErr = service.ParseLeaderboardFilter(c)
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest("GET", "/leaderboard?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if (parseErr != nil) != tt.wantErr {
+				t.Fatalf("ParseLeaderboardFilter() error = %v, wantErr %v", parseErr, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if filter.RankBy != tt.wantRankBy || filter.Limit != tt.wantLimit {
+				t.Errorf("filter = %+v, want rank_by %s limit %d", filter, tt.wantRankBy, tt.wantLimit)
+			}
+		})
+	}
+
+	t.Run("Cache key follows scope", func(t *testing.T) {
+		a := models.LeaderboardFilter{ProgramStudy: "Informatika", RankBy: "verified", Limit: 10}
+		b := a
+		b.RankBy = "points"
+		if a.CacheKey() == b.CacheKey() {
+			t.Errorf("CacheKey() = %q for different rank_by", a.CacheKey())
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"crud-app/database"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestParseMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int64
+		wantErr string
+	}{
+		{
+			name: "pairs sorted by version",
+			files: fstest.MapFS{
+				"0010_later.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+				"0010_later.down.sql": {Data: []byte("DROP TABLE b;")},
+				"0002_first.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+				"0002_first.down.sql": {Data: []byte("DROP TABLE a;")},
+				"README.md":           {Data: []byte("bukan migration")},
+			},
+			want: []int64{2, 10},
+		},
+		{
+			name: "missing down file",
+			files: fstest.MapFS{
+				"0001_init.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+			},
+			wantErr: "harus memiliki file up dan down",
+		},
+		{
+			name: "invalid file name",
+			files: fstest.MapFS{
+				"init.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "harus berformat",
+		},
+		{
+			name: "invalid direction",
+			files: fstest.MapFS{
+				"0001_init.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: ".up.sql atau .down.sql",
+		},
+		{
+			name: "duplicate version with different names",
+			files: fstest.MapFS{
+				"0001_init.up.sql":    {Data: []byte("SELECT 1;")},
+				"0001_init.down.sql":  {Data: []byte("SELECT 1;")},
+				"0001_other.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_other.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: "memiliki dua nama",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := database.ParseMigrations(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(migrations) != len(tt.want) {
+				t.Fatalf("migrations = %d, want %d", len(migrations), len(tt.want))
+			}
+			for i, migration := range migrations {
+				if migration.Version != tt.want[i] {
+					t.Errorf("migration %d version = %d, want %d", i, migration.Version, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMigrations_Embedded(t *testing.T) {
+	migrations, err := database.Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+
+	want := []string{"initial_schema", "scoring_rubrics", "achievement_taxonomy"}
+	if len(migrations) != len(want) {
+		t.Fatalf("migrations = %d, want %d", len(migrations), len(want))
+	}
+	for i, migration := range migrations {
+		if migration.Version != int64(i+1) || migration.Name != want[i] {
+			t.Errorf("migration %d = %04d_%s, want %04d_%s", i, migration.Version, migration.Name, i+1, want[i])
+		}
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %s is missing up or down SQL", migration.Name)
+		}
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	migrations := []database.Migration{
+		{Version: 1, Name: "init"},
+		{Version: 2, Name: "rubrics"},
+		{Version: 3, Name: "taxonomy"},
+	}
+	now := time.Now()
+
+	pending, err := database.PendingMigrations(migrations, map[int64]time.Time{1: now, 3: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Version != 2 {
+		t.Errorf("pending = %+v, want version 2", pending)
+	}
+
+	pending, err = database.PendingMigrations(migrations, map[int64]time.Time{1: now, 2: now, 3: now})
+	if err != nil || len(pending) != 0 {
+		t.Errorf("pending = %+v, err = %v, want none", pending, err)
+	}
+
+	if _, err := database.PendingMigrations(migrations, map[int64]time.Time{1: now, 4: now}); err == nil || !strings.Contains(err.Error(), "4") {
+		t.Errorf("expected error for unknown version 4, got %v", err)
+	}
+}
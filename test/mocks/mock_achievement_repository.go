@@ -202,6 +202,45 @@ func (m *MockAchievementRepository) GetStatisticsByStudentIDs(ctx context.Contex
 	return stats, nil
 }
 
+func (m *MockAchievementRepository) FindDeletedBefore(ctx context.Context, cutoff time.Time) ([]models.Achievement, error) {
+	m.calls["FindDeletedBefore"]++
+
+	var results []models.Achievement
+	for _, achievement := range m.achievements {
+		if achievement.IsDeleted && achievement.DeletedAt != nil && achievement.DeletedAt.Before(cutoff) && len(achievement.Documents) > 0 {
+			results = append(results, *achievement)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockAchievementRepository) ClearDocuments(ctx context.Context, achievementID string) error {
+	m.calls["ClearDocuments"]++
+
+	achievement, exists := m.achievements[achievementID]
+	if !exists {
+		return errors.New("achievement not found")
+	}
+	achievement.Documents = []models.Document{}
+	achievement.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockAchievementRepository) FindAllDocumentPaths(ctx context.Context) (map[string]bool, error) {
+	m.calls["FindAllDocumentPaths"]++
+
+	paths := make(map[string]bool)
+	for _, achievement := range m.achievements {
+		for _, doc := range achievement.Documents {
+			paths[doc.Filepath] = true
+			if doc.ThumbnailPath != "" {
+				paths[doc.ThumbnailPath] = true
+			}
+		}
+	}
+	return paths, nil
+}
+
 // Helper methods for testing
 func (m *MockAchievementRepository) AddAchievement(achievement *models.Achievement) {
 	if achievement.ID.IsZero() {
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	models "crud-app/app/model"
+	"time"
+)
+
+// MockUploadSessionRepository implements FileGCSessionRepository for testing
+type MockUploadSessionRepository struct {
+	Sessions map[string]models.UploadSession
+}
+
+func NewMockUploadSessionRepository() *MockUploadSessionRepository {
+	return &MockUploadSessionRepository{Sessions: make(map[string]models.UploadSession)}
+}
+
+func (m *MockUploadSessionRepository) FindExpired(ctx context.Context, now time.Time) ([]models.UploadSession, error) {
+	var results []models.UploadSession
+	for _, session := range m.Sessions {
+		if session.ExpiresAt.Before(now) {
+			results = append(results, session)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockUploadSessionRepository) Delete(ctx context.Context, uploadID string) error {
+	delete(m.Sessions, uploadID)
+	return nil
+}
+
+// MockExportJobRepository implements FileGCExportJobRepository for testing
+type MockExportJobRepository struct {
+	Jobs map[string]models.ExportJob
+}
+
+func NewMockExportJobRepository() *MockExportJobRepository {
+	return &MockExportJobRepository{Jobs: make(map[string]models.ExportJob)}
+}
+
+func (m *MockExportJobRepository) FindExpired(ctx context.Context, now time.Time) ([]models.ExportJob, error) {
+	var results []models.ExportJob
+	for _, job := range m.Jobs {
+		if job.ExpiresAt.Before(now) {
+			results = append(results, job)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockExportJobRepository) Delete(ctx context.Context, jobID string) error {
+	delete(m.Jobs, jobID)
+	return nil
+}
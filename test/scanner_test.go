@@ -0,0 +1,194 @@
+package test
+
+import (
+	"bytes"
+	"crud-app/app/utils"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamdStreamMax meniru StreamMaxLength clamd
+const fakeClamdStreamMax = 1024
+
+// startFakeClamd menjalankan server TCP yang meniru protokol INSTREAM clamd
+func startFakeClamd(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go handleFakeClamdConn(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func handleFakeClamdConn(conn net.Conn) {
+	defer conn.Close()
+
+	command := make([]byte, len("zINSTREAM\x00"))
+	if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var data bytes.Buffer
+	size := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size)
+		if n == 0 {
+			break
+		}
+		if _, err := io.CopyN(&data, conn, int64(n)); err != nil {
+			return
+		}
+	}
+
+	if data.Len() > fakeClamdStreamMax {
+		conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+		return
+	}
+	if strings.Contains(data.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}
+
+func TestClamdScanner_Scan(t *testing.T) {
+	scanner := utils.NewClamdScanner(startFakeClamd(t))
+	scanner.ChunkSize = 16 // paksa beberapa chunk
+
+	tests := []struct {
+		name          string
+		content       string
+		wantClean     bool
+		wantSignature string
+	}{
+		{"Clean file", "%PDF-1.4 sertifikat lomba", true, ""},
+		{"EICAR test file", eicar, false, "Eicar-Test-Signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := scanner.Scan(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if result.Clean != tt.wantClean {
+				t.Errorf("Clean = %v, want %v", result.Clean, tt.wantClean)
+			}
+			if result.Signature != tt.wantSignature {
+				t.Errorf("Signature = %v, want %v", result.Signature, tt.wantSignature)
+			}
+		})
+	}
+}
+
+func TestClamdScanner_StreamLimit(t *testing.T) {
+	scanner := utils.NewClamdScanner(startFakeClamd(t))
+	large := "%PDF-1.4 " + strings.Repeat("x", 2*fakeClamdStreamMax)
+
+	t.Run("Size limit reply", func(t *testing.T) {
+		unlimited := *scanner
+		unlimited.MaxStreamSize = 0
+		if _, err := unlimited.Scan(strings.NewReader(large)); !errors.Is(err, utils.ErrScanTooLarge) {
+			t.Errorf("Scan() error = %v, want %v", err, utils.ErrScanTooLarge)
+		}
+	})
+
+	t.Run("Client side limit", func(t *testing.T) {
+		limited := utils.NewClamdScanner(scanner.Address)
+		limited.MaxStreamSize = fakeClamdStreamMax
+		if _, err := limited.Scan(strings.NewReader(large)); !errors.Is(err, utils.ErrScanTooLarge) {
+			t.Errorf("Scan() error = %v, want %v", err, utils.ErrScanTooLarge)
+		}
+	})
+}
+
+func TestSaveUploadedFile_Scanning(t *testing.T) {
+	config := utils.DefaultUploadConfig
+	config.UploadPath = t.TempDir()
+	config.QuarantinePath = t.TempDir()
+	config.Scanner = utils.NewClamdScanner(startFakeClamd(t))
+
+	t.Run("Clean file leaves quarantine", func(t *testing.T) {
+		header := buildFileHeader(t, "sertifikat.pdf", []byte("%PDF-1.4 content"))
+		saved, err := utils.SaveUploadedFile(header, config)
+		if err != nil {
+			t.Fatalf("SaveUploadedFile() error = %v", err)
+		}
+		if saved.ScanStatus != utils.ScanStatusClean {
+			t.Errorf("ScanStatus = %v, want %v", saved.ScanStatus, utils.ScanStatusClean)
+		}
+		if filepath.Dir(saved.Filepath) != config.UploadPath {
+			t.Errorf("Filepath = %v, want file in %v", saved.Filepath, config.UploadPath)
+		}
+	})
+
+	t.Run("Infected file is rejected", func(t *testing.T) {
+		header := buildFileHeader(t, "sertifikat.pdf", []byte("%PDF-1.4 "+eicar))
+		_, err := utils.SaveUploadedFile(header, config)
+
+		var uploadErr *utils.UploadError
+		if !errors.As(err, &uploadErr) || uploadErr.Code != utils.UploadErrInfected {
+			t.Fatalf("SaveUploadedFile() error = %v, want code %v", err, utils.UploadErrInfected)
+		}
+		entries, _ := os.ReadDir(config.QuarantinePath)
+		if len(entries) != 0 {
+			t.Errorf("Infected file still in quarantine: %v", entries)
+		}
+	})
+
+	t.Run("File above scanner limit leaves quarantine unscanned", func(t *testing.T) {
+		content := []byte("%PDF-1.4 " + strings.Repeat("x", 2*fakeClamdStreamMax))
+		saved, err := utils.SaveUploadedFile(buildFileHeader(t, "video.pdf", content), config)
+		if err != nil {
+			t.Fatalf("SaveUploadedFile() error = %v", err)
+		}
+		if saved.ScanStatus != utils.ScanStatusTooLarge {
+			t.Errorf("ScanStatus = %v, want %v", saved.ScanStatus, utils.ScanStatusTooLarge)
+		}
+		if filepath.Dir(saved.Filepath) != config.UploadPath {
+			t.Errorf("Filepath = %v, want file in %v", saved.Filepath, config.UploadPath)
+		}
+	})
+
+	t.Run("Unavailable scanner keeps file in quarantine", func(t *testing.T) {
+		offline := config
+		offline.Scanner = utils.NewClamdScanner("127.0.0.1:1")
+
+		header := buildFileHeader(t, "sertifikat.pdf", []byte("%PDF-1.4 content"))
+		saved, err := utils.SaveUploadedFile(header, offline)
+		if err != nil {
+			t.Fatalf("SaveUploadedFile() error = %v", err)
+		}
+		if saved.ScanStatus != utils.ScanStatusPending {
+			t.Errorf("ScanStatus = %v, want %v", saved.ScanStatus, utils.ScanStatusPending)
+		}
+		if filepath.Dir(saved.Filepath) != offline.QuarantinePath {
+			t.Errorf("Filepath = %v, want file in %v", saved.Filepath, offline.QuarantinePath)
+		}
+	})
+}
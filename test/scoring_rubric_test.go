@@ -0,0 +1,43 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"testing"
+)
+
+func TestScoringRubrics_Points(t *testing.T) {
+	rubrics := models.ScoringRubrics{
+		{Category: "Kompetisi", Level: "Internasional", Points: 50},
+		{Category: "Kompetisi", Level: "Nasional", Points: 30},
+		{Category: "*", Level: "Nasional", Points: 20},
+		{Category: "Penelitian", Level: "Nasional", Points: 25},
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		level    string
+		want     int
+	}{
+		{"Exact match", "Kompetisi", "Internasional", 50},
+		{"Case-insensitive with spaces", " kompetisi ", "NASIONAL", 30},
+		{"Specific category wins over wildcard", "Penelitian", "Nasional", 25},
+		{"Wildcard category", "Organisasi", "Nasional", 20},
+		{"No rubric", "Organisasi", "Internasional", 0},
+		{"Unknown level", "Kompetisi", "Lokal", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rubrics.Points(tt.category, tt.level); got != tt.want {
+				t.Errorf("Points(%q, %q) = %d, want %d", tt.category, tt.level, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Empty rubric", func(t *testing.T) {
+		if got := (models.ScoringRubrics{}).Points("Kompetisi", "Nasional"); got != 0 {
+			t.Errorf("Points() = %d, want 0", got)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"crud-app/app/utils"
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"Single word", "Lomba", []string{"lomba"}},
+		{"Multiple words with duplicates", "lomba robot LOMBA", []string{"lomba", "robot"}},
+		{"Phrase and negation", `"juara 1" -regional`, []string{"juara", "1"}},
+		{"Punctuation only", "!!", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.ParseSearchTerms(tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSearchTerms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightText(t *testing.T) {
+	longText := "Mahasiswa mengikuti berbagai kegiatan kampus selama satu semester penuh sebelum akhirnya " +
+		"menjadi juara lomba robot tingkat nasional di Surabaya bersama tim."
+
+	tests := []struct {
+		name      string
+		text      string
+		terms     []string
+		maxLength int
+		want      string
+	}{
+		{"Case insensitive", "Juara Lomba Robot", []string{"lomba"}, 0, "Juara <mark>Lomba</mark> Robot"},
+		{"Prefix match", "Perlombaan dan lombanya", []string{"lomba"}, 0, "Perlombaan dan <mark>lombanya</mark>"},
+		{"Escapes HTML", "<b>Lomba</b> & robot", []string{"robot"}, 0, "&lt;b&gt;Lomba&lt;/b&gt; &amp; <mark>robot</mark>"},
+		{"No match", "Juara Lomba", []string{"robot"}, 0, ""},
+		{"Snippet around match", longText, []string{"robot"}, 40, "...lomba <mark>robot</mark> tingkat nasional di..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.HighlightText(tt.text, tt.terms, tt.maxLength)
+			if got != tt.want {
+				t.Errorf("HighlightText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
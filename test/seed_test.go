@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"crud-app/app/middleware"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"crud-app/config"
+	"crud-app/route"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestBuildPermissionCatalog(t *testing.T) {
+	catalog, err := service.BuildPermissionCatalog([]string{"users.read", "students.assign_advisor", "users.read", "reports.export_pdf"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(catalog) != 3 {
+		t.Fatalf("catalog = %d, want 3", len(catalog))
+	}
+
+	want := []struct{ name, resource, action, description string }{
+		{"reports.export_pdf", "reports", "export_pdf", "Akses export pdf pada reports"},
+		{"students.assign_advisor", "students", "assign_advisor", "Menetapkan dosen wali mahasiswa"},
+		{"users.read", "users", "read", "Melihat daftar dan detail user"},
+	}
+	for i, permission := range catalog {
+		if permission.Name != want[i].name || permission.Resource != want[i].resource || permission.Action != want[i].action || permission.Description != want[i].description {
+			t.Errorf("catalog[%d] = %+v, want %+v", i, permission, want[i])
+		}
+	}
+
+	for _, name := range []string{"users", "Users.read", "users.read.all", ".read"} {
+		if _, err := service.BuildPermissionCatalog([]string{name}); err == nil {
+			t.Errorf("expected error for permission name %q", name)
+		}
+	}
+}
+
+func TestBuildRoleGrants(t *testing.T) {
+	catalog, err := service.BuildPermissionCatalog([]string{
+		"achievements.read", "achievements.create", "achievements.update", "achievements.delete", "achievements.verify",
+		"students.read", "lecturers.read", "users.delete",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	grants, err := service.BuildRoleGrants(catalog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grants[service.RoleAdmin]) != len(catalog) {
+		t.Errorf("admin grants = %d, want all %d permissions", len(grants[service.RoleAdmin]), len(catalog))
+	}
+	for _, roleID := range []string{service.RoleLecturer, service.RoleStudent} {
+		for _, name := range grants[roleID] {
+			if name == "users.delete" {
+				t.Errorf("role %s should not be granted users.delete", roleID)
+			}
+		}
+	}
+
+	if _, err := service.BuildRoleGrants(catalog[:1]); err == nil {
+		t.Error("expected error when default grants reference unknown permissions")
+	}
+}
+
+// Katalog dari route harus mencakup semua grant bawaan
+func TestRoutePermissionsCoverDefaultGrants(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("mongo.Connect() error = %v", err)
+	}
+	defer client.Disconnect(context.Background())
+	route.Routes(fiber.New(), config.Default(), nil, client.Database("seed_test"))
+
+	names := middleware.RequiredPermissions()
+	for _, want := range []string{"users.read", "users.assign_role", "achievements.verify", "students.assign_advisor"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("RequiredPermissions() = %v, missing %q", names, want)
+		}
+	}
+
+	catalog, err := service.BuildPermissionCatalog(names)
+	if err != nil {
+		t.Fatalf("BuildPermissionCatalog() error = %v", err)
+	}
+	if _, err := service.BuildRoleGrants(catalog); err != nil {
+		t.Errorf("BuildRoleGrants() error = %v", err)
+	}
+}
+
+func TestSeedAdminFromConfig(t *testing.T) {
+	admin, err := service.SeedAdminFromConfig(config.SeedConfig{})
+	if admin != nil || err != nil {
+		t.Errorf("empty config = %+v, %v, want nil, nil", admin, err)
+	}
+
+	admin, err = service.SeedAdminFromConfig(config.SeedConfig{
+		AdminUsername: " admin ",
+		AdminEmail:    "admin@example.com",
+		AdminPassword: "rahasia123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if admin.Username != "admin" || admin.RoleID != service.RoleAdmin || admin.FullName != "Administrator" || !admin.IsActive {
+		t.Errorf("admin = %+v", admin)
+	}
+	if !utils.CheckPassword("rahasia123", admin.PasswordHash) {
+		t.Error("password hash does not match SEED_ADMIN_PASSWORD")
+	}
+
+	_, err = service.SeedAdminFromConfig(config.SeedConfig{AdminEmail: "bukan-email", AdminPassword: "pendek"})
+	for _, want := range []string{"SEED_ADMIN_USERNAME wajib diisi", "SEED_ADMIN_EMAIL tidak valid", "SEED_ADMIN_PASSWORD minimal 8 karakter"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want containing %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/service"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStatisticsMatch(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("All students without filter", func(t *testing.T) {
+		match := repository.StatisticsMatch(nil, nil)
+		if _, ok := match["student_id"]; ok {
+			t.Errorf("match = %v, should not restrict student_id", match)
+		}
+		if match["is_deleted"] != false {
+			t.Errorf("match = %v, should exclude deleted", match)
+		}
+	})
+
+	t.Run("Status and inclusive date range", func(t *testing.T) {
+		filter := &models.StatisticsFilter{Status: "verified", DateFrom: &from, DateTo: &to}
+		match := repository.StatisticsMatch([]string{"user-1"}, filter)
+
+		if match["status"] != "verified" {
+			t.Errorf("status = %v, want verified", match["status"])
+		}
+		dateRange, ok := match["date"].(bson.M)
+		if !ok {
+			t.Fatalf("date = %v, want range", match["date"])
+		}
+		if !dateRange["$gte"].(time.Time).Equal(from) {
+			t.Errorf("$gte = %v, want %v", dateRange["$gte"], from)
+		}
+		if !dateRange["$lt"].(time.Time).Equal(to.AddDate(0, 0, 1)) {
+			t.Errorf("$lt = %v, want day after %v", dateRange["$lt"], to)
+		}
+	})
+}
+
+func TestStatisticsFacet_ToMap(t *testing.T) {
+	key := func(s string) *string { return &s }
+	facet := repository.StatisticsFacet{
+		ByStatus: []repository.StatisticsBucket{
+			{Key: key("verified"), Count: 3},
+			{Key: key("submitted"), Count: 2},
+			{Key: key("draft"), Count: 1},
+		},
+		ByCategory: []repository.StatisticsBucket{{Key: key("Kompetisi"), Count: 4}, {Key: key("Penelitian"), Count: 2}},
+		ByLevel:    []repository.StatisticsBucket{{Key: key("Nasional"), Count: 6}},
+		ByPeriod:   []repository.StatisticsBucket{{Key: key("2025-03"), Count: 5}, {Key: nil, Count: 1}},
+	}
+
+	stats := facet.ToMap()
+
+	wantInts := map[string]int{
+		"total_achievements": 6,
+		"total_verified":     3,
+		"total_pending":      2,
+		"total_rejected":     0,
+		"total_draft":        1,
+	}
+	for field, want := range wantInts {
+		if got := stats[field].(int); got != want {
+			t.Errorf("%s = %d, want %d", field, got, want)
+		}
+	}
+
+	if got := stats["category_count"].(map[string]int)["Kompetisi"]; got != 4 {
+		t.Errorf("category_count[Kompetisi] = %d, want 4", got)
+	}
+	periods := stats["period_count"].(map[string]int)
+	if len(periods) != 1 || periods["2025-03"] != 5 {
+		t.Errorf("period_count = %v, want only 2025-03", periods)
+	}
+}
+
+func TestParseStatisticsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"No filter", "", false},
+		{"Status and range", "status=verified&date_from=2025-01-01&date_to=2025-12-31", false},
+		{"Invalid status", "status=approved", true},
+		{"Invalid date", "date_from=01-01-2025", true},
+		{"Reversed range", "date_from=2025-12-31&date_to=2025-01-01", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parseErr error
+			app := fiber.New()
+			app.Get("/statistics", func(c *fiber.Ctx) error {
+				_, parseErr = service.ParseStatisticsFilter(c)
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest("GET", "/statistics?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if (parseErr != nil) != tt.wantErr {
+				t.Errorf("ParseStatisticsFilter() error = %v, wantErr %v", parseErr, tt.wantErr)
+			}
+		})
+	}
+}
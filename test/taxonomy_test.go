@@ -0,0 +1,94 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"testing"
+)
+
+func TestTaxonomyResolve(t *testing.T) {
+	taxonomy := &models.Taxonomy{
+		Categories: []models.AchievementCategory{
+			{ID: "c1", Code: "kompetisi-akademik", Name: "Academic Competition", Aliases: []string{"Lomba Akademik"}},
+			{ID: "c2", Code: "penelitian", Name: "Research", Aliases: []string{"academic competition"}},
+		},
+		Levels: []models.AchievementLevel{
+			{ID: "l1", Code: "nasional", Name: "National", SortOrder: 3, Aliases: []string{"Nasional"}},
+		},
+	}
+
+	categoryTests := []struct {
+		name   string
+		value  string
+		wantID string
+		wantOK bool
+	}{
+		{"Exact name", "Academic Competition", "c1", true},
+		{"Case and whitespace", "  academic   COMPETITION ", "c1", true},
+		{"Code", "penelitian", "c2", true},
+		{"Alias", "lomba akademik", "c1", true},
+		{"Name wins over alias of another category", "academic competition", "c1", true},
+		{"Unknown", "Sports", "", false},
+		{"Empty", "   ", "", false},
+	}
+	for _, tt := range categoryTests {
+		t.Run("Category "+tt.name, func(t *testing.T) {
+			category, ok := taxonomy.ResolveCategory(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("ResolveCategory(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && category.ID != tt.wantID {
+				t.Errorf("ResolveCategory(%q) = %s, want %s", tt.value, category.ID, tt.wantID)
+			}
+		})
+	}
+
+	t.Run("Level alias", func(t *testing.T) {
+		level, ok := taxonomy.ResolveLevel("NASIONAL")
+		if !ok || level.Name != "National" {
+			t.Errorf("ResolveLevel(NASIONAL) = %v, %v, want National", level, ok)
+		}
+	})
+}
+
+func TestCategoryParentCreatesCycle(t *testing.T) {
+	parent := func(id string) *string { return &id }
+	categories := []models.AchievementCategory{
+		{ID: "root"},
+		{ID: "child", ParentID: parent("root")},
+		{ID: "grandchild", ParentID: parent("child")},
+		{ID: "other"},
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		parentID string
+		want     bool
+	}{
+		{"Unrelated parent", "other", "grandchild", false},
+		{"Self as parent", "root", "root", true},
+		{"Descendant as parent", "root", "grandchild", true},
+		{"Ancestor as parent", "grandchild", "root", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := models.CategoryParentCreatesCycle(categories, tt.id, tt.parentID); got != tt.want {
+				t.Errorf("CategoryParentCreatesCycle(%s, %s) = %v, want %v", tt.id, tt.parentID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaxonomyCode(t *testing.T) {
+	tests := map[string]string{
+		"Academic Competition":  "academic-competition",
+		"  Lomba / Kompetisi  ": "lomba-kompetisi",
+		"Research.":             "research",
+		"!!!":                   "",
+	}
+	for name, want := range tests {
+		if got := models.TaxonomyCode(name); got != want {
+			t.Errorf("TaxonomyCode(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
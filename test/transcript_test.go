@@ -0,0 +1,151 @@
+package test
+
+import (
+	"bytes"
+	models "crud-app/app/model"
+	"crud-app/app/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+func transcriptAchievements() []models.AchievementView {
+	verifiedAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	return []models.AchievementView{
+		{
+			Achievement: models.Achievement{
+				AchievementID: "ach-1",
+				Title:         "Juara 1 Lomba Karya Tulis Ilmiah Nasional dengan judul yang cukup panjang sehingga terbagi beberapa baris",
+				Category:      "Kompetisi",
+				Level:         "Nasional",
+				Date:          time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+				Status:        "verified",
+			},
+			VerifiedAt: &verifiedAt,
+		},
+		{
+			Achievement: models.Achievement{AchievementID: "ach-2", Title: "Publikasi Jurnal", Status: "verified"},
+			VerifiedAt:  &verifiedAt,
+		},
+	}
+}
+
+var testTranscriptSecret = []byte("test-transcript-secret")
+
+func transcriptStudent() *models.StudentDetail {
+	return &models.StudentDetail{
+		UserID:       "user-1",
+		StudentID:    "2021001",
+		FullName:     "Budi Santoso",
+		ProgramStudy: "Teknik Informatika",
+		AcademicYear: "2021",
+		AdvisorName:  "Dr. Siti Rahayu",
+	}
+}
+
+func TestTranscriptVerificationCode(t *testing.T) {
+	achievements := transcriptAchievements()
+	code := utils.TranscriptVerificationCode(transcriptStudent(), achievements, testTranscriptSecret)
+
+	t.Run("Formatted code", func(t *testing.T) {
+		if len(code) != 19 || strings.Count(code, "-") != 3 {
+			t.Errorf("code = %q, want XXXX-XXXX-XXXX-XXXX", code)
+		}
+	})
+
+	t.Run("Independent of order", func(t *testing.T) {
+		reversed := []models.AchievementView{achievements[1], achievements[0]}
+		if got := utils.TranscriptVerificationCode(transcriptStudent(), reversed, testTranscriptSecret); got != code {
+			t.Errorf("code = %q, want %q", got, code)
+		}
+	})
+
+	t.Run("Changes with content", func(t *testing.T) {
+		if got := utils.TranscriptVerificationCode(transcriptStudent(), achievements[:1], testTranscriptSecret); got == code {
+			t.Error("different achievements should produce a different code")
+		}
+	})
+
+	// Identitas yang dicetak pada PDF ikut dihitung
+	identity := []struct {
+		name   string
+		change func(*models.StudentDetail)
+	}{
+		{"Student", func(s *models.StudentDetail) { s.UserID = "user-2" }},
+		{"Name", func(s *models.StudentDetail) { s.FullName = "Budi S." }},
+		{"NIM", func(s *models.StudentDetail) { s.StudentID = "2021002" }},
+		{"Program study", func(s *models.StudentDetail) { s.ProgramStudy = "Sistem Informasi" }},
+		{"Advisor", func(s *models.StudentDetail) { s.AdvisorName = "Dr. Andi" }},
+	}
+	for _, tt := range identity {
+		t.Run("Changes with "+tt.name, func(t *testing.T) {
+			student := transcriptStudent()
+			tt.change(student)
+			if got := utils.TranscriptVerificationCode(student, achievements, testTranscriptSecret); got == code {
+				t.Errorf("changing %s should produce a different code", tt.name)
+			}
+		})
+	}
+}
+
+func TestRenderTranscriptPDF(t *testing.T) {
+	student := transcriptStudent()
+
+	tests := []struct {
+		name         string
+		achievements []models.AchievementView
+	}{
+		{"With achievements", transcriptAchievements()},
+		{"Without achievements", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := utils.RenderTranscriptPDF(&buf, &models.Transcript{
+				Student:          student,
+				Achievements:     tt.achievements,
+				VerificationCode: utils.TranscriptVerificationCode(student, tt.achievements, testTranscriptSecret),
+				GeneratedAt:      time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("RenderTranscriptPDF() error = %v", err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+				t.Errorf("output is not a PDF")
+			}
+		})
+	}
+}
+
+func TestNewIssuedTranscript(t *testing.T) {
+	achievements := transcriptAchievements()
+	issuedAt := time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)
+	student := transcriptStudent()
+	transcript := &models.Transcript{
+		Student:          student,
+		Achievements:     achievements,
+		VerificationCode: utils.TranscriptVerificationCode(student, achievements, testTranscriptSecret),
+		GeneratedAt:      issuedAt,
+	}
+
+	issued := models.NewIssuedTranscript(transcript)
+	if issued.VerificationCode != transcript.VerificationCode || issued.StudentID != "user-1" || issued.StudentNumber != "2021001" {
+		t.Errorf("issued = %+v", issued)
+	}
+	if issued.StudentName != "Budi Santoso" || issued.ProgramStudy != "Teknik Informatika" || issued.AdvisorName != "Dr. Siti Rahayu" {
+		t.Errorf("issued identity = %+v", issued)
+	}
+	if !issued.IssuedAt.Equal(issuedAt) {
+		t.Errorf("IssuedAt = %v, want %v", issued.IssuedAt, issuedAt)
+	}
+	if len(issued.Achievements) != 2 || issued.Achievements[0].AchievementID != "ach-1" || issued.Achievements[0].Level != "Nasional" {
+		t.Fatalf("Achievements = %+v", issued.Achievements)
+	}
+
+	// Salinan tidak ikut berubah jika data achievement berubah setelah diterbitkan
+	achievements[0].Title = "Judul baru"
+	if issued.Achievements[0].Title == "Judul baru" {
+		t.Error("issued transcript should keep the title at issue time")
+	}
+}
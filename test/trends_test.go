@@ -0,0 +1,146 @@
+package test
+
+import (
+	models "crud-app/app/model"
+	"crud-app/app/repository"
+	"crud-app/app/service"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTrendPeriod(t *testing.T) {
+	march := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	september := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	january := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		bucket string
+		date   time.Time
+		want   string
+	}{
+		{"Month", repository.TrendBucketMonth, march, "2024-03"},
+		{"Even semester", repository.TrendBucketSemester, march, "2023/2024-Genap"},
+		{"Even semester ends in July", repository.TrendBucketSemester, july, "2023/2024-Genap"},
+		{"Odd semester starts in August", repository.TrendBucketSemester, august, "2024/2025-Ganjil"},
+		{"Odd semester", repository.TrendBucketSemester, september, "2024/2025-Ganjil"},
+		{"Odd semester ends in January", repository.TrendBucketSemester, january, "2024/2025-Ganjil"},
+		{"Year", repository.TrendBucketYear, september, "2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repository.TrendPeriod(tt.bucket, tt.date); got != tt.want {
+				t.Errorf("TrendPeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyYearOverYear(t *testing.T) {
+	groupBy := []string{"program_study"}
+	points := []models.TrendPoint{
+		{Period: "2022/2023-Ganjil", Group: map[string]string{"program_study": "Informatika"}, Total: 4},
+		{Period: "2022/2023-Ganjil", Group: map[string]string{"program_study": "Sistem Informasi"}, Total: 0},
+		{Period: "2023/2024-Ganjil", Group: map[string]string{"program_study": "Informatika"}, Total: 6},
+		{Period: "2023/2024-Ganjil", Group: map[string]string{"program_study": "Sistem Informasi"}, Total: 3},
+		{Period: "2023/2024-Genap", Group: map[string]string{"program_study": "Informatika"}, Total: 2},
+	}
+
+	repository.ApplyYearOverYear(points, groupBy)
+
+	t.Run("Growth against same period last year", func(t *testing.T) {
+		point := points[2]
+		if point.PreviousYearTotal == nil || *point.PreviousYearTotal != 4 {
+			t.Fatalf("PreviousYearTotal = %v, want 4", point.PreviousYearTotal)
+		}
+		if point.YearOverYear == nil || *point.YearOverYear != 50 {
+			t.Errorf("YearOverYear = %v, want 50", point.YearOverYear)
+		}
+	})
+
+	t.Run("Previous total zero has no growth", func(t *testing.T) {
+		point := points[3]
+		if point.PreviousYearTotal == nil || *point.PreviousYearTotal != 0 {
+			t.Fatalf("PreviousYearTotal = %v, want 0", point.PreviousYearTotal)
+		}
+		if point.YearOverYear != nil {
+			t.Errorf("YearOverYear = %v, want nil", *point.YearOverYear)
+		}
+	})
+
+	t.Run("No previous period", func(t *testing.T) {
+		if points[0].PreviousYearTotal != nil || points[4].PreviousYearTotal != nil {
+			t.Errorf("PreviousYearTotal should be nil without a previous period")
+		}
+	})
+}
+
+func TestParseTrendFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantErr     bool
+		wantBucket  string
+		wantGroupBy int
+	}{
+		{"Defaults", "", false, "month", 0},
+		{"Semester by cohort", "bucket=semester&group_by=program_study,academic_year", false, "semester", 2},
+		{"Duplicate dimension", "bucket=year&group_by=level,level", false, "year", 1},
+		{"Invalid bucket", "bucket=week", true, "", 0},
+		{"Invalid dimension", "group_by=student_name", true, "", 0},
+		{"Invalid status", "status=approved", true, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter *models.TrendFilter
+			var parseErr error
+			app := fiber.New()
+			app.Get("/trends", func(c *fiber.Ctx) error {
+				filter, parseErr = service.ParseTrendFilter(c)
+				return nil
+			})
+			if _, err := app.Test(httptest.NewRequest("GET", "/trends?"+tt.query, nil)); err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if (parseErr != nil) != tt.wantErr {
+				t.Fatalf("ParseTrendFilter() error = %v, wantErr %v", parseErr, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if filter.Bucket != tt.wantBucket || len(filter.GroupBy) != tt.wantGroupBy {
+				t.Errorf("filter = %+v, want bucket %v with %d dimensions", filter, tt.wantBucket, tt.wantGroupBy)
+			}
+		})
+	}
+}
+
+func TestTrendsPipeline_GroupByAdvisorID(t *testing.T) {
+	pipeline := repository.TrendsPipeline(bson.M{}, []string{"advisor"}, repository.TrendBucketMonth)
+
+	group, ok := pipeline[1][0].Value.(bson.M)
+	if !ok || pipeline[1][0].Key != "$group" {
+		t.Fatalf("pipeline[1] = %v, want $group stage", pipeline[1])
+	}
+	id := group["_id"].(bson.D)
+	if len(id) != 2 || id[1].Key != "advisor" || id[1].Value != "$advisor_id" {
+		t.Errorf("_id = %v, want advisor grouped by $advisor_id", id)
+	}
+	if name, ok := group[repository.TrendAdvisorNameKey].(bson.M); !ok || name["$max"] != "$advisor_name" {
+		t.Errorf("%s = %v, want advisor name projected from $advisor_name", repository.TrendAdvisorNameKey, group[repository.TrendAdvisorNameKey])
+	}
+
+	without := repository.TrendsPipeline(bson.M{}, []string{"level"}, repository.TrendBucketMonth)
+	if _, ok := without[1][0].Value.(bson.M)[repository.TrendAdvisorNameKey]; ok {
+		t.Error("advisor name should only be projected when grouping by advisor")
+	}
+}
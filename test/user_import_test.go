@@ -0,0 +1,239 @@
+package test
+
+import (
+	"bytes"
+	models "crud-app/app/model"
+	"crud-app/app/service"
+	"crud-app/app/utils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadTable_RoundTrip(t *testing.T) {
+	for _, format := range []string{utils.ExportFormatCSV, utils.ExportFormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			table, err := utils.NewTableWriter(format, &buf)
+			if err != nil {
+				t.Fatalf("NewTableWriter() error = %v", err)
+			}
+			table.WriteRow("username", "email", "full_name")
+			table.WriteRow("budi", "budi@example.com", "Budi & <Santoso>")
+			table.WriteRow("ani", "", "Ani")
+			if err := table.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			records, err := utils.ReadTable(format, buf.Bytes())
+			if err != nil {
+				t.Fatalf("ReadTable() error = %v", err)
+			}
+			want := [][]string{
+				{"username", "email", "full_name"},
+				{"budi", "budi@example.com", "Budi & <Santoso>"},
+				{"ani", "", "Ani"},
+			}
+			if !reflect.DeepEqual(records, want) {
+				t.Errorf("ReadTable() = %q, want %q", records, want)
+			}
+		})
+	}
+}
+
+func TestReadTable_CSVSemicolonAndBOM(t *testing.T) {
+	records, err := utils.ReadTable(utils.ExportFormatCSV, []byte("\xef\xbb\xbfusername;email\nbudi;budi@example.com\n"))
+	if err != nil {
+		t.Fatalf("ReadTable() error = %v", err)
+	}
+	want := [][]string{{"username", "email"}, {"budi", "budi@example.com"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("ReadTable() = %q, want %q", records, want)
+	}
+}
+
+func TestReadTable_InvalidXLSX(t *testing.T) {
+	if _, err := utils.ReadTable(utils.ExportFormatXLSX, []byte("bukan zip")); err == nil {
+		t.Error("expected error for invalid XLSX")
+	}
+}
+
+func TestParseUserImportTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   [][]string
+		want    []models.UserImportRow
+		wantErr string
+	}{
+		{
+			name: "headers case-insensitive and blank rows skipped",
+			table: [][]string{
+				{"Username", " EMAIL ", "full_name", "role", "is_active", "student_id"},
+				{"budi", "budi@example.com", "Budi", "Mahasiswa", "", "123"},
+				{"", "", "", "", "", ""},
+				{"ani", "ani@example.com", "Ani", "2", "tidak"},
+			},
+			want: []models.UserImportRow{
+				{Row: 2, Username: "budi", Email: "budi@example.com", FullName: "Budi", Role: "Mahasiswa", IsActive: true, StudentID: "123"},
+				{Row: 4, Username: "ani", Email: "ani@example.com", FullName: "Ani", Role: "2", IsActive: false},
+			},
+		},
+		{
+			name: "invalid is_active reported on row",
+			table: [][]string{
+				{"username", "email", "full_name", "role", "is_active"},
+				{"budi", "budi@example.com", "Budi", "3", "maybe"},
+			},
+			want: []models.UserImportRow{
+				{Row: 2, Username: "budi", Email: "budi@example.com", FullName: "Budi", Role: "3", IsActive: true, Errors: []string{`is_active "maybe" tidak valid`}},
+			},
+		},
+		{
+			name:    "missing required column",
+			table:   [][]string{{"username", "email", "full_name"}},
+			wantErr: `kolom wajib "role"`,
+		},
+		{
+			name:    "unknown column",
+			table:   [][]string{{"username", "email", "full_name", "role", "password"}},
+			wantErr: `kolom "password" tidak dikenal`,
+		},
+		{
+			name:    "empty file",
+			table:   nil,
+			wantErr: "kosong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := service.ParseUserImportTable(tt.table)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUserImportTable_MaxRows(t *testing.T) {
+	table := [][]string{{"username", "email", "full_name", "role"}}
+	for i := 0; i <= service.UserImportMaxRows; i++ {
+		table = append(table, []string{"u", "u@example.com", "U", "3"})
+	}
+	if _, err := service.ParseUserImportTable(table); err == nil {
+		t.Error("expected error when exceeding max rows")
+	}
+}
+
+func TestValidateUserImportRows(t *testing.T) {
+	lookup := &models.UserImportLookup{
+		Roles:               map[string]string{"1": "1", "admin": "1", "2": "2", "dosen wali": "2", "3": "3", "mahasiswa": "3"},
+		ExistingUsernames:   map[string]bool{"taken": true},
+		ExistingEmails:      map[string]bool{"taken@example.com": true},
+		ExistingStudentIDs:  map[string]bool{"111": true},
+		ExistingLecturerIDs: map[string]bool{"nip-lama": true},
+		Lecturers:           map[string]string{"nip-lama": "lecturer-user-id"},
+	}
+	rows := []models.UserImportRow{
+		{Row: 2, Username: "dosen1", Email: "dosen1@example.com", FullName: "Dosen", Role: "Dosen Wali", LecturerID: "NIP-BARU"},
+		{Row: 3, Username: "mhs1", Email: "mhs1@example.com", FullName: "Mhs", Role: "mahasiswa", StudentID: "222", AdvisorNIP: "nip-baru"},
+		{Row: 4, Username: "mhs2", Email: "mhs2@example.com", FullName: "Mhs", Role: "3", StudentID: "333", AdvisorNIP: "NIP-LAMA"},
+		{Row: 5, Username: "Taken", Email: "TAKEN@example.com", FullName: "X", Role: "3", StudentID: "111"},
+		{Row: 6, Username: "MHS1", Email: "bukan-email", FullName: "", Role: "superuser"},
+		{Row: 7, Username: "admin2", Email: "admin2@example.com", FullName: "Admin", Role: "admin", Department: "TI"},
+		{Row: 8, Username: "mhs3", Email: "mhs3@example.com", FullName: "Mhs", Role: "3", StudentID: "444", AdvisorNIP: "tidak-ada"},
+	}
+
+	service.ValidateUserImportRows(rows, lookup)
+
+	wantErrors := map[int][]string{
+		2: nil,
+		3: nil,
+		4: nil,
+		5: {`username "Taken" sudah digunakan`, `email "TAKEN@example.com" sudah digunakan`, `student_id "111" sudah digunakan`},
+		6: {`username "MHS1" duplikat dengan baris 3`, `email "bukan-email" tidak valid`, "full_name wajib diisi", `role "superuser" tidak dikenal`},
+		7: {"kolom profil dosen hanya untuk role dosen", "lecturer_id wajib diisi jika department diisi"},
+		8: {`dosen wali dengan NIP "tidak-ada" tidak ditemukan`},
+	}
+	for _, row := range rows {
+		if !reflect.DeepEqual(row.Errors, wantErrors[row.Row]) {
+			t.Errorf("row %d errors = %q, want %q", row.Row, row.Errors, wantErrors[row.Row])
+		}
+	}
+
+	if rows[0].RoleID != "2" || rows[1].RoleID != "3" {
+		t.Errorf("role IDs = %q, %q, want 2, 3", rows[0].RoleID, rows[1].RoleID)
+	}
+	// Dosen wali di file yang sama di-resolve saat import, dosen lama langsung dari database
+	if rows[1].AdvisorID != "" {
+		t.Errorf("advisor in same file should be resolved on import, got %q", rows[1].AdvisorID)
+	}
+	if rows[2].AdvisorID != "lecturer-user-id" {
+		t.Errorf("advisor_id = %q, want lecturer-user-id", rows[2].AdvisorID)
+	}
+}
+
+func TestValidateUserImportRows_InvalidAdvisorRow(t *testing.T) {
+	lookup := &models.UserImportLookup{
+		Roles:               map[string]string{"2": "2", "3": "3"},
+		ExistingUsernames:   map[string]bool{"taken": true},
+		ExistingEmails:      map[string]bool{},
+		ExistingStudentIDs:  map[string]bool{},
+		ExistingLecturerIDs: map[string]bool{},
+		Lecturers:           map[string]string{},
+	}
+	rows := []models.UserImportRow{
+		{Row: 2, Username: "taken", Email: "d@example.com", FullName: "D", Role: "2", LecturerID: "NIP1"},
+		{Row: 3, Username: "mhs", Email: "m@example.com", FullName: "M", Role: "3", StudentID: "1", AdvisorNIP: "NIP1"},
+	}
+
+	service.ValidateUserImportRows(rows, lookup)
+
+	want := []string{`dosen wali dengan NIP "NIP1" pada baris 2 tidak valid`}
+	if !reflect.DeepEqual(rows[1].Errors, want) {
+		t.Errorf("errors = %q, want %q", rows[1].Errors, want)
+	}
+}
+
+func TestCredentialsEncryption(t *testing.T) {
+	plaintext := []byte("username,password\nbudi,rahasia\n")
+	secret := []byte("test-credentials-secret")
+
+	sealed, err := utils.EncryptCredentials(plaintext, secret)
+	if err != nil {
+		t.Fatalf("EncryptCredentials() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("rahasia")) {
+		t.Error("ciphertext contains plaintext password")
+	}
+	other, _ := utils.EncryptCredentials(plaintext, secret)
+	if bytes.Equal(sealed, other) {
+		t.Error("encryption should use a random nonce")
+	}
+
+	opened, err := utils.DecryptCredentials(sealed, secret)
+	if err != nil {
+		t.Fatalf("DecryptCredentials() error = %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("DecryptCredentials() = %q, want %q", opened, plaintext)
+	}
+
+	if _, err := utils.DecryptCredentials(sealed, []byte("other-secret")); err == nil {
+		t.Error("expected error for wrong secret")
+	}
+
+	sealed[len(sealed)-1] ^= 0xff
+	if _, err := utils.DecryptCredentials(sealed, secret); err == nil {
+		t.Error("expected error for tampered ciphertext")
+	}
+}